@@ -17,6 +17,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 	"github.com/gruntwork-io/go-commons/errors"
 	"github.com/gruntwork-io/terragrunt/options"
 )
@@ -98,7 +99,7 @@ func CreateAwsSessionFromConfig(config *AwsSessionConfig, terragruntOptions *opt
 		)
 	}
 
-	if iamRoleOptions.WebIdentityToken != "" && iamRoleOptions.RoleARN != "" {
+	if hasWebIdentityToken(iamRoleOptions) && iamRoleOptions.RoleARN != "" {
 		sess.Config.Credentials = getWebIdentityCredentialsFromIAMRoleOptions(sess, iamRoleOptions)
 		return sess, nil
 	}
@@ -145,7 +146,12 @@ func getWebIdentityCredentialsFromIAMRoleOptions(sess *session.Session, iamRoleO
 	}
 
 	svc := sts.New(sess)
-	p := stscreds.NewWebIdentityRoleProviderWithOptions(svc, iamRoleOptions.RoleARN, roleSessionName, tokenFetcher(iamRoleOptions.WebIdentityToken))
+	var fetcher stscreds.TokenFetcher = tokenFetcher(iamRoleOptions.WebIdentityToken)
+	if iamRoleOptions.WebIdentityTokenFile != "" {
+		fetcher = stscreds.FetchTokenPath(iamRoleOptions.WebIdentityTokenFile)
+	}
+
+	p := stscreds.NewWebIdentityRoleProviderWithOptions(svc, iamRoleOptions.RoleARN, roleSessionName, fetcher)
 
 	if iamRoleOptions.AssumeRoleDuration > 0 {
 		p.Duration = time.Second * time.Duration(iamRoleOptions.AssumeRoleDuration)
@@ -210,7 +216,7 @@ func CreateAwsSession(config *AwsSessionConfig, terragruntOptions *options.Terra
 		sess.Handlers.Build.PushFrontNamed(addUserAgent)
 
 		if terragruntOptions.IAMRoleOptions.RoleARN != "" {
-			if terragruntOptions.IAMRoleOptions.WebIdentityToken != "" {
+			if hasWebIdentityToken(terragruntOptions.IAMRoleOptions) {
 				terragruntOptions.Logger.Debugf("Assuming role %s using WebIdentity token", terragruntOptions.IAMRoleOptions.RoleARN)
 				sess.Config.Credentials = getWebIdentityCredentialsFromIAMRoleOptions(sess, terragruntOptions.IAMRoleOptions)
 			} else {
@@ -250,7 +256,7 @@ func AssumeIamRole(iamRoleOpts options.IAMRoleOptions) (*sts.Credentials, error)
 
 	sess.Handlers.Build.PushFrontNamed(addUserAgent)
 
-	if iamRoleOpts.RoleARN != "" && iamRoleOpts.WebIdentityToken != "" {
+	if iamRoleOpts.RoleARN != "" && hasWebIdentityToken(iamRoleOpts) {
 		sess.Config.Credentials = getWebIdentityCredentialsFromIAMRoleOptions(sess, iamRoleOpts)
 	}
 
@@ -261,62 +267,96 @@ func AssumeIamRole(iamRoleOpts options.IAMRoleOptions) (*sts.Credentials, error)
 
 	stsClient := sts.New(sess)
 
-	sessionName := options.GetDefaultIAMAssumeRoleSessionName()
-	if iamRoleOpts.AssumeRoleSessionName != "" {
-		sessionName = iamRoleOpts.AssumeRoleSessionName
+	if hasWebIdentityToken(iamRoleOpts) {
+		return AssumeIamRoleWithWebIdentity(stsClient, iamRoleOpts)
 	}
 
-	sessionDurationSeconds := int64(options.DefaultIAMAssumeRoleDuration)
-	if iamRoleOpts.AssumeRoleDuration != 0 {
-		sessionDurationSeconds = iamRoleOpts.AssumeRoleDuration
+	// Use regular sts AssumeRole
+	input := sts.AssumeRoleInput{
+		RoleArn:         aws.String(iamRoleOpts.RoleARN),
+		RoleSessionName: aws.String(assumeRoleSessionName(iamRoleOpts)),
+		DurationSeconds: aws.Int64(assumeRoleDuration(iamRoleOpts)),
 	}
 
-	if iamRoleOpts.WebIdentityToken == "" {
-		// Use regular sts AssumeRole
-		input := sts.AssumeRoleInput{
-			RoleArn:         aws.String(iamRoleOpts.RoleARN),
-			RoleSessionName: aws.String(sessionName),
-			DurationSeconds: aws.Int64(sessionDurationSeconds),
-		}
-
-		output, err := stsClient.AssumeRole(&input)
-		if err != nil {
-			return nil, errors.WithStackTrace(err)
-		}
-
-		return output.Credentials, nil
+	output, err := stsClient.AssumeRole(&input)
+	if err != nil {
+		return nil, errors.WithStackTrace(err)
 	}
 
-	// Use sts AssumeRoleWithWebIdentity
-	var token string
-	// Check if value is a raw token or a path to a file with a token
-	if _, err := os.Stat(iamRoleOpts.WebIdentityToken); err != nil {
-		token = iamRoleOpts.WebIdentityToken
-	} else {
-		tb, err := os.ReadFile(iamRoleOpts.WebIdentityToken)
-		if err != nil {
-			return nil, errors.WithStackTrace(err)
-		}
+	return output.Credentials, nil
+}
 
-		token = string(tb)
+// AssumeIamRoleWithWebIdentity calls sts:AssumeRoleWithWebIdentity using the token from the file set in
+// WebIdentityTokenFile or, if not set, from WebIdentityToken, which can be either a raw token or a path to a file.
+func AssumeIamRoleWithWebIdentity(stsClient stsiface.STSAPI, iamRoleOpts options.IAMRoleOptions) (*sts.Credentials, error) {
+	token, err := webIdentityToken(iamRoleOpts)
+	if err != nil {
+		return nil, err
 	}
 
 	input := sts.AssumeRoleWithWebIdentityInput{
 		RoleArn:          aws.String(iamRoleOpts.RoleARN),
-		RoleSessionName:  aws.String(sessionName),
+		RoleSessionName:  aws.String(assumeRoleSessionName(iamRoleOpts)),
 		WebIdentityToken: aws.String(token),
-		DurationSeconds:  aws.Int64(sessionDurationSeconds),
+		DurationSeconds:  aws.Int64(assumeRoleDuration(iamRoleOpts)),
 	}
-	req, resp := stsClient.AssumeRoleWithWebIdentityRequest(&input)
+
 	// InvalidIdentityToken error is a temporary error that can occur
 	// when assuming an Role with a JWT web identity token.
 	// N.B: copied from SDK implementation
-	req.RetryErrorCodes = append(req.RetryErrorCodes, sts.ErrCodeInvalidIdentityTokenException)
-	if err := req.Send(); err != nil {
+	retryInvalidToken := func(req *request.Request) {
+		req.RetryErrorCodes = append(req.RetryErrorCodes, sts.ErrCodeInvalidIdentityTokenException)
+	}
+
+	output, err := stsClient.AssumeRoleWithWebIdentityWithContext(aws.BackgroundContext(), &input, retryInvalidToken)
+	if err != nil {
 		return nil, errors.WithStackTrace(err)
 	}
 
-	return resp.Credentials, nil
+	return output.Credentials, nil
+}
+
+func hasWebIdentityToken(iamRoleOpts options.IAMRoleOptions) bool {
+	return iamRoleOpts.WebIdentityToken != "" || iamRoleOpts.WebIdentityTokenFile != ""
+}
+
+func webIdentityToken(iamRoleOpts options.IAMRoleOptions) (string, error) {
+	if iamRoleOpts.WebIdentityTokenFile != "" {
+		token, err := os.ReadFile(iamRoleOpts.WebIdentityTokenFile)
+		if err != nil {
+			return "", errors.WithStackTrace(err)
+		}
+
+		return string(token), nil
+	}
+
+	// Check if value is a raw token or a path to a file with a token
+	if _, err := os.Stat(iamRoleOpts.WebIdentityToken); err != nil {
+		return iamRoleOpts.WebIdentityToken, nil
+	}
+
+	token, err := os.ReadFile(iamRoleOpts.WebIdentityToken)
+	if err != nil {
+		return "", errors.WithStackTrace(err)
+	}
+
+	return string(token), nil
+}
+
+func assumeRoleSessionName(iamRoleOpts options.IAMRoleOptions) string {
+	if iamRoleOpts.AssumeRoleSessionName != "" {
+		return iamRoleOpts.AssumeRoleSessionName
+	}
+
+	return options.GetDefaultIAMAssumeRoleSessionName()
+}
+
+func assumeRoleDuration(iamRoleOpts options.IAMRoleOptions) int64 {
+	if iamRoleOpts.AssumeRoleDuration != 0 {
+		return iamRoleOpts.AssumeRoleDuration
+	}
+
+	return int64(options.DefaultIAMAssumeRoleDuration)
 }
 
 // GetAWSCallerIdentity returns the AWS caller identity associated with the current set of credentials
@@ -0,0 +1,56 @@
+package awshelper_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/gruntwork-io/terragrunt/awshelper"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSTSClient struct {
+	stsiface.STSAPI
+	input *sts.AssumeRoleWithWebIdentityInput
+}
+
+func (client *mockSTSClient) AssumeRoleWithWebIdentityWithContext(_ aws.Context, input *sts.AssumeRoleWithWebIdentityInput, _ ...request.Option) (*sts.AssumeRoleWithWebIdentityOutput, error) {
+	client.input = input
+
+	return &sts.AssumeRoleWithWebIdentityOutput{
+		Credentials: &sts.Credentials{
+			AccessKeyId:     aws.String("access-key-id"),
+			SecretAccessKey: aws.String("secret-access-key"),
+			SessionToken:    aws.String("session-token"),
+		},
+	}, nil
+}
+
+func TestAssumeIamRoleWithWebIdentityTokenFile(t *testing.T) {
+	t.Parallel()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("eks-web-identity-token"), 0600))
+
+	client := &mockSTSClient{}
+
+	creds, err := awshelper.AssumeIamRoleWithWebIdentity(client, options.IAMRoleOptions{
+		RoleARN:               "arn:aws:iam::123456789012:role/test",
+		WebIdentityTokenFile:  tokenFile,
+		AssumeRoleSessionName: "test-session",
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, client.input)
+	assert.Equal(t, "eks-web-identity-token", aws.StringValue(client.input.WebIdentityToken))
+	assert.Equal(t, "arn:aws:iam::123456789012:role/test", aws.StringValue(client.input.RoleArn))
+	assert.Equal(t, "test-session", aws.StringValue(client.input.RoleSessionName))
+	assert.Equal(t, int64(options.DefaultIAMAssumeRoleDuration), aws.Int64Value(client.input.DurationSeconds))
+	assert.Equal(t, "access-key-id", aws.StringValue(creds.AccessKeyId))
+}
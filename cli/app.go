@@ -37,11 +37,13 @@ import (
 	"github.com/gruntwork-io/terragrunt/cli/commands"
 	awsproviderpatch "github.com/gruntwork-io/terragrunt/cli/commands/aws-provider-patch"
 	"github.com/gruntwork-io/terragrunt/cli/commands/catalog"
+	engineCmd "github.com/gruntwork-io/terragrunt/cli/commands/engine"
 	graphdependencies "github.com/gruntwork-io/terragrunt/cli/commands/graph-dependencies"
 	"github.com/gruntwork-io/terragrunt/cli/commands/hclfmt"
 	outputmodulegroups "github.com/gruntwork-io/terragrunt/cli/commands/output-module-groups"
 	renderjson "github.com/gruntwork-io/terragrunt/cli/commands/render-json"
 	runall "github.com/gruntwork-io/terragrunt/cli/commands/run-all"
+	shellCmd "github.com/gruntwork-io/terragrunt/cli/commands/shell"
 	terraformCmd "github.com/gruntwork-io/terragrunt/cli/commands/terraform"
 	terragruntinfo "github.com/gruntwork-io/terragrunt/cli/commands/terragrunt-info"
 	validateinputs "github.com/gruntwork-io/terragrunt/cli/commands/validate-inputs"
@@ -96,19 +98,17 @@ func (app *App) RunContext(ctx context.Context, args []string) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	shell.RegisterSignalHandler(func(signal os.Signal) {
-		app.opts.Logger.Infof("%s signal received. Gracefully shutting down... (it can take up to %v)", cases.Title(language.English).String(signal.String()), shell.SignalForwardingDelay)
-		cancel()
+	// the signals are handled once the flags, which can change `SignalsToForward`, are parsed
+	before := app.Before
+	app.Before = func(cliCtx *cli.Context) error {
+		app.registerSignalHandler(cancel)
 
-		shell.RegisterSignalHandler(func(signal os.Signal) {
-			app.opts.Logger.Infof("Second %s signal received, force shutting down...", cases.Title(language.English).String(signal.String()))
-			os.Exit(1)
-		})
+		if before != nil {
+			return before(cliCtx)
+		}
 
-		time.Sleep(forceExitInterval)
-		app.opts.Logger.Infof("Failed to gracefully shutdown within %v, force shutting down...", forceExitInterval)
-		os.Exit(1)
-	}, shell.InterruptSignals...)
+		return nil
+	}
 
 	// configure telemetry integration
 	err := telemetry.InitTelemetry(ctx, &telemetry.TelemetryOptions{
@@ -130,16 +130,22 @@ func (app *App) RunContext(ctx context.Context, args []string) error {
 	ctx = config.WithConfigValues(ctx)
 
 	// init engine if required
-	if engine.IsEngineEnabled() {
+	if engine.IsEngineEnabled(ctx, app.opts) {
 		ctx = engine.WithEngineValues(ctx)
 	}
 
 	defer func(ctx context.Context) {
-		if err := engine.Shutdown(ctx); err != nil {
+		if err := engine.Shutdown(ctx, app.opts); err != nil {
 			_, _ = app.ErrWriter.Write([]byte(err.Error()))
 		}
 	}(ctx)
 
+	defer func() {
+		if err := writeCommandSummary(app.opts); err != nil {
+			_, _ = app.ErrWriter.Write([]byte(err.Error()))
+		}
+	}()
+
 	if err := app.App.RunContext(ctx, args); err != nil && !goerrors.Is(err, context.Canceled) {
 		return err
 	}
@@ -147,6 +153,41 @@ func (app *App) RunContext(ctx context.Context, args []string) error {
 	return nil
 }
 
+// registerSignalHandler gracefully shuts down Terragrunt when one of the `SignalsToForward` is received. The default
+// signals that are not forwarded are caught without shutting down, so that the running commands handle them on their
+// own, e.g. `Ctrl+C` which the terminal sends to them as well.
+func (app *App) registerSignalHandler(cancel context.CancelFunc) {
+	if len(app.opts.SignalsToForward) > 0 {
+		shell.RegisterSignalHandler(func(signal os.Signal) {
+			app.opts.Logger.Infof("%s signal received. Gracefully shutting down... (it can take up to %v)", cases.Title(language.English).String(signal.String()), shell.SignalForwardingDelay)
+			cancel()
+
+			shell.RegisterSignalHandler(func(signal os.Signal) {
+				app.opts.Logger.Infof("Second %s signal received, force shutting down...", cases.Title(language.English).String(signal.String()))
+				os.Exit(1)
+			})
+
+			time.Sleep(forceExitInterval)
+			app.opts.Logger.Infof("Failed to gracefully shutdown within %v, force shutting down...", forceExitInterval)
+			os.Exit(1)
+		}, app.opts.SignalsToForward...)
+	}
+
+	var notForwarded []os.Signal
+
+	for _, signal := range options.DefaultSignalsToForward {
+		if !util.ListContainsElement(app.opts.SignalsToForward, signal) {
+			notForwarded = append(notForwarded, signal)
+		}
+	}
+
+	if len(notForwarded) > 0 {
+		shell.CatchSignals(func(signal os.Signal) {
+			app.opts.Logger.Debugf("%s signal received, it is not forwarded", cases.Title(language.English).String(signal.String()))
+		}, notForwarded...)
+	}
+}
+
 // TerragruntCommands returns the set of Terragrunt commands.
 func TerragruntCommands(opts *options.TerragruntOptions) cli.Commands {
 	cmds := cli.Commands{
@@ -162,6 +203,8 @@ func TerragruntCommands(opts *options.TerragruntOptions) cli.Commands {
 		scaffold.NewCommand(opts),           // scaffold
 		graph.NewCommand(opts),              // graph
 		hclvalidate.NewCommand(opts),        // hclvalidate
+		shellCmd.NewCommand(opts),           // shell
+		engineCmd.NewCommand(opts),          // engine
 	}
 
 	sort.Sort(cmds)
@@ -337,11 +380,28 @@ func initialSetup(cliCtx *cli.Context, opts *options.TerragruntOptions) error {
 
 	opts.TerraformPath = filepath.ToSlash(opts.TerraformPath)
 
-	opts.ExcludeDirs, err = util.GlobCanonicalPath(opts.WorkingDir, opts.ExcludeDirs...)
+	excludeDirs := opts.ExcludeDirs
+
+	opts.ExcludeDirs, err = util.GlobCanonicalPath(opts.WorkingDir, excludeDirs...)
 	if err != nil {
 		return err
 	}
 
+	// Keep the glob patterns as well, so that they also match the modules of the directories that don't exist yet or
+	// are nested deeper than the expanded paths, e.g. `**/experimental/**`.
+	for _, excludeDir := range excludeDirs {
+		if !util.IsGlobPattern(excludeDir) {
+			continue
+		}
+
+		pattern, err := util.CanonicalPath(excludeDir, opts.WorkingDir)
+		if err != nil {
+			return err
+		}
+
+		opts.ExcludeDirs = append(opts.ExcludeDirs, pattern)
+	}
+
 	if len(opts.IncludeDirs) > 0 {
 		opts.Logger.Debugf("Included directories set. Excluding by default.")
 		opts.ExcludeByDefault = true
@@ -352,7 +412,7 @@ func initialSetup(cliCtx *cli.Context, opts *options.TerragruntOptions) error {
 		return err
 	}
 
-	excludeDirs, err := util.GetExcludeDirsFromFile(opts.WorkingDir, opts.ExcludesFile)
+	excludeDirs, err = util.GetExcludeDirsFromFile(opts.WorkingDir, opts.ExcludesFile)
 	if err != nil {
 		return err
 	}
@@ -389,6 +449,17 @@ func initialSetup(cliCtx *cli.Context, opts *options.TerragruntOptions) error {
 	return nil
 }
 
+// writeCommandSummary writes the summary of all the commands run during the session if --terragrunt-command-summary-file is set.
+func writeCommandSummary(opts *options.TerragruntOptions) error {
+	if opts.CommandSummaryFile == "" || opts.CommandSummary == nil {
+		return nil
+	}
+
+	opts.Logger.Debugf("Writing command summary to %s", opts.CommandSummaryFile)
+
+	return opts.CommandSummary.WriteFile(opts.CommandSummaryFile)
+}
+
 func OSExiter(exitCode int) {
 	// Do nothing. We just need to override this function, as the default value calls os.Exit, which
 	// kills the app (or any automated test) dead in its tracks.
@@ -401,6 +401,47 @@ func TestTerragruntVersion(t *testing.T) {
 	}
 }
 
+func TestTerraformPathPrecedence(t *testing.T) {
+	t.Setenv(options.TerraformPathEnvName, "/env/tofu")
+
+	testCases := []struct {
+		args         []string
+		expectedPath string
+	}{
+		{[]string{"plan"}, "/env/tofu"},
+		{[]string{"plan", doubleDashed(commands.TerragruntTFPathFlagName), "/flag/tofu"}, "/flag/tofu"},
+	}
+
+	for _, testCase := range testCases {
+		opts := options.NewTerragruntOptions()
+		assert.Equal(t, "/env/tofu", opts.TerraformPath)
+
+		opts, err := runAppTest(testCase.args, opts)
+		require.NoError(t, err, testCase)
+
+		assert.Equal(t, testCase.expectedPath, opts.TerraformPath, testCase)
+	}
+}
+
+func TestEngineCachePathPrecedence(t *testing.T) {
+	t.Setenv(commands.TerragruntEngineCachePathEnvName, "/env/cache")
+
+	testCases := []struct {
+		args         []string
+		expectedPath string
+	}{
+		{[]string{"plan"}, "/env/cache"},
+		{[]string{"plan", doubleDashed(commands.TerragruntEngineCachePathFlagName), "/flag/cache"}, "/flag/cache"},
+	}
+
+	for _, testCase := range testCases {
+		opts, err := runAppTest(testCase.args, options.NewTerragruntOptions())
+		require.NoError(t, err, testCase)
+
+		assert.Equal(t, testCase.expectedPath, opts.EngineCachePath, testCase)
+	}
+}
+
 func TestTerragruntHelp(t *testing.T) {
 	t.Parallel()
 
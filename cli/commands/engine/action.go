@@ -0,0 +1,57 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gruntwork-io/go-commons/errors"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+// RunListVersions prints the available versions of the engine source, one per line, newest first.
+func RunListVersions(ctx context.Context, opts *options.TerragruntOptions, source string) error {
+	if source == "" {
+		return errors.WithStackTrace(MissingSourceError{})
+	}
+
+	versions, err := engine.ListAvailableVersions(ctx, opts, source)
+	if err != nil {
+		return err
+	}
+
+	for _, version := range versions {
+		if _, err := fmt.Fprintln(opts.Writer, version); err != nil {
+			return errors.WithStackTrace(err)
+		}
+	}
+
+	return nil
+}
+
+// RunUpdateLock downloads the engine set in the `engine` block of the module and records it in the engine lock file,
+// replacing the previously locked version.
+func RunUpdateLock(ctx context.Context, opts *options.TerragruntOptions) error {
+	terragruntConfig, err := config.ReadTerragruntConfig(ctx, opts, config.DefaultParserOptions(opts))
+	if err != nil {
+		return err
+	}
+
+	engineOptions, err := terragruntConfig.EngineOptions()
+	if err != nil {
+		return err
+	}
+
+	if engineOptions == nil {
+		return errors.WithStackTrace(MissingEngineBlockError{ConfigPath: opts.TerragruntConfigPath})
+	}
+
+	opts.Engine = engineOptions
+
+	// the lock is updated even if the engine is not enabled with TG_EXPERIMENTAL_ENGINE
+	ctx = engine.WithEngineValues(engine.WithEngineEnabled(ctx, true))
+
+	return engine.UpdateEngineLock(ctx, opts)
+}
@@ -0,0 +1,55 @@
+// Package engine provides the `engine` command to work with Terragrunt engines, e.g. to list the available versions
+// of an engine before pinning one in the `engine` block.
+package engine
+
+import (
+	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/cli"
+)
+
+const (
+	CommandName            = "engine"
+	SubCommandListVersions = "list-versions"
+	SubCommandUpdateLock   = "update-lock"
+
+	SourceFlagName = "source"
+)
+
+func NewCommand(opts *options.TerragruntOptions) *cli.Command {
+	return &cli.Command{
+		Name:  CommandName,
+		Usage: "Work with Terragrunt engines.",
+		Subcommands: cli.Commands{
+			newListVersionsCommand(opts),
+			newUpdateLockCommand(opts),
+		},
+	}
+}
+
+func newListVersionsCommand(opts *options.TerragruntOptions) *cli.Command {
+	var source string
+
+	return &cli.Command{
+		Name:                   SubCommandListVersions,
+		Usage:                  "List the available versions of the engine published in the given source repository, newest first.",
+		DisallowUndefinedFlags: true,
+		Flags: cli.Flags{
+			&cli.GenericFlag[string]{
+				Name:        SourceFlagName,
+				Destination: &source,
+				Usage:       "Engine source repository, e.g. github.com/gruntwork-io/terragrunt-engine-opentofu.",
+			},
+		},
+		Action: func(ctx *cli.Context) error { return RunListVersions(ctx, opts.OptionsFromContext(ctx), source) },
+	}
+}
+
+func newUpdateLockCommand(opts *options.TerragruntOptions) *cli.Command {
+	return &cli.Command{
+		Name:                   SubCommandUpdateLock,
+		Usage:                  "Download the engine of the module and record its version and SHA-256 hash in the " + engine.EngineLockFileName + " file.",
+		DisallowUndefinedFlags: true,
+		Action:                 func(ctx *cli.Context) error { return RunUpdateLock(ctx, opts.OptionsFromContext(ctx)) },
+	}
+}
@@ -0,0 +1,17 @@
+package engine
+
+import "fmt"
+
+type MissingSourceError struct{}
+
+func (err MissingSourceError) Error() string {
+	return "Missing engine source, set it with --" + SourceFlagName + " (Example: terragrunt engine list-versions --source github.com/gruntwork-io/terragrunt-engine-opentofu)"
+}
+
+type MissingEngineBlockError struct {
+	ConfigPath string
+}
+
+func (err MissingEngineBlockError) Error() string {
+	return fmt.Sprintf("No engine block found in %s, there is no engine to lock", err.ConfigPath)
+}
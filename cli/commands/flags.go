@@ -4,9 +4,12 @@ package commands
 import (
 	goErrors "errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/gruntwork-io/go-commons/collections"
 	"github.com/gruntwork-io/go-commons/errors"
+	"github.com/gruntwork-io/terragrunt/engine"
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/pkg/cli"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
@@ -26,7 +29,10 @@ const (
 	TerragruntConfigEnvName  = "TERRAGRUNT_CONFIG"
 
 	TerragruntTFPathFlagName = "terragrunt-tfpath"
-	TerragruntTFPathEnvName  = "TERRAGRUNT_TFPATH"
+	TerragruntTFPathEnvName  = options.TerraformPathEnvName
+
+	TerragruntTerraformInitArgsFlagName = "terragrunt-terraform-init-args"
+	TerragruntTerraformInitArgsEnvName  = "TERRAGRUNT_TERRAFORM_INIT_ARGS"
 
 	TerragruntNoAutoInitFlagName = "terragrunt-no-auto-init"
 	TerragruntNoAutoInitEnvName  = "TERRAGRUNT_NO_AUTO_INIT"
@@ -34,6 +40,30 @@ const (
 	TerragruntNoAutoRetryFlagName = "terragrunt-no-auto-retry"
 	TerragruntNoAutoRetryEnvName  = "TERRAGRUNT_NO_AUTO_RETRY"
 
+	TerragruntRetryMaxAttemptsFlagName = "terragrunt-retry-max-attempts"
+	TerragruntRetryMaxAttemptsEnvName  = "TERRAGRUNT_RETRY_MAX_ATTEMPTS"
+
+	TerragruntSignalEscalationDelayFlagName = "terragrunt-signal-escalation-delay"
+	TerragruntSignalEscalationDelayEnvName  = "TERRAGRUNT_SIGNAL_ESCALATION_DELAY"
+
+	TerragruntForwardSignalFlagName = "terragrunt-forward-signal"
+	TerragruntForwardSignalEnvName  = "TERRAGRUNT_FORWARD_SIGNAL"
+
+	TerragruntNoForwardSignalFlagName = "terragrunt-no-forward-signal"
+	TerragruntNoForwardSignalEnvName  = "TERRAGRUNT_NO_FORWARD_SIGNAL"
+
+	TerragruntOutputPrefixTimestampFlagName = "terragrunt-output-prefix-timestamp"
+	TerragruntOutputPrefixTimestampEnvName  = "TERRAGRUNT_OUTPUT_PREFIX_TIMESTAMP"
+
+	TerragruntOutputTimestampFormatFlagName = "terragrunt-output-timestamp-format"
+	TerragruntOutputTimestampFormatEnvName  = "TERRAGRUNT_OUTPUT_TIMESTAMP_FORMAT"
+
+	TerragruntLogStripSensitiveFlagName = "terragrunt-log-strip-sensitive"
+	TerragruntLogStripSensitiveEnvName  = "TERRAGRUNT_LOG_STRIP_SENSITIVE"
+
+	TerragruntLogSensitivePatternFlagName = "terragrunt-log-sensitive-pattern"
+	TerragruntLogSensitivePatternEnvName  = "TERRAGRUNT_LOG_SENSITIVE_PATTERN"
+
 	TerragruntNoAutoApproveFlagName = "terragrunt-no-auto-approve"
 	TerragruntNoAutoApproveEnvName  = "TERRAGRUNT_NO_AUTO_APPROVE"
 
@@ -43,6 +73,9 @@ const (
 	TerragruntWorkingDirFlagName = "terragrunt-working-dir"
 	TerragruntWorkingDirEnvName  = "TERRAGRUNT_WORKING_DIR"
 
+	TerragruntWorkingDirAbsFlagName = "terragrunt-working-dir-abs"
+	TerragruntWorkingDirAbsEnvName  = "TERRAGRUNT_WORKING_DIR_ABS"
+
 	TerragruntDownloadDirFlagName = "terragrunt-download-dir"
 	TerragruntDownloadDirEnvName  = "TERRAGRUNT_DOWNLOAD"
 
@@ -55,6 +88,15 @@ const (
 	TerragruntSourceUpdateFlagName = "terragrunt-source-update"
 	TerragruntSourceUpdateEnvName  = "TERRAGRUNT_SOURCE_UPDATE"
 
+	TerragruntRefreshSourceBeforeRunFlagName = "terragrunt-refresh-source-before-run"
+	TerragruntRefreshSourceBeforeRunEnvName  = "TERRAGRUNT_REFRESH_SOURCE_BEFORE_RUN"
+
+	TerragruntSourceNoPrereleaseFlagName = "terragrunt-source-no-prerelease"
+	TerragruntSourceNoPrereleaseEnvName  = "TERRAGRUNT_SOURCE_NO_PRERELEASE"
+
+	TerragruntSourceTagPrefixFlagName = "terragrunt-source-tag-prefix"
+	TerragruntSourceTagPrefixEnvName  = "TERRAGRUNT_SOURCE_TAG_PREFIX"
+
 	TerragruntIAMRoleFlagName = "terragrunt-iam-role"
 	TerragruntIAMRoleEnvName  = "TERRAGRUNT_IAM_ROLE"
 
@@ -67,6 +109,9 @@ const (
 	TerragruntIAMWebIdentityTokenFlagName = "terragrunt-iam-web-identity-token"
 	TerragruntIAMWebIdentityTokenEnvName  = "TERRAGRUNT_IAM_ASSUME_ROLE_WEB_IDENTITY_TOKEN"
 
+	TerragruntAssumeRoleWebIdentityTokenFileFlagName = "terragrunt-assume-role-web-identity-token-file"
+	TerragruntAssumeRoleWebIdentityTokenFileEnvName  = "TERRAGRUNT_ASSUME_ROLE_WEB_IDENTITY_TOKEN_FILE"
+
 	TerragruntIgnoreDependencyErrorsFlagName = "terragrunt-ignore-dependency-errors"
 	TerragruntIgnoreDependencyErrorsEnvName  = "TERRAGRUNT_IGNORE_DEPENDENCY_ERRORS"
 
@@ -91,9 +136,30 @@ const (
 	TerragruntStrictIncludeFlagName = "terragrunt-strict-include"
 	TerragruntStrictIncludeEnvName  = "TERRAGRUNT_STRICT_INCLUDE"
 
+	TerragruntModuleDependencyGraphPruneFlagName = "terragrunt-module-dependency-graph-prune"
+	TerragruntModuleDependencyGraphPruneEnvName  = "TERRAGRUNT_MODULE_DEPENDENCY_GRAPH_PRUNE"
+
 	TerragruntParallelismFlagName = "terragrunt-parallelism"
 	TerragruntParallelismEnvName  = "TERRAGRUNT_PARALLELISM"
 
+	TerragruntEngineRestartAttemptsFlagName = "terragrunt-engine-restart-attempts"
+	TerragruntEngineRestartAttemptsEnvName  = "TERRAGRUNT_ENGINE_RESTART_ATTEMPTS"
+
+	TerragruntEnginePlatformFlagName = "terragrunt-engine-platform"
+	TerragruntEnginePlatformEnvName  = "TERRAGRUNT_ENGINE_PLATFORM"
+
+	TerragruntEngineGRPCMaxMessageSizeFlagName = "terragrunt-engine-grpc-max-message-size"
+	TerragruntEngineGRPCMaxMessageSizeEnvName  = "TERRAGRUNT_ENGINE_GRPC_MAX_MESSAGE_SIZE"
+
+	TerragruntEngineTimeoutFlagName = "terragrunt-engine-timeout"
+	TerragruntEngineTimeoutEnvName  = "TERRAGRUNT_ENGINE_TIMEOUT"
+
+	TerragruntEngineMetaFlagName = "terragrunt-engine-meta"
+	TerragruntEngineMetaEnvName  = "TERRAGRUNT_ENGINE_META"
+
+	TerragruntEngineCachePathFlagName = "terragrunt-engine-cache-path"
+	TerragruntEngineCachePathEnvName  = engine.EngineCachePathEnv
+
 	TerragruntDebugFlagName = "terragrunt-debug"
 	TerragruntDebugEnvName  = "TERRAGRUNT_DEBUG"
 
@@ -103,6 +169,39 @@ const (
 	TerragruntModulesThatIncludeFlagName = "terragrunt-modules-that-include"
 	TerragruntModulesThatIncludeEnvName  = "TERRAGRUNT_MODULES_THAT_INCLUDE"
 
+	TerragruntModulesIncludeByPrefixFlagName = "terragrunt-modules-include-by-prefix"
+	TerragruntModulesIncludeByPrefixEnvName  = "TERRAGRUNT_MODULES_INCLUDE_BY_PREFIX"
+
+	TerragruntSuppressStderrFlagName = "terragrunt-suppress-stderr"
+	TerragruntSuppressStderrEnvName  = "TERRAGRUNT_SUPPRESS_STDERR"
+
+	TerragruntProviderOverrideFileFlagName = "terragrunt-provider-override-file"
+	TerragruntProviderOverrideFileEnvName  = "TERRAGRUNT_PROVIDER_OVERRIDE_FILE"
+
+	TerragruntGitCredentialHelperFlagName = "terragrunt-git-credential-helper"
+	TerragruntGitCredentialHelperEnvName  = "TERRAGRUNT_GIT_CREDENTIAL_HELPER"
+
+	TerragruntGitAllowShallowFlagName = "terragrunt-git-allow-shallow"
+	TerragruntGitAllowShallowEnvName  = "TERRAGRUNT_GIT_ALLOW_SHALLOW"
+
+	TerragruntGitNoSSHFallbackFlagName = "terragrunt-git-no-ssh-fallback"
+	TerragruntGitNoSSHFallbackEnvName  = "TERRAGRUNT_GIT_NO_SSH_FALLBACK"
+
+	TerragruntBackendRequireVersionConstraintFlagName = "terragrunt-backend-require-version-constraint"
+	TerragruntBackendRequireVersionConstraintEnvName  = "TERRAGRUNT_BACKEND_REQUIRE_VERSION_CONSTRAINT"
+
+	TerragruntCommandOutputDirFlagName = "terragrunt-command-output-dir"
+	TerragruntCommandOutputDirEnvName  = "TERRAGRUNT_COMMAND_OUTPUT_DIR"
+
+	TerragruntFailOnDirtyGitFlagName = "terragrunt-fail-on-dirty-git"
+	TerragruntFailOnDirtyGitEnvName  = "TERRAGRUNT_FAIL_ON_DIRTY_GIT"
+
+	TerragruntGitMaxConcurrencyFlagName = "terragrunt-git-max-concurrency"
+	TerragruntGitMaxConcurrencyEnvName  = "TERRAGRUNT_GIT_MAX_CONCURRENCY"
+
+	TerragruntCommandSummaryFileFlagName = "terragrunt-command-summary-file"
+	TerragruntCommandSummaryFileEnvName  = "TERRAGRUNT_COMMAND_SUMMARY_FILE"
+
 	TerragruntFetchDependencyOutputFromStateFlagName = "terragrunt-fetch-dependency-output-from-state"
 	TerragruntFetchDependencyOutputFromStateEnvName  = "TERRAGRUNT_FETCH_DEPENDENCY_OUTPUT_FROM_STATE"
 
@@ -121,12 +220,45 @@ const (
 	TerragruntAuthProviderCmdFlagName = "terragrunt-auth-provider-cmd"
 	TerragruntAuthProviderCmdEnvName  = "TERRAGRUNT_AUTH_PROVIDER_CMD"
 
+	TerragruntEnvFromSSMFlagName = "terragrunt-env-from-ssm"
+	TerragruntEnvFromSSMEnvName  = "TERRAGRUNT_ENV_FROM_SSM"
+
+	TerragruntDryRunFlagName = "terragrunt-dry-run"
+	TerragruntDryRunEnvName  = "TERRAGRUNT_DRY_RUN"
+
+	TerragruntCommandAuditLogFlagName = "terragrunt-command-audit-log"
+	TerragruntCommandAuditLogEnvName  = "TERRAGRUNT_COMMAND_AUDIT_LOG"
+
+	TerragruntConsoleInputFlagName = "terragrunt-console-input"
+	TerragruntConsoleInputEnvName  = "TERRAGRUNT_CONSOLE_INPUT"
+
+	TerragruntWorkingDirHashFlagName = "terragrunt-working-dir-hash"
+	TerragruntWorkingDirHashEnvName  = "TERRAGRUNT_WORKING_DIR_HASH"
+
+	TerragruntForwardTFStdoutCommandFlagName = "terragrunt-forward-tf-stdout-command"
+	TerragruntForwardTFStdoutCommandEnvName  = "TERRAGRUNT_FORWARD_TF_STDOUT_COMMAND"
+
+	TerragruntForwardTFStdoutFlagFlagName = "terragrunt-forward-tf-stdout-flag"
+	TerragruntForwardTFStdoutFlagEnvName  = "TERRAGRUNT_FORWARD_TF_STDOUT_FLAG"
+
 	TerragruntOutDirFlagEnvName = "TERRAGRUNT_OUT_DIR"
 	TerragruntOutDirFlagName    = "terragrunt-out-dir"
 
 	TerragruntJSONOutDirFlagEnvName = "TERRAGRUNT_JSON_OUT_DIR"
 	TerragruntJSONOutDirFlagName    = "terragrunt-json-out-dir"
 
+	TerragruntGzipPlanOutputFlagName = "terragrunt-gzip-plan-output"
+	TerragruntGzipPlanOutputEnvName  = "TERRAGRUNT_GZIP_PLAN_OUTPUT"
+
+	TerragruntSavePlanJSONFlagName = "terragrunt-save-plan-json"
+	TerragruntSavePlanJSONEnvName  = "TERRAGRUNT_SAVE_PLAN_JSON"
+
+	TerragruntRunAllReportFileFlagName = "terragrunt-run-all-report-file"
+	TerragruntRunAllReportFileEnvName  = "TERRAGRUNT_RUN_ALL_REPORT_FILE"
+
+	TerragruntRunAllReportFormatFlagName = "terragrunt-run-all-report-format"
+	TerragruntRunAllReportFormatEnvName  = "TERRAGRUNT_RUN_ALL_REPORT_FORMAT"
+
 	// Logs related flags/envs
 
 	TerragruntLogLevelFlagName = "terragrunt-log-level"
@@ -140,10 +272,23 @@ const (
 
 	TerragruntShowLogAbsPathsFlagName = "terragrunt-log-show-abs-paths"
 	TerragruntShowLogAbsPathsEnvName  = "TERRAGRUNT_LOG_SHOW_ABS_PATHS"
+	TerragruntShowLogAbsPathFlagAlias = "terragrunt-log-show-abs-path"
 
 	TerragruntForwardTFStdoutFlagName = "terragrunt-forward-tf-stdout"
 	TerragruntForwardTFStdoutEnvName  = "TERRAGRUNT_FORWARD_TF_STDOUT"
 
+	TerragruntTFLogLevelFlagName = "terragrunt-tf-log-level"
+	TerragruntTFLogLevelEnvName  = "TERRAGRUNT_TF_LOG_LEVEL"
+
+	TerragruntTFLogFileFlagName = "terragrunt-tf-log-file"
+	TerragruntTFLogFileEnvName  = "TERRAGRUNT_TF_LOG_FILE"
+
+	TerragruntNoAutoTFLogFlagName = "terragrunt-no-auto-tf-log"
+	TerragruntNoAutoTFLogEnvName  = "TERRAGRUNT_NO_AUTO_TF_LOG"
+
+	TerragruntErrorHintsFlagName = "terragrunt-error-hints"
+	TerragruntErrorHintsEnvName  = "TERRAGRUNT_ERROR_HINTS"
+
 	// Terragrunt Provider Cache related flags/envs
 
 	TerragruntProviderCacheFlagName = "terragrunt-provider-cache"
@@ -183,6 +328,12 @@ func NewGlobalFlags(opts *options.TerragruntOptions) cli.Flags {
 			Destination: &opts.TerraformPath,
 			Usage:       "Path to the Terraform binary. Default is tofu (on PATH).",
 		},
+		&cli.SliceFlag[string]{
+			Name:        TerragruntTerraformInitArgsFlagName,
+			EnvVar:      TerragruntTerraformInitArgsEnvName,
+			Destination: &opts.TerraformInitArgs,
+			Usage:       "Extra args, such as -plugin-dir, appended to every 'init' command Terragrunt runs. Can be passed multiple times.",
+		},
 		&cli.BoolFlag{
 			Name:        TerragruntNoAutoInitFlagName,
 			EnvVar:      TerragruntNoAutoInitEnvName,
@@ -197,6 +348,82 @@ func NewGlobalFlags(opts *options.TerragruntOptions) cli.Flags {
 			Usage:       "Don't automatically re-run command in case of transient errors.",
 			Negative:    true,
 		},
+		&cli.GenericFlag[int]{
+			Name:        TerragruntRetryMaxAttemptsFlagName,
+			EnvVar:      TerragruntRetryMaxAttemptsEnvName,
+			Destination: &opts.StateLockRetryMaxAttempts,
+			Usage:       "Maximum number of attempts for 'apply' and 'plan' commands that fail to acquire the state lock.",
+		},
+		&cli.GenericFlag[string]{
+			Name:   TerragruntSignalEscalationDelayFlagName,
+			EnvVar: TerragruntSignalEscalationDelayEnvName,
+			Usage:  "Kill the running command if it doesn't exit within the given duration after an interrupt signal was forwarded to it, e.g. 1m. Disabled by default.",
+			Action: func(ctx *cli.Context, val string) error {
+				delay, err := time.ParseDuration(val)
+				if err != nil {
+					return errors.WithStackTrace(err)
+				}
+
+				opts.SignalEscalationDelay = delay
+
+				return nil
+			},
+		},
+		&cli.SliceFlag[string]{
+			Name:   TerragruntForwardSignalFlagName,
+			EnvVar: TerragruntForwardSignalEnvName,
+			Usage:  "Forward the given signal, e.g. SIGHUP, to the running commands in addition to SIGTERM and SIGINT. Can be specified multiple times.",
+			Action: func(ctx *cli.Context, names []string) error {
+				for _, name := range names {
+					if err := opts.AddSignalToForward(name); err != nil {
+						return errors.WithStackTrace(err)
+					}
+				}
+
+				return nil
+			},
+		},
+		&cli.SliceFlag[string]{
+			Name:   TerragruntNoForwardSignalFlagName,
+			EnvVar: TerragruntNoForwardSignalEnvName,
+			Usage:  "Don't forward the given signal, e.g. SIGINT, to the running commands. Can be specified multiple times.",
+			Action: func(ctx *cli.Context, names []string) error {
+				for _, name := range names {
+					if err := opts.RemoveSignalToForward(name); err != nil {
+						return errors.WithStackTrace(err)
+					}
+				}
+
+				return nil
+			},
+		},
+		&cli.BoolFlag{
+			Name:        TerragruntOutputPrefixTimestampFlagName,
+			EnvVar:      TerragruntOutputPrefixTimestampEnvName,
+			Destination: &opts.OutputPrefixTimestamp,
+			Usage:       "Prepend the time to each line of the OpenTofu/Terraform output.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        TerragruntOutputTimestampFormatFlagName,
+			EnvVar:      TerragruntOutputTimestampFormatEnvName,
+			Destination: &opts.OutputTimestampFormat,
+			Usage:       "The Go time layout of the time prepended to the output lines. Default is RFC3339 with nanoseconds.",
+		},
+		&cli.BoolFlag{
+			Name:        TerragruntLogStripSensitiveFlagName,
+			EnvVar:      TerragruntLogStripSensitiveEnvName,
+			Destination: &opts.LogStripSensitive,
+			Usage:       "Redact common secrets, such as AWS access key IDs, private keys and Bearer tokens, from the OpenTofu/Terraform output.",
+		},
+		&cli.SliceFlag[string]{
+			Name:   TerragruntLogSensitivePatternFlagName,
+			EnvVar: TerragruntLogSensitivePatternEnvName,
+			Usage:  "A regular expression of the secrets redacted from the OpenTofu/Terraform output, in addition to the common ones, when --terragrunt-log-strip-sensitive is set. Can be specified multiple times.",
+			Action: func(ctx *cli.Context, patterns []string) error {
+				opts.LogSensitivePatterns = append(opts.LogSensitivePatterns, patterns...)
+				return nil
+			},
+		},
 		&cli.BoolFlag{
 			Name:        TerragruntNoAutoApproveFlagName,
 			EnvVar:      TerragruntNoAutoApproveEnvName,
@@ -216,6 +443,12 @@ func NewGlobalFlags(opts *options.TerragruntOptions) cli.Flags {
 			Destination: &opts.WorkingDir,
 			Usage:       "The path to the directory of Terragrunt configurations. Default is current directory.",
 		},
+		&cli.BoolFlag{
+			Name:        TerragruntWorkingDirAbsFlagName,
+			EnvVar:      TerragruntWorkingDirAbsEnvName,
+			Destination: &opts.WorkingDirAbs,
+			Usage:       "Resolve the symlinks in the working directory before running subprocesses. Enabled by default on macOS.",
+		},
 		&cli.GenericFlag[string]{
 			Name:        TerragruntDownloadDirFlagName,
 			EnvVar:      TerragruntDownloadDirEnvName,
@@ -234,6 +467,24 @@ func NewGlobalFlags(opts *options.TerragruntOptions) cli.Flags {
 			Destination: &opts.SourceUpdate,
 			Usage:       "Delete the contents of the temporary folder to clear out any old, cached source code before downloading new source code into it.",
 		},
+		&cli.BoolFlag{
+			Name:        TerragruntRefreshSourceBeforeRunFlagName,
+			EnvVar:      TerragruntRefreshSourceBeforeRunEnvName,
+			Destination: &opts.SourceRefreshBeforeRun,
+			Usage:       "Download again the source of the Git modules pinned to a branch before every run. Sources pinned to a tag or a commit are kept in the cache.",
+		},
+		&cli.BoolFlag{
+			Name:        TerragruntSourceNoPrereleaseFlagName,
+			EnvVar:      TerragruntSourceNoPrereleaseEnvName,
+			Destination: &opts.SourceNoPrerelease,
+			Usage:       "Ignore pre-release tags, such as v1.2.0-rc.1, when looking up the latest release tag of a module source.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        TerragruntSourceTagPrefixFlagName,
+			EnvVar:      TerragruntSourceTagPrefixEnvName,
+			Destination: &opts.SourceTagPrefix,
+			Usage:       "Only consider the tags that start with this prefix, such as module-name/, when looking up the latest release tag of a module source.",
+		},
 		&cli.MapFlag[string, string]{
 			Name:        TerragruntSourceMapFlagName,
 			EnvVar:      TerragruntSourceMapEnvName,
@@ -265,6 +516,12 @@ func NewGlobalFlags(opts *options.TerragruntOptions) cli.Flags {
 			Destination: &opts.IAMRoleOptions.WebIdentityToken,
 			Usage:       "For AssumeRoleWithWebIdentity, the WebIdentity token. Can also be set via TERRAGRUNT_IAM_ASSUME_ROLE_WEB_IDENTITY_TOKEN environment variable",
 		},
+		&cli.GenericFlag[string]{
+			Name:        TerragruntAssumeRoleWebIdentityTokenFileFlagName,
+			EnvVar:      TerragruntAssumeRoleWebIdentityTokenFileEnvName,
+			Destination: &opts.IAMRoleOptions.WebIdentityTokenFile,
+			Usage:       "For AssumeRoleWithWebIdentity, the path to a file with the WebIdentity token, such as AWS_WEB_IDENTITY_TOKEN_FILE on EKS.",
+		},
 		&cli.BoolFlag{
 			Name:        TerragruntIgnoreDependencyErrorsFlagName,
 			EnvVar:      TerragruntIgnoreDependencyErrorsEnvName,
@@ -295,6 +552,55 @@ func NewGlobalFlags(opts *options.TerragruntOptions) cli.Flags {
 			Destination: &opts.Parallelism,
 			Usage:       "*-all commands parallelism set to at most N modules",
 		},
+		&cli.GenericFlag[int]{
+			Name:        TerragruntEngineRestartAttemptsFlagName,
+			EnvVar:      TerragruntEngineRestartAttemptsEnvName,
+			Destination: &opts.EngineRestartAttempts,
+			Usage:       "The number of times to restart the engine plugin when it fails the health check before each run.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        TerragruntEnginePlatformFlagName,
+			EnvVar:      TerragruntEnginePlatformEnvName,
+			Destination: &opts.EnginePlatform,
+			Usage:       "The os/arch pair of the engine binary to use, e.g. linux/amd64, instead of the detected platform.",
+			Action: func(ctx *cli.Context, val string) error {
+				_, err := engine.ParsePlatform(val)
+				return err
+			},
+		},
+		&cli.GenericFlag[int]{
+			Name:        TerragruntEngineGRPCMaxMessageSizeFlagName,
+			EnvVar:      TerragruntEngineGRPCMaxMessageSizeEnvName,
+			Destination: &opts.EngineGRPCMaxMessageSize,
+			Usage:       "The maximum size in bytes of the gRPC messages sent to and received from the engine plugin.",
+		},
+		&cli.GenericFlag[string]{
+			Name:   TerragruntEngineTimeoutFlagName,
+			EnvVar: TerragruntEngineTimeoutEnvName,
+			Usage:  "The maximum duration of a command run with the engine, e.g. 1h. Default is 30m.",
+			Action: func(ctx *cli.Context, val string) error {
+				timeout, err := time.ParseDuration(val)
+				if err != nil {
+					return errors.WithStackTrace(err)
+				}
+
+				opts.EngineTimeout = timeout
+
+				return nil
+			},
+		},
+		&cli.MapFlag[string, string]{
+			Name:        TerragruntEngineMetaFlagName,
+			EnvVar:      TerragruntEngineMetaEnvName,
+			Destination: &opts.EngineMeta,
+			Usage:       "Metadata passed to the engine plugin as key=value, e.g. registry_token=abc. Can be specified multiple times.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        TerragruntEngineCachePathFlagName,
+			EnvVar:      TerragruntEngineCachePathEnvName,
+			Destination: &opts.EngineCachePath,
+			Usage:       "The root directory of the engine cache. Default is ~/.cache.",
+		},
 		&cli.GenericFlag[string]{
 			Name:        TerragruntExcludesFileFlagName,
 			EnvVar:      TerragruntExcludesFileEnvName,
@@ -332,7 +638,7 @@ func NewGlobalFlags(opts *options.TerragruntOptions) cli.Flags {
 					"fatal",
 				}
 
-				if collections.ListContainsElement(removedLevels, val) {
+				if collections.ListContainsElement(removedLevels, strings.ToLower(val)) {
 					opts.ForwardTFStdout = true
 					opts.Logger.SetOptions(log.WithFormatter(&format.SilentFormatter{}))
 					return nil
@@ -340,7 +646,8 @@ func NewGlobalFlags(opts *options.TerragruntOptions) cli.Flags {
 
 				level, err := log.ParseLevel(val)
 				if err != nil {
-					return errors.Errorf("flag --%s, %w", TerragruntLogLevelFlagName, err)
+					// the value can come from the flag or from the env var, which is easier to overlook in a pipeline
+					return errors.Errorf("flag --%s or env var %s, %w", TerragruntLogLevelFlagName, TerragruntLogLevelEnvName, err)
 				}
 
 				opts.Logger.SetOptions(log.WithLevel(level))
@@ -375,21 +682,26 @@ func NewGlobalFlags(opts *options.TerragruntOptions) cli.Flags {
 			Destination: &opts.JSONLogFormat,
 			Usage:       "If specified, Terragrunt will output its logs in JSON format.",
 			Action: func(ctx *cli.Context, _ bool) error {
-				opts.Logger.SetOptions(log.WithFormatter(&format.JSONFormatter{}))
+				opts.Logger.SetOptions(log.WithFormatter(&format.JSONFormatter{ShowAbsPaths: opts.LogShowAbsPaths}))
 				return nil
 			},
 		},
 		&cli.BoolFlag{
 			Name:        TerragruntShowLogAbsPathsFlagName,
+			Aliases:     []string{TerragruntShowLogAbsPathFlagAlias},
 			EnvVar:      TerragruntShowLogAbsPathsEnvName,
 			Destination: &opts.LogShowAbsPaths,
 			Usage:       "Show absolute paths in logs",
+			Action: func(ctx *cli.Context, val bool) error {
+				opts.LogFormatter.ShowAbsPaths = val
+				return nil
+			},
 		},
 		&cli.BoolFlag{
 			Name:        TerragruntNoColorFlagName,
 			EnvVar:      TerragruntNoColorEnvName,
 			Destination: &opts.DisableLogColors,
-			Usage:       "If specified, Terragrunt output won't contain any color.",
+			Usage:       "If specified, Terragrunt and OpenTofu/Terraform output won't contain any color. Enabled by default if stdout is not a terminal.",
 			Action: func(ctx *cli.Context, val bool) error {
 				opts.LogFormatter.DisableColors = val
 				return nil
@@ -401,6 +713,40 @@ func NewGlobalFlags(opts *options.TerragruntOptions) cli.Flags {
 			Destination: &opts.TerraformLogsToJSON,
 			Usage:       "If specified, Terragrunt will wrap Terraform stdout and stderr in JSON.",
 		},
+		&cli.GenericFlag[string]{
+			Name:   TerragruntTFLogLevelFlagName,
+			EnvVar: TerragruntTFLogLevelEnvName,
+			Usage:  fmt.Sprintf("Sets the logging level for all OpenTofu/Terraform TF_LOG output integrated into the Terragrunt log, instead of the level of each TF_LOG record. Supported levels: %s", log.AllLevels),
+			Action: func(ctx *cli.Context, val string) error {
+				level, err := log.ParseLevel(val)
+				if err != nil {
+					return errors.Errorf("flag --%s, %w", TerragruntTFLogLevelFlagName, err)
+				}
+
+				opts.TFLogLevel = &level
+
+				return nil
+			},
+		},
+		&cli.GenericFlag[string]{
+			Name:        TerragruntTFLogFileFlagName,
+			EnvVar:      TerragruntTFLogFileEnvName,
+			Destination: &opts.TFLogFile,
+			Usage:       "Path to the file where OpenTofu/Terraform TF_LOG output is written in addition to the Terragrunt log.",
+		},
+		&cli.BoolFlag{
+			Name:        TerragruntNoAutoTFLogFlagName,
+			EnvVar:      TerragruntNoAutoTFLogEnvName,
+			Destination: &opts.AutoTFLog,
+			Usage:       "Don't set TF_LOG to DEBUG or TRACE when the Terragrunt log level is debug or trace.",
+			Negative:    true,
+		},
+		&cli.BoolFlag{
+			Name:        TerragruntErrorHintsFlagName,
+			EnvVar:      TerragruntErrorHintsEnvName,
+			Destination: &opts.ErrorHints,
+			Usage:       "Append remediation hints to the errors of the commands, such as failing to acquire the state lock. Default is true.",
+		},
 		&cli.BoolFlag{
 			Name:        TerragruntUsePartialParseConfigCacheFlagName,
 			EnvVar:      TerragruntUsePartialParseConfigCacheEnvName,
@@ -425,12 +771,85 @@ func NewGlobalFlags(opts *options.TerragruntOptions) cli.Flags {
 			Destination: &opts.StrictInclude,
 			Usage:       "If flag is set, only modules under the directories passed in with '--terragrunt-include-dir' will be included.",
 		},
+		&cli.BoolFlag{
+			Name:        TerragruntModuleDependencyGraphPruneFlagName,
+			EnvVar:      TerragruntModuleDependencyGraphPruneEnvName,
+			Destination: &opts.ModuleDependencyGraphPrune,
+			Usage:       "Remove modules that have no dependency path to any module passed in with '--terragrunt-include-dir'. Enabled by default.",
+		},
 		&cli.SliceFlag[string]{
 			Name:        TerragruntModulesThatIncludeFlagName,
 			EnvVar:      TerragruntModulesThatIncludeEnvName,
 			Destination: &opts.ModulesThatInclude,
 			Usage:       "If flag is set, 'run-all' will only run the command against Terragrunt modules that include the specified file.",
 		},
+		&cli.SliceFlag[string]{
+			Name:        TerragruntModulesIncludeByPrefixFlagName,
+			EnvVar:      TerragruntModulesIncludeByPrefixEnvName,
+			Destination: &opts.ModulesIncludeByPrefix,
+			Usage:       "If flag is set, 'run-all' will only run the command against Terragrunt modules whose path relative to the working dir starts with the specified prefix.",
+		},
+		&cli.BoolFlag{
+			Name:        TerragruntSuppressStderrFlagName,
+			EnvVar:      TerragruntSuppressStderrEnvName,
+			Destination: &opts.SuppressStderr,
+			Usage:       "Do not display the stderr of terraform commands, e.g. deprecation warnings of 'terraform output -json'.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        TerragruntProviderOverrideFileFlagName,
+			EnvVar:      TerragruntProviderOverrideFileEnvName,
+			Destination: &opts.ProviderOverrideFile,
+			Usage:       "Path to a file copied into the working directory as _terragrunt_override.tf while terraform runs, e.g. to point providers to local mocks.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        TerragruntGitCredentialHelperFlagName,
+			EnvVar:      TerragruntGitCredentialHelperEnvName,
+			Destination: &opts.GitCredentialHelper,
+			Usage:       "Git credential helper used when querying the tags of remote repositories, e.g. 'store' or '!gh auth git-credential'.",
+		},
+		&cli.BoolFlag{
+			Name:        TerragruntGitAllowShallowFlagName,
+			EnvVar:      TerragruntGitAllowShallowEnvName,
+			Destination: &opts.GitAllowShallow,
+			Usage:       "Don't fetch the full history of the working directory before querying the tags of a repository when it is a shallow clone.",
+		},
+		&cli.BoolFlag{
+			Name:        TerragruntGitNoSSHFallbackFlagName,
+			EnvVar:      TerragruntGitNoSSHFallbackEnvName,
+			Destination: &opts.GitNoSSHFallback,
+			Usage:       "Don't retry with the HTTPS URL when querying the tags of a repository over SSH fails to authenticate.",
+		},
+		&cli.BoolFlag{
+			Name:        TerragruntBackendRequireVersionConstraintFlagName,
+			EnvVar:      TerragruntBackendRequireVersionConstraintEnvName,
+			Destination: &opts.RequireTFVersionConstraint,
+			Usage:       "Fail before auto-init if the module does not declare a required_version constraint.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        TerragruntCommandOutputDirFlagName,
+			EnvVar:      TerragruntCommandOutputDirEnvName,
+			Destination: &opts.CommandOutputDir,
+			Usage:       "Directory where the stdout and stderr of every command run by Terragrunt are written, one file per command.",
+		},
+		&cli.BoolFlag{
+			Name:        TerragruntFailOnDirtyGitFlagName,
+			EnvVar:      TerragruntFailOnDirtyGitEnvName,
+			Destination: &opts.FailOnDirtyGit,
+			Usage:       "Fail commands that change the infrastructure, such as apply, when the git working tree has uncommitted changes.",
+		},
+		&cli.GenericFlag[int]{
+			Name:        TerragruntGitMaxConcurrencyFlagName,
+			EnvVar:      TerragruntGitMaxConcurrencyEnvName,
+			Destination: &opts.GitMaxConcurrency,
+			Usage:       "The maximum number of git subprocesses that can run at the same time. 0 disables the limit.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        TerragruntCommandSummaryFileFlagName,
+			EnvVar:      TerragruntCommandSummaryFileEnvName,
+			Destination: &opts.CommandSummaryFile,
+			Usage:       "Path to the file where a JSON summary of all the commands run by Terragrunt is written when it exits.",
+		},
+
 		&cli.BoolFlag{
 			Name:        TerragruntFailOnStateBucketCreationFlagName,
 			EnvVar:      TerragruntFailOnStateBucketCreationEnvName,
@@ -492,6 +911,48 @@ func NewGlobalFlags(opts *options.TerragruntOptions) cli.Flags {
 			EnvVar:      TerragruntAuthProviderCmdEnvName,
 			Usage:       "The command and arguments that can be used to fetch authentication configurations.",
 		},
+		&cli.SliceFlag[string]{
+			Name:        TerragruntEnvFromSSMFlagName,
+			Destination: &opts.EnvFromSSM,
+			EnvVar:      TerragruntEnvFromSSMEnvName,
+			Usage:       "Load an environment variable from AWS SSM Parameter Store, in the format ENV_VAR=/ssm/path. Can be specified multiple times.",
+		},
+		&cli.BoolFlag{
+			Name:        TerragruntDryRunFlagName,
+			EnvVar:      TerragruntDryRunEnvName,
+			Destination: &opts.DryRun,
+			Usage:       "Log the shell commands that would be run, instead of executing them.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        TerragruntCommandAuditLogFlagName,
+			EnvVar:      TerragruntCommandAuditLogEnvName,
+			Destination: &opts.CommandAuditLog,
+			Usage:       "Append a JSON line for every shell command run by Terragrunt to the given file.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        TerragruntConsoleInputFlagName,
+			EnvVar:      TerragruntConsoleInputEnvName,
+			Destination: &opts.ConsoleInput,
+			Usage:       "Read the input of 'terraform console' from the given file instead of the terminal.",
+		},
+		&cli.BoolFlag{
+			Name:        TerragruntWorkingDirHashFlagName,
+			EnvVar:      TerragruntWorkingDirHashEnvName,
+			Destination: &opts.WorkingDirHash,
+			Usage:       "Enables the get_working_dir_hash() function, a short hash of the absolute working directory to add to resource names.",
+		},
+		&cli.SliceFlag[string]{
+			Name:        TerragruntForwardTFStdoutCommandFlagName,
+			EnvVar:      TerragruntForwardTFStdoutCommandEnvName,
+			Destination: &opts.ForceForwardStdoutCommands,
+			Usage:       "A terraform command whose stdout is forwarded as is, without Terragrunt logging. Replaces the default list of commands.",
+		},
+		&cli.SliceFlag[string]{
+			Name:        TerragruntForwardTFStdoutFlagFlagName,
+			EnvVar:      TerragruntForwardTFStdoutFlagEnvName,
+			Destination: &opts.ForceForwardStdoutFlags,
+			Usage:       "A terraform flag that makes the stdout of any command forwarded as is, without Terragrunt logging. Replaces the default list of flags.",
+		},
 	}
 
 	flags.Sort()
@@ -42,11 +42,22 @@ func Run(ctx context.Context, opts *options.TerragruntOptions) error {
 		}
 	}
 
+	format, isGraphExport := graphFormat(opts.TerraformCliArgs)
+	isGraphExport = isGraphExport && opts.TerraformCommand == terraform.CommandNameGraph
+
+	if isGraphExport && format != GraphFormatDOT && format != GraphFormatJSON {
+		return errors.WithStackTrace(UnsupportedGraphFormatError{Format: format})
+	}
+
 	stack, err := configstack.FindStackInSubfolders(ctx, opts)
 	if err != nil {
 		return err
 	}
 
+	if isGraphExport {
+		return writeStackGraph(opts, stack, format)
+	}
+
 	return RunAllOnStack(ctx, opts, stack)
 }
 
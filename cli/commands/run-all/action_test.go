@@ -1,9 +1,12 @@
 package runall_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	runall "github.com/gruntwork-io/terragrunt/cli/commands/run-all"
@@ -28,3 +31,49 @@ func TestMissingRunAllArguments(t *testing.T) {
 	fmt.Println(err, errors.Unwrap(err))
 	assert.True(t, ok)
 }
+
+func TestRunAllGraphExport(t *testing.T) {
+	t.Parallel()
+
+	stackDir := t.TempDir()
+
+	for module, config := range map[string]string{
+		"vpc": "",
+		"app": "dependencies {\n  paths = [\"../vpc\"]\n}\n",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(stackDir, module), os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(stackDir, module, "terragrunt.hcl"), []byte(config), 0644))
+		// the modules without OpenTofu/Terraform configuration are skipped
+		require.NoError(t, os.WriteFile(filepath.Join(stackDir, module, "main.tf"), []byte{}, 0644))
+	}
+
+	runGraph := func(args ...string) (string, error) {
+		tgOptions, err := options.NewTerragruntOptionsForTest(filepath.Join(stackDir, "terragrunt.hcl"))
+		require.NoError(t, err)
+
+		var stdout bytes.Buffer
+
+		tgOptions.WorkingDir = stackDir
+		tgOptions.Writer = &stdout
+		tgOptions.TerraformCommand = "graph"
+		tgOptions.TerraformCliArgs = append([]string{"graph"}, args...)
+
+		err = runall.Run(context.Background(), tgOptions)
+
+		return stdout.String(), err
+	}
+
+	stdout, err := runGraph("--format", "json")
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"module": "app", "dependencies": ["vpc"]}, {"module": "vpc", "dependencies": []}]`, stdout)
+
+	stdout, err = runGraph("--format=dot")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "\"app\" -> \"vpc\";")
+
+	_, err = runGraph("--format", "svg")
+
+	var formatErr runall.UnsupportedGraphFormatError
+	require.True(t, errors.As(err, &formatErr))
+	assert.Equal(t, "svg", formatErr.Format)
+}
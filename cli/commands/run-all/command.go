@@ -47,6 +47,30 @@ func NewFlags(opts *options.TerragruntOptions) cli.Flags {
 			Destination: &opts.JSONOutputFolder,
 			Usage:       "Directory to store json plan files.",
 		},
+		&cli.BoolFlag{
+			Name:        commands.TerragruntGzipPlanOutputFlagName,
+			EnvVar:      commands.TerragruntGzipPlanOutputEnvName,
+			Destination: &opts.GzipPlanOutput,
+			Usage:       "Compress plan files stored in the directory set by --terragrunt-out-dir with gzip.",
+		},
+		&cli.BoolFlag{
+			Name:        commands.TerragruntSavePlanJSONFlagName,
+			EnvVar:      commands.TerragruntSavePlanJSONEnvName,
+			Destination: &opts.SavePlanJSON,
+			Usage:       "Save the JSON representation of plan files as plan.json in the directory set by --terragrunt-out-dir.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        commands.TerragruntRunAllReportFileFlagName,
+			EnvVar:      commands.TerragruntRunAllReportFileEnvName,
+			Destination: &opts.RunAllReportFile,
+			Usage:       "Write a report of the execution results of all modules to the given file.",
+		},
+		&cli.GenericFlag[string]{
+			Name:        commands.TerragruntRunAllReportFormatFlagName,
+			EnvVar:      commands.TerragruntRunAllReportFormatEnvName,
+			Destination: &opts.RunAllReportFormat,
+			Usage:       "Format of the report set by --terragrunt-run-all-report-file, either 'json' or 'yaml'.",
+		},
 	}
 }
 
@@ -16,3 +16,11 @@ type MissingCommand struct{}
 func (err MissingCommand) Error() string {
 	return "Missing run-all command argument (Example: terragrunt run-all plan)"
 }
+
+type UnsupportedGraphFormatError struct {
+	Format string
+}
+
+func (err UnsupportedGraphFormatError) Error() string {
+	return fmt.Sprintf("unsupported graph format %q, expected %q or %q", err.Format, GraphFormatDOT, GraphFormatJSON)
+}
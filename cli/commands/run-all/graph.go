@@ -0,0 +1,54 @@
+package runall
+
+import (
+	"strings"
+
+	"github.com/gruntwork-io/go-commons/errors"
+	"github.com/gruntwork-io/terragrunt/configstack"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+// graphFormatFlagName is the arg of `run-all graph` that writes the dependency graph of the stack, instead of running
+// `terraform graph` in each module.
+const graphFormatFlagName = "format"
+
+// Formats of the dependency graph written by `run-all graph --format`.
+const (
+	GraphFormatDOT  = "dot"
+	GraphFormatJSON = "json"
+)
+
+// graphFormat returns the value of the `--format` arg of `run-all graph`, or false if the arg is not set.
+func graphFormat(args []string) (string, bool) {
+	for i, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if name != graphFormatFlagName {
+			continue
+		}
+
+		if !hasValue && i+1 < len(args) {
+			value = args[i+1]
+		}
+
+		return value, true
+	}
+
+	return "", false
+}
+
+// writeStackGraph writes the dependency graph of the stack, as it is used to order the modules in `run-all`, to stdout
+// in the given format.
+func writeStackGraph(opts *options.TerragruntOptions, stack *configstack.Stack, format string) error {
+	switch format {
+	case GraphFormatDOT:
+		return stack.Modules.WriteDot(opts.Writer, opts)
+	case GraphFormatJSON:
+		return stack.Modules.WriteJSONGraph(opts.Writer, opts)
+	}
+
+	return errors.WithStackTrace(UnsupportedGraphFormatError{Format: format})
+}
@@ -363,7 +363,7 @@ func rewriteTemplateURL(ctx context.Context, opts *options.TerragruntOptions, pa
 			return nil, errors.WithStackTrace(err)
 		}
 
-		tag, err := shell.GitLastReleaseTag(ctx, opts, rootSourceURL)
+		tag, err := shell.GitLastReleaseTag(ctx, opts, rootSourceURL, opts.SourceTagPrefix)
 		if err != nil || tag == "" {
 			opts.Logger.Warnf("Failed to find last release tag for URL %s, so will not add a ref param to the URL", rootSourceURL)
 		} else {
@@ -396,7 +396,7 @@ func addRefToModuleURL(ctx context.Context, opts *options.TerragruntOptions, par
 			return nil, errors.WithStackTrace(err)
 		}
 
-		tag, err := shell.GitLastReleaseTag(ctx, opts, rootSourceURL)
+		tag, err := shell.GitLastReleaseTag(ctx, opts, rootSourceURL, opts.SourceTagPrefix)
 		if err != nil || tag == "" {
 			opts.Logger.Warnf("Failed to find last release tag for %s", rootSourceURL)
 		} else {
@@ -0,0 +1,61 @@
+package shell
+
+import (
+	"context"
+	"os"
+	"runtime"
+
+	"golang.org/x/term"
+
+	"github.com/gruntwork-io/terragrunt/cli/commands/terraform"
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/options"
+	tgshell "github.com/gruntwork-io/terragrunt/shell"
+)
+
+const (
+	defaultUnixShell    = "/bin/sh"
+	defaultWindowsShell = "cmd.exe"
+)
+
+// Run runs the given command, or the user's `$SHELL` if no command is given, in the Terragrunt working directory once
+// the configuration is generated and the module is initialized, so that the command sees the same environment
+// (including the inputs as `TF_VAR_` variables) as OpenTofu/Terraform would.
+func Run(ctx context.Context, opts *options.TerragruntOptions, args []string) error {
+	if len(args) > 0 && args[0] == "--" {
+		args = args[1:]
+	}
+
+	target := terraform.NewTarget(terraform.TargetPointInitCommand, func(ctx context.Context, opts *options.TerragruntOptions, _ *config.TerragruntConfig) error {
+		return runShell(ctx, opts, args)
+	})
+
+	return terraform.RunWithTarget(ctx, opts, target)
+}
+
+func runShell(ctx context.Context, opts *options.TerragruntOptions, args []string) error {
+	if len(args) == 0 {
+		args = []string{defaultShell(opts)}
+	}
+
+	// A PTY can only be allocated when Terragrunt itself is attached to a terminal.
+	allocatePseudoTty := term.IsTerminal(int(os.Stdin.Fd()))
+
+	opts.Logger.Debugf("Running %v in %s", args, opts.WorkingDir)
+
+	_, err := tgshell.RunShellCommandWithOutput(ctx, opts, "", false, false, allocatePseudoTty, args[0], args[1:]...)
+
+	return err
+}
+
+func defaultShell(opts *options.TerragruntOptions) string {
+	if shell := opts.Env["SHELL"]; shell != "" {
+		return shell
+	}
+
+	if runtime.GOOS == "windows" {
+		return defaultWindowsShell
+	}
+
+	return defaultUnixShell
+}
@@ -0,0 +1,21 @@
+// Package shell provides the `shell` command, which opens an interactive shell, or runs the given command, in the
+// Terragrunt working directory with the environment that Terragrunt would pass to OpenTofu/Terraform.
+package shell
+
+import (
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/cli"
+)
+
+const (
+	CommandName = "shell"
+)
+
+func NewCommand(opts *options.TerragruntOptions) *cli.Command {
+	return &cli.Command{
+		Name:      CommandName,
+		Usage:     "Open an interactive shell, or run the given command, with the Terragrunt environment set.",
+		UsageText: "terragrunt shell [-- command args...]",
+		Action:    func(ctx *cli.Context) error { return Run(ctx, opts.OptionsFromContext(ctx), ctx.Args().Slice()) },
+	}
+}
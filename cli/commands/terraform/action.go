@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/gruntwork-io/terragrunt/cli/commands/terraform/creds"
+	"github.com/gruntwork-io/terragrunt/cli/commands/terraform/creds/providers/amazonssm"
 	"github.com/gruntwork-io/terragrunt/cli/commands/terraform/creds/providers/amazonsts"
 	"github.com/gruntwork-io/terragrunt/cli/commands/terraform/creds/providers/externalcmd"
 	"github.com/gruntwork-io/terragrunt/telemetry"
@@ -36,6 +37,7 @@ import (
 const (
 	CommandNameTerragruntReadConfig = "terragrunt-read-config"
 	NullTFVarsFile                  = ".terragrunt-null-vars.auto.tfvars.json"
+	ProviderOverrideFile            = "_terragrunt_override.tf"
 
 	useLegacyNullValuesEnvVar = "TERRAGRUNT_TEMP_QUOTE_NULL"
 )
@@ -65,6 +67,17 @@ var TerraformCommandsThatDoNotNeedInit = []string{
 	"graph-dependencies",
 }
 
+// TerraformCommandsThatWrite are the terraform commands that change the infrastructure or the state, which
+// --terragrunt-fail-on-dirty-git prevents from running on a git working tree with uncommitted changes.
+var TerraformCommandsThatWrite = []string{
+	terraform.CommandNameApply,
+	terraform.CommandNameDestroy,
+	terraform.CommandNameImport,
+	terraform.CommandNameTaint,
+	terraform.CommandNameUntaint,
+	"refresh",
+}
+
 var ModuleRegex = regexp.MustCompile(`module[[:blank:]]+".+"`)
 
 const TerraformExtensionGlob = "*.tf"
@@ -115,6 +128,16 @@ func runTerraform(ctx context.Context, terragruntOptions *options.TerragruntOpti
 
 	terragruntOptions.Engine = engine
 
+	// the metadata set with --terragrunt-engine-meta takes precedence over the `engine_meta` block
+	if terragruntConfig.EngineMeta != nil {
+		engineMeta := util.CloneStringMap(terragruntConfig.EngineMeta)
+		for key, value := range terragruntOptions.EngineMeta {
+			engineMeta[key] = value
+		}
+
+		terragruntOptions.EngineMeta = engineMeta
+	}
+
 	terragruntOptionsClone, err := terragruntOptions.Clone(terragruntOptions.TerragruntConfigPath)
 	if err != nil {
 		return err
@@ -142,7 +165,7 @@ func runTerraform(ctx context.Context, terragruntOptions *options.TerragruntOpti
 		terragruntOptions.OriginalIAMRoleOptions,
 	)
 
-	if err := credsGetter.ObtainAndUpdateEnvIfNecessary(ctx, terragruntOptions, amazonsts.NewProvider(terragruntOptions)); err != nil {
+	if err := credsGetter.ObtainAndUpdateEnvIfNecessary(ctx, terragruntOptions, amazonsts.NewProvider(terragruntOptions), amazonssm.NewProvider(terragruntOptions)); err != nil {
 		return err
 	}
 
@@ -285,10 +308,29 @@ func runTerragruntWithConfig(ctx context.Context, originalTerragruntOptions *opt
 		}
 	}
 
+	// Set the `set_env` env vars only for the commands run in the module working directory
+	if terragruntConfig.SetEnv != nil {
+		terragruntOptions.SetEnvOverrides(terragruntOptions.WorkingDir, terragruntConfig.SetEnv)
+	}
+
 	if err := SetTerragruntInputsAsEnvVars(terragruntOptions, terragruntConfig); err != nil {
 		return err
 	}
 
+	// The override file is copied before `init`, which may be run automatically below, so that every command sees it.
+	overrideFile, err := copyProviderOverrideFile(originalTerragruntOptions, terragruntOptions)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if overrideFile != "" {
+			if err := os.Remove(overrideFile); err != nil {
+				terragruntOptions.Logger.Debugf("Failed to remove provider override file %s: %v", overrideFile, err)
+			}
+		}
+	}()
+
 	if util.FirstArg(terragruntOptions.TerraformCliArgs) == terraform.CommandNameInit {
 		if err := prepareInitCommand(ctx, terragruntOptions, terragruntConfig); err != nil {
 			return err
@@ -323,6 +365,10 @@ func runTerragruntWithConfig(ctx context.Context, originalTerragruntOptions *opt
 		return err
 	}
 
+	if err := checkDirtyGit(ctx, terragruntOptions); err != nil {
+		return err
+	}
+
 	return runActionWithHooks(ctx, "terraform", terragruntOptions, terragruntConfig, func(ctx context.Context) error {
 		runTerraformError := RunTerraformWithRetry(ctx, terragruntOptions)
 
@@ -445,14 +491,18 @@ func SetTerragruntInputsAsEnvVars(terragruntOptions *options.TerragruntOptions,
 func RunTerraformWithRetry(ctx context.Context, terragruntOptions *options.TerragruntOptions) error {
 	// Retry the command configurable time with sleep in between
 	for i := 0; i < terragruntOptions.RetryMaxAttempts; i++ {
-		if out, err := shell.RunTerraformCommandWithOutput(ctx, terragruntOptions, terragruntOptions.TerraformCliArgs...); err != nil {
+		if err := runTerraformCommand(ctx, terragruntOptions); err != nil {
+			var out *util.CmdOutput
+
+			if execErr := util.Unwrap[util.ProcessExecutionError](err); execErr != nil {
+				out = &util.CmdOutput{Stdout: execErr.Stdout, Stderr: execErr.Stderr}
+			}
+
 			if out == nil || !IsRetryable(terragruntOptions, out) {
 				logger := terragruntOptions.Logger
 
-				if execErr := util.Unwrap[util.ProcessExecutionError](err); execErr != nil {
-					if execErr.Stderr != "" {
-						logger = logger.WithField("stderr", "\n"+execErr.Stderr)
-					}
+				if out != nil && out.Stderr != "" {
+					logger = logger.WithField("stderr", "\n"+out.Stderr)
 				}
 
 				logger.Errorf("%s invocation failed in %s", terragruntOptions.TerraformImplementation, terragruntOptions.WorkingDir)
@@ -475,6 +525,29 @@ func RunTerraformWithRetry(ctx context.Context, terragruntOptions *options.Terra
 	return errors.WithStackTrace(MaxRetriesExceeded{terragruntOptions})
 }
 
+// runTerraformCommand runs the command of the given options. The `apply` and `plan` commands that fail to acquire the
+// state lock are retried up to `StateLockRetryMaxAttempts` times, set with --terragrunt-retry-max-attempts.
+func runTerraformCommand(ctx context.Context, terragruntOptions *options.TerragruntOptions) error {
+	if shouldRetryStateLock(terragruntOptions) {
+		return shell.RunTerraformCommandWithRetry(ctx, terragruntOptions, terragruntOptions.StateLockRetryMaxAttempts, nil, terragruntOptions.TerraformCliArgs...)
+	}
+
+	_, err := shell.RunTerraformCommandWithOutput(ctx, terragruntOptions, terragruntOptions.TerraformCliArgs...)
+
+	return err
+}
+
+// shouldRetryStateLock returns true if `apply` or `plan` should be retried when they fail to acquire the state lock.
+func shouldRetryStateLock(opts *options.TerragruntOptions) bool {
+	if opts.StateLockRetryMaxAttempts < 2 { //nolint:mnd
+		return false
+	}
+
+	cmd := opts.TerraformCommand
+
+	return cmd == terraform.CommandNameApply || cmd == terraform.CommandNamePlan
+}
+
 // IsRetryable checks whether there was an error and if the output matches any of the configured RetryableErrors
 func IsRetryable(opts *options.TerragruntOptions, out *util.CmdOutput) bool {
 	if !opts.AutoRetry {
@@ -633,6 +706,12 @@ func runTerraformInit(ctx context.Context, originalTerragruntOptions *options.Te
 		return nil
 	}
 
+	if terragruntOptions.RequireTFVersionConstraint {
+		if err := checkVersionConstraint(terragruntOptions); err != nil {
+			return err
+		}
+	}
+
 	initOptions, err := prepareInitOptions(terragruntOptions)
 	if err != nil {
 		return err
@@ -650,6 +729,20 @@ func runTerraformInit(ctx context.Context, originalTerragruntOptions *options.Te
 	return nil
 }
 
+// checkVersionConstraint returns an error if the Terraform code in the working dir does not declare a `required_version`.
+func checkVersionConstraint(terragruntOptions *options.TerragruntOptions) error {
+	constraints, err := terraform.ModuleRequiredVersions(terragruntOptions.WorkingDir)
+	if err != nil {
+		return err
+	}
+
+	if len(constraints) == 0 {
+		return errors.WithStackTrace(fmt.Errorf("%w in %s", ErrMissingVersionConstraint, terragruntOptions.WorkingDir))
+	}
+
+	return nil
+}
+
 func prepareInitOptions(terragruntOptions *options.TerragruntOptions) (*options.TerragruntOptions, error) {
 	// Need to clone the terragruntOptions, so the TerraformCliArgs can be configured to run the init command
 	initOptions, err := terragruntOptions.Clone(terragruntOptions.TerragruntConfigPath)
@@ -730,6 +823,27 @@ func checkProtectedModule(terragruntOptions *options.TerragruntOptions, terragru
 	return nil
 }
 
+// checkDirtyGit returns an error if --terragrunt-fail-on-dirty-git is set, the command is one of
+// TerraformCommandsThatWrite and the git working tree of the module has uncommitted changes.
+func checkDirtyGit(ctx context.Context, terragruntOptions *options.TerragruntOptions) error {
+	if !terragruntOptions.FailOnDirtyGit || !util.ListContainsElement(TerraformCommandsThatWrite, terragruntOptions.TerraformCommand) {
+		return nil
+	}
+
+	configDir := filepath.Dir(terragruntOptions.TerragruntConfigPath)
+
+	modifiedPaths, err := shell.GitModifiedPaths(ctx, terragruntOptions, configDir)
+	if err != nil {
+		return err
+	}
+
+	if len(modifiedPaths) > 0 {
+		return errors.WithStackTrace(DirtyGitWorkingTreeError{Dir: configDir, ModifiedPaths: modifiedPaths})
+	}
+
+	return nil
+}
+
 func FilterTerraformExtraArgs(terragruntOptions *options.TerragruntOptions, terragruntConfig *config.TerragruntConfig) []string {
 	out := []string{}
 	cmd := util.FirstArg(terragruntOptions.TerraformCliArgs)
@@ -853,6 +967,34 @@ func setTerragruntNullValues(terragruntOptions *options.TerragruntOptions, terra
 	return varFile, nil
 }
 
+// copyProviderOverrideFile copies the file set by --terragrunt-provider-override-file into the working dir as
+// `_terragrunt_override.tf` and returns its path, or an empty string if the flag is not set. The copy is removed after
+// the command, so it fails if the working dir already has a `_terragrunt_override.tf` file of its own.
+func copyProviderOverrideFile(originalTerragruntOptions *options.TerragruntOptions, terragruntOptions *options.TerragruntOptions) (string, error) {
+	if terragruntOptions.ProviderOverrideFile == "" {
+		return "", nil
+	}
+
+	srcFile := terragruntOptions.ProviderOverrideFile
+	if !filepath.IsAbs(srcFile) {
+		srcFile = filepath.Join(originalTerragruntOptions.WorkingDir, srcFile)
+	}
+
+	dstFile := filepath.Join(terragruntOptions.WorkingDir, ProviderOverrideFile)
+
+	if util.FileExists(dstFile) {
+		return "", errors.WithStackTrace(ProviderOverrideFileExistsError{Path: dstFile})
+	}
+
+	terragruntOptions.Logger.Debugf("Copying provider override file %s to %s", srcFile, dstFile)
+
+	if err := util.CopyFile(srcFile, dstFile); err != nil {
+		return "", errors.WithStackTrace(err)
+	}
+
+	return dstFile, nil
+}
+
 func useLegacyNullValues() bool {
 	return os.Getenv(useLegacyNullValuesEnvVar) == "1"
 }
@@ -0,0 +1,50 @@
+package terraform
+
+import (
+	goErrors "errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyProviderOverrideFile(t *testing.T) {
+	t.Parallel()
+
+	moduleDir := t.TempDir()
+	workingDir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(moduleDir, "override.tf"), []byte("# override"), 0644))
+
+	originalOpts, err := options.NewTerragruntOptionsForTest(filepath.Join(moduleDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	originalOpts.WorkingDir = moduleDir
+	originalOpts.ProviderOverrideFile = "override.tf"
+
+	opts := *originalOpts
+	opts.WorkingDir = workingDir
+
+	overrideFile, err := copyProviderOverrideFile(originalOpts, &opts)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(workingDir, ProviderOverrideFile), overrideFile)
+
+	content, err := os.ReadFile(overrideFile)
+	require.NoError(t, err)
+	assert.Equal(t, "# override", string(content))
+
+	// the override file of the module is neither overwritten nor removed
+	require.NoError(t, os.WriteFile(overrideFile, []byte("# module"), 0644))
+
+	_, err = copyProviderOverrideFile(originalOpts, &opts)
+
+	var existsErr ProviderOverrideFileExistsError
+	require.True(t, goErrors.As(err, &existsErr), err)
+
+	content, err = os.ReadFile(overrideFile)
+	require.NoError(t, err)
+	assert.Equal(t, "# module", string(content))
+}
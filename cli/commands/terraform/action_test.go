@@ -11,6 +11,7 @@ import (
 	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/shell"
 	"github.com/gruntwork-io/terragrunt/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -265,6 +266,61 @@ func TestTerragruntHandlesCatastrophicTerraformFailure(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestRunTerraformWithRetryStateLock(t *testing.T) {
+	t.Parallel()
+
+	tgOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	tgOptions.TerraformCommand = "apply"
+	tgOptions.TerraformCliArgs = []string{"apply"}
+	tgOptions.RetrySleepInterval = 0
+	tgOptions.RetryMaxAttempts = 2
+	tgOptions.StateLockRetryMaxAttempts = 4
+	tgOptions.AutoRetry = true
+	tgOptions.RetryableErrors = []string{"(?s).*TLS handshake timeout.*"}
+
+	newContext := func(stderrs ...string) (context.Context, *int) {
+		attempts := 0
+
+		fake := shell.NewFakeExecutor()
+		fake.Register(tgOptions.TerraformPath, func(args []string) (*util.CmdOutput, error) {
+			attempts++
+
+			if attempts > len(stderrs) {
+				return &util.CmdOutput{}, nil
+			}
+
+			stderr := stderrs[attempts-1]
+
+			return &util.CmdOutput{Stderr: stderr}, util.ProcessExecutionError{Err: shell.FakeExitError{ExitCode: 1}, Stderr: stderr}
+		})
+
+		return shell.ContextWithShellCommandHook(context.Background(), fake.Run), &attempts
+	}
+
+	const (
+		lockErr    = "Error: Error acquiring the state lock"
+		timeoutErr = "Error: TLS handshake timeout"
+	)
+
+	// the state lock errors are retried up to `StateLockRetryMaxAttempts` times
+	ctx, attempts := newContext(lockErr, lockErr, lockErr)
+	require.NoError(t, terraform.RunTerraformWithRetry(ctx, tgOptions))
+	assert.Equal(t, 4, *attempts)
+
+	ctx, attempts = newContext(lockErr, lockErr, lockErr, lockErr)
+	require.Error(t, terraform.RunTerraformWithRetry(ctx, tgOptions))
+	assert.Equal(t, 4, *attempts)
+
+	// the other retryable errors are retried up to `RetryMaxAttempts` times
+	ctx, attempts = newContext(timeoutErr, timeoutErr)
+
+	var maxRetriesErr terraform.MaxRetriesExceeded
+	require.ErrorAs(t, terraform.RunTerraformWithRetry(ctx, tgOptions), &maxRetriesErr)
+	assert.Equal(t, 2, *attempts)
+}
+
 func TestToTerraformEnvVars(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,18 @@
+package amazonssm
+
+import (
+	"fmt"
+	"strings"
+)
+
+type InvalidEnvFromSSMError string
+
+func (err InvalidEnvFromSSMError) Error() string {
+	return fmt.Sprintf("invalid SSM environment variable mapping %q, expected format ENV_VAR=/ssm/path", string(err))
+}
+
+type ParametersNotFoundError []string
+
+func (err ParametersNotFoundError) Error() string {
+	return "SSM parameters not found: " + strings.Join(err, ", ")
+}
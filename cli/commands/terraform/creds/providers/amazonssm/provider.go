@@ -0,0 +1,119 @@
+// Package amazonssm provides a provider that obtains environment variables from AWS SSM Parameter Store.
+package amazonssm
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"github.com/gruntwork-io/go-commons/errors"
+	"github.com/gruntwork-io/terragrunt/awshelper"
+	"github.com/gruntwork-io/terragrunt/cli/commands/terraform/creds/providers"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// maxParametersPerRequest is the maximum number of parameters that can be fetched by a single GetParameters API call.
+const maxParametersPerRequest = 10
+
+// Provider obtains environment variables by fetching parameters from AWS SSM Parameter Store.
+type Provider struct {
+	terragruntOptions *options.TerragruntOptions
+	client            ssmiface.SSMAPI
+}
+
+// NewProvider returns a new Provider instance.
+func NewProvider(opts *options.TerragruntOptions) providers.Provider {
+	return &Provider{
+		terragruntOptions: opts,
+	}
+}
+
+// NewProviderWithClient returns a new Provider instance that uses the given SSM client.
+func NewProviderWithClient(opts *options.TerragruntOptions, client ssmiface.SSMAPI) providers.Provider {
+	return &Provider{
+		terragruntOptions: opts,
+		client:            client,
+	}
+}
+
+// Name implements providers.Name
+func (provider *Provider) Name() string {
+	return "AWS SSM Parameter Store"
+}
+
+// GetCredentials implements providers.GetCredentials
+func (provider *Provider) GetCredentials(ctx context.Context) (*providers.Credentials, error) {
+	if len(provider.terragruntOptions.EnvFromSSM) == 0 {
+		return nil, nil
+	}
+
+	// map of SSM parameter paths to environment variable names, the same parameter can be assigned to several variables
+	paramEnvNames := make(map[string][]string)
+
+	var paramNames []string
+
+	for _, entry := range provider.terragruntOptions.EnvFromSSM {
+		envName, paramName, ok := strings.Cut(entry, "=")
+		if !ok || envName == "" || paramName == "" {
+			return nil, errors.WithStackTrace(InvalidEnvFromSSMError(entry))
+		}
+
+		if _, ok := paramEnvNames[paramName]; !ok {
+			paramNames = append(paramNames, paramName)
+		}
+
+		paramEnvNames[paramName] = append(paramEnvNames[paramName], envName)
+	}
+
+	client, err := provider.ssmClient()
+	if err != nil {
+		return nil, err
+	}
+
+	creds := &providers.Credentials{
+		Name: providers.SSMParameters,
+		Envs: make(map[string]string),
+	}
+
+	for start := 0; start < len(paramNames); start += maxParametersPerRequest {
+		end := util.Min(start+maxParametersPerRequest, len(paramNames))
+
+		provider.terragruntOptions.Logger.Debugf("Fetching SSM parameters %s", strings.Join(paramNames[start:end], ", "))
+
+		resp, err := client.GetParametersWithContext(ctx, &ssm.GetParametersInput{
+			Names:          aws.StringSlice(paramNames[start:end]),
+			WithDecryption: aws.Bool(true),
+		})
+		if err != nil {
+			return nil, errors.WithStackTrace(err)
+		}
+
+		if len(resp.InvalidParameters) > 0 {
+			return nil, errors.WithStackTrace(ParametersNotFoundError(aws.StringValueSlice(resp.InvalidParameters)))
+		}
+
+		for _, param := range resp.Parameters {
+			for _, envName := range paramEnvNames[aws.StringValue(param.Name)] {
+				creds.Envs[envName] = aws.StringValue(param.Value)
+			}
+		}
+	}
+
+	return creds, nil
+}
+
+func (provider *Provider) ssmClient() (ssmiface.SSMAPI, error) {
+	if provider.client != nil {
+		return provider.client, nil
+	}
+
+	sess, err := awshelper.CreateAwsSession(nil, provider.terragruntOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return ssm.New(sess), nil
+}
@@ -0,0 +1,141 @@
+package amazonssm_test
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"github.com/gruntwork-io/terragrunt/cli/commands/terraform/creds"
+	"github.com/gruntwork-io/terragrunt/cli/commands/terraform/creds/providers/amazonssm"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/shell"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSSMClient struct {
+	ssmiface.SSMAPI
+
+	params   map[string]string
+	requests [][]string
+}
+
+func (client *mockSSMClient) GetParametersWithContext(_ aws.Context, input *ssm.GetParametersInput, _ ...request.Option) (*ssm.GetParametersOutput, error) {
+	names := aws.StringValueSlice(input.Names)
+	client.requests = append(client.requests, names)
+
+	output := &ssm.GetParametersOutput{}
+
+	for _, name := range names {
+		if value, ok := client.params[name]; ok {
+			output.Parameters = append(output.Parameters, &ssm.Parameter{Name: aws.String(name), Value: aws.String(value)})
+		} else {
+			output.InvalidParameters = append(output.InvalidParameters, aws.String(name))
+		}
+	}
+
+	return output, nil
+}
+
+func TestEnvFromSSMReachesSubprocess(t *testing.T) {
+	t.Parallel()
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	opts.EnvFromSSM = []string{"TG_SSM_DB_PASSWORD=/app/db/password"}
+
+	client := &mockSSMClient{params: map[string]string{"/app/db/password": "secret"}}
+
+	err = creds.NewGetter().ObtainAndUpdateEnvIfNecessary(context.Background(), opts, amazonssm.NewProviderWithClient(opts, client))
+	require.NoError(t, err)
+
+	out, err := shell.RunShellCommandWithOutput(context.Background(), opts, "", true, false, false, "sh", "-c", "echo $TG_SSM_DB_PASSWORD")
+	require.NoError(t, err)
+
+	assert.Equal(t, "secret", strings.TrimSpace(out.Stdout))
+}
+
+func TestEnvFromSSMGetCredentials(t *testing.T) {
+	t.Parallel()
+
+	params := make(map[string]string)
+	for i := 0; i < 12; i++ {
+		params["/param/"+strconv.Itoa(i)] = "value" + strconv.Itoa(i)
+	}
+
+	tc := []struct {
+		envFromSSM       []string
+		expectedEnvs     map[string]string
+		expectedRequests int
+		expectedErr      bool
+	}{
+		{
+			envFromSSM:       nil,
+			expectedEnvs:     nil,
+			expectedRequests: 0,
+		},
+		{
+			envFromSSM:       []string{"FOO=/param/1", "BAR=/param/1", "BAZ=/param/2"},
+			expectedEnvs:     map[string]string{"FOO": "value1", "BAR": "value1", "BAZ": "value2"},
+			expectedRequests: 1,
+		},
+		{
+			envFromSSM: []string{
+				"V0=/param/0", "V1=/param/1", "V2=/param/2", "V3=/param/3", "V4=/param/4", "V5=/param/5",
+				"V6=/param/6", "V7=/param/7", "V8=/param/8", "V9=/param/9", "V10=/param/10", "V11=/param/11",
+			},
+			expectedEnvs: map[string]string{
+				"V0": "value0", "V1": "value1", "V2": "value2", "V3": "value3", "V4": "value4", "V5": "value5",
+				"V6": "value6", "V7": "value7", "V8": "value8", "V9": "value9", "V10": "value10", "V11": "value11",
+			},
+			expectedRequests: 2,
+		},
+		{
+			envFromSSM:  []string{"FOO"},
+			expectedErr: true,
+		},
+		{
+			envFromSSM:       []string{"FOO=/param/missing"},
+			expectedErr:      true,
+			expectedRequests: 1,
+		},
+	}
+
+	for i, tt := range tc {
+		tt := tt
+
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			opts, err := options.NewTerragruntOptionsForTest("")
+			require.NoError(t, err)
+
+			opts.EnvFromSSM = tt.envFromSSM
+
+			client := &mockSSMClient{params: params}
+
+			creds, err := amazonssm.NewProviderWithClient(opts, client).GetCredentials(context.Background())
+			assert.Len(t, client.requests, tt.expectedRequests)
+
+			if tt.expectedErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			if tt.expectedEnvs == nil {
+				assert.Nil(t, creds)
+				return
+			}
+
+			assert.Equal(t, tt.expectedEnvs, creds.Envs)
+		})
+	}
+}
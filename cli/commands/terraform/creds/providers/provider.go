@@ -7,6 +7,7 @@ import (
 
 const (
 	AWSCredentials CredentialsName = "AWS"
+	SSMParameters  CredentialsName = "SSM"
 )
 
 type CredentialsName string
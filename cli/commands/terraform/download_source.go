@@ -13,6 +13,7 @@ import (
 	"github.com/gruntwork-io/terragrunt/cli/commands"
 	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/shell"
 	"github.com/gruntwork-io/terragrunt/terraform"
 	"github.com/gruntwork-io/terragrunt/util"
 )
@@ -73,6 +74,19 @@ func DownloadTerraformSourceIfNecessary(ctx context.Context, terraformSource *te
 		if err := os.RemoveAll(terraformSource.DownloadDir); err != nil {
 			return errors.WithStackTrace(err)
 		}
+	} else if terragruntOptions.SourceRefreshBeforeRun {
+		branchPinned, err := isBranchPinnedGitSource(ctx, terraformSource, terragruntOptions)
+		if err != nil {
+			return err
+		}
+
+		if branchPinned {
+			terragruntOptions.Logger.Debugf("The --%s flag is set and %s is pinned to a branch, so deleting the temporary folder %s before downloading source.", commands.TerragruntRefreshSourceBeforeRunFlagName, terraformSource.CanonicalSourceURL, terraformSource.DownloadDir)
+
+			if err := os.RemoveAll(terraformSource.DownloadDir); err != nil {
+				return errors.WithStackTrace(err)
+			}
+		}
 	}
 
 	alreadyLatest, err := AlreadyHaveLatestCode(terraformSource, terragruntOptions)
@@ -142,6 +156,50 @@ func DownloadTerraformSourceIfNecessary(ctx context.Context, terraformSource *te
 	return nil
 }
 
+// isBranchPinnedGitSource returns true if the source is a Git source pinned to a branch, or not pinned at all, so that
+// its code can change between runs. The ref is resolved against the tags, then the branches of the repository, since
+// a tag or a branch may look like a commit SHA, e.g. `deadbeef`. A ref that is neither is a branch, unless it is a
+// commit SHA.
+func isBranchPinnedGitSource(ctx context.Context, terraformSource *terraform.Source, terragruntOptions *options.TerragruntOptions) (bool, error) {
+	if !terraformSource.IsGitSource() {
+		return false, nil
+	}
+
+	ref := terraformSource.GitRef()
+	if ref == "" {
+		return true, nil
+	}
+
+	repoURL, _, err := terraform.SplitSourceURL(terraformSource.CanonicalSourceURL, terragruntOptions.Logger)
+	if err != nil {
+		return false, err
+	}
+
+	// the root repo may be the source URL itself, the ref is removed from a copy
+	repo := *repoURL
+	repo.RawQuery = ""
+
+	tags, _, err := shell.GitRepoTags(ctx, terragruntOptions, &repo)
+	if err != nil {
+		return false, err
+	}
+
+	if util.ListContainsElement(shell.GitRepoTagNames(tags), ref) {
+		return false, nil
+	}
+
+	branches, err := shell.GitRepoBranches(ctx, terragruntOptions, &repo)
+	if err != nil {
+		return false, err
+	}
+
+	if util.ListContainsElement(branches, ref) {
+		return true, nil
+	}
+
+	return !terraform.IsCommitSHA(ref), nil
+}
+
 // AlreadyHaveLatestCode returns true if the specified TerraformSource, of the exact same version, has already been downloaded into the
 // DownloadFolder. This helps avoid downloading the same code multiple times. Note that if the TerraformSource points
 // to a local file path, a hash will be generated from the contents of the source dir. See the ProcessTerraformSource method for more info.
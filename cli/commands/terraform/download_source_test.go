@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/url"
 	"os"
+	"os/exec"
 	"path"
 	"path/filepath"
 	"strings"
@@ -371,6 +372,76 @@ func TestDownloadTerraformSourceFromLocalFolderWithManifest(t *testing.T) {
 
 }
 
+func TestDownloadTerraformSourceIfNecessaryRefreshSourceBeforeRun(t *testing.T) {
+	t.Parallel()
+
+	repoDir := t.TempDir()
+
+	runGit := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", repoDir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	runGit("init", "--initial-branch=main")
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "main.tf"), []byte("# Hello, World"), 0644))
+	runGit("add", "main.tf")
+	runGit("commit", "-m", "Hello, World")
+	runGit("tag", "v0.0.1")
+	// a branch named like a version is still a branch
+	runGit("branch", "1.0")
+	// the tags and branches named like a commit SHA are resolved as such
+	runGit("branch", "deadbeef")
+	runGit("tag", "cafe1234")
+
+	commitSHA, err := exec.Command("git", "-C", repoDir, "rev-parse", "HEAD").Output()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		ref       string
+		refreshed bool
+	}{
+		{"main", true},
+		{"1.0", true},
+		{"deadbeef", true},
+		{"v0.0.1", false},
+		{"cafe1234", false},
+		{strings.TrimSpace(string(commitSHA)), false},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.ref, func(t *testing.T) {
+			t.Parallel()
+
+			downloadDir := t.TempDir()
+			canonicalURL := fmt.Sprintf("git::file://%s?ref=%s", filepath.ToSlash(repoDir), testCase.ref)
+
+			terraformSource, terragruntOptions, terragruntConfig, err := createConfig(t, canonicalURL, downloadDir, false)
+			require.NoError(t, err)
+
+			terragruntOptions.SourceRefreshBeforeRun = true
+
+			err = terraform.DownloadTerraformSourceIfNecessary(context.Background(), terraformSource, terragruntOptions, terragruntConfig)
+			require.NoError(t, err)
+
+			// a local change is discarded only if the source is downloaded again
+			mainFile := filepath.Join(downloadDir, "main.tf")
+			require.NoError(t, os.WriteFile(mainFile, []byte("# Changed"), 0644))
+
+			err = terraform.DownloadTerraformSourceIfNecessary(context.Background(), terraformSource, terragruntOptions, terragruntConfig)
+			require.NoError(t, err)
+
+			if testCase.refreshed {
+				assert.Equal(t, "# Hello, World", readFile(t, mainFile))
+			} else {
+				assert.Equal(t, "# Changed", readFile(t, mainFile))
+			}
+		})
+	}
+}
+
 func testDownloadTerraformSourceIfNecessary(t *testing.T, canonicalURL string, downloadDir string, sourceUpdate bool, expectedFileContents string, requireInitFile bool) {
 	t.Helper()
 
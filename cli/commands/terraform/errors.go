@@ -1,6 +1,7 @@
 package terraform
 
 import (
+	goErrors "errors"
 	"fmt"
 	"strings"
 
@@ -57,3 +58,22 @@ type MaxRetriesExceeded struct {
 func (err MaxRetriesExceeded) Error() string {
 	return fmt.Sprintf("Exhausted retries (%v) for command %v %v", err.Opts.RetryMaxAttempts, err.Opts.TerraformPath, strings.Join(err.Opts.TerraformCliArgs, " "))
 }
+
+type DirtyGitWorkingTreeError struct {
+	Dir           string
+	ModifiedPaths []string
+}
+
+func (err DirtyGitWorkingTreeError) Error() string {
+	return fmt.Sprintf("The git working tree of %s has uncommitted changes, commit or stash them before running the command, or remove --terragrunt-fail-on-dirty-git. Modified paths:\n  %s", err.Dir, strings.Join(err.ModifiedPaths, "\n  "))
+}
+
+type ProviderOverrideFileExistsError struct {
+	Path string
+}
+
+func (err ProviderOverrideFileExistsError) Error() string {
+	return fmt.Sprintf("The provider override file can't be copied to %s since the file already exists, rename it or remove --terragrunt-provider-override-file.", err.Path)
+}
+
+var ErrMissingVersionConstraint = goErrors.New("the Terraform code does not declare a required_version constraint")
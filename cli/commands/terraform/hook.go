@@ -20,6 +20,9 @@ const (
 	HookCtxTFPathEnvName   = "TG_CTX_TF_PATH"
 	HookCtxCommandEnvName  = "TG_CTX_COMMAND"
 	HookCtxHookNameEnvName = "TG_CTX_HOOK_NAME"
+
+	// tflintHookCommand is the command of the hooks that run the built-in tflint.
+	tflintHookCommand = "tflint"
 )
 
 func processErrorHooks(ctx context.Context, hooks []config.ErrorHook, terragruntOptions *options.TerragruntOptions, previousExecErrors *multierror.Error) error {
@@ -77,6 +80,7 @@ func processErrorHooks(ctx context.Context, hooks []config.ErrorHook, terragrunt
 				workingDir,
 				suppressStdout,
 				false,
+				false,
 				actionToExecute, actionParams...,
 			)
 			if possibleError != nil {
@@ -94,18 +98,43 @@ func processHooks(ctx context.Context, hooks []config.Hook, terragruntOptions *o
 		return nil
 	}
 
-	var errorsOccured *multierror.Error
+	var (
+		errorsOccured *multierror.Error
+		parallelHooks []config.Hook
+	)
 
 	terragruntOptions.Logger.Debugf("Detected %d Hooks", len(hooks))
 
+	// consecutive hooks with `parallel = true` are run at the same time, the next hook waits for all of them to finish.
+	runParallelHooks := func() {
+		if len(parallelHooks) == 0 {
+			return
+		}
+
+		if err := runHooksInParallel(ctx, terragruntOptions, parallelHooks); err != nil {
+			errorsOccured = multierror.Append(errorsOccured, err)
+		}
+
+		parallelHooks = nil
+	}
+
 	for _, curHook := range hooks {
+		if !isParallelHook(curHook) {
+			runParallelHooks()
+		}
+
 		allPreviousErrors := multierror.Append(previousExecErrors, errorsOccured)
 		if shouldRunHook(curHook, terragruntOptions, allPreviousErrors) {
+			if isParallelHook(curHook) {
+				parallelHooks = append(parallelHooks, curHook)
+				continue
+			}
+
 			err := telemetry.Telemetry(ctx, terragruntOptions, "hook_"+curHook.Name, map[string]interface{}{
 				"hook": curHook.Name,
 				"dir":  curHook.WorkingDir,
 			}, func(childCtx context.Context) error {
-				return runHook(ctx, terragruntOptions, terragruntConfig, curHook)
+				return runHook(childCtx, terragruntOptions, terragruntConfig, curHook)
 			})
 			if err != nil {
 				errorsOccured = multierror.Append(errorsOccured, err)
@@ -113,9 +142,50 @@ func processHooks(ctx context.Context, hooks []config.Hook, terragruntOptions *o
 		}
 	}
 
+	runParallelHooks()
+
 	return errorsOccured.ErrorOrNil()
 }
 
+// isParallelHook returns true if the hook can run at the same time as its neighbours. tflint hooks always run alone
+// since tflint is not thread safe.
+func isParallelHook(hook config.Hook) bool {
+	return hook.Parallel != nil && *hook.Parallel && hook.Execute[0] != tflintHookCommand
+}
+
+// runHooksInParallel runs the given hooks at the same time, limited by --terragrunt-parallelism.
+func runHooksInParallel(ctx context.Context, terragruntOptions *options.TerragruntOptions, hooks []config.Hook) error {
+	commands := make([]shell.ShellCommandSpec, 0, len(hooks))
+
+	for _, curHook := range hooks {
+		terragruntOptions.Logger.Infof("Executing hook: %s", curHook.Name)
+
+		command := shell.ShellCommandSpec{
+			Opts:           terragruntOptionsWithHookEnvs(terragruntOptions, curHook.Name),
+			Command:        curHook.Execute[0],
+			Args:           curHook.Execute[1:],
+			SuppressStdout: curHook.SuppressStdout != nil && *curHook.SuppressStdout,
+		}
+
+		if curHook.WorkingDir != nil {
+			command.WorkingDir = *curHook.WorkingDir
+		}
+
+		commands = append(commands, command)
+	}
+
+	return telemetry.Telemetry(ctx, terragruntOptions, "parallel_hooks", map[string]interface{}{
+		"hooks": len(hooks),
+	}, func(childCtx context.Context) error {
+		if _, err := shell.RunShellCommandsInParallel(childCtx, terragruntOptions, terragruntOptions.Parallelism, commands); err != nil {
+			terragruntOptions.Logger.Errorf("Error running parallel hooks with message: %s", err.Error())
+			return err
+		}
+
+		return nil
+	})
+}
+
 func shouldRunHook(hook config.Hook, terragruntOptions *options.TerragruntOptions, previousExecErrors *multierror.Error) bool {
 	// if there's no previous error, execute command
 	// OR if a previous error DID happen AND we want to run anyways
@@ -146,7 +216,7 @@ func runHook(ctx context.Context, terragruntOptions *options.TerragruntOptions,
 	actionParams := curHook.Execute[1:]
 	terragruntOptions = terragruntOptionsWithHookEnvs(terragruntOptions, curHook.Name)
 
-	if actionToExecute == "tflint" {
+	if actionToExecute == tflintHookCommand {
 		if err := executeTFLint(ctx, terragruntOptions, terragruntConfig, curHook, workingDir); err != nil {
 			return err
 		}
@@ -157,6 +227,7 @@ func runHook(ctx context.Context, terragruntOptions *options.TerragruntOptions,
 			workingDir,
 			suppressStdout,
 			false,
+			false,
 			actionToExecute, actionParams...,
 		)
 		if possibleError != nil {
@@ -0,0 +1,59 @@
+package terraform
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessHooksParallel(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	// each parallel hook creates its own marker and waits for the marker of the other one, so both of them only
+	// succeed if they run at the same time. The last hook runs once both of them are finished.
+	cfg := fmt.Sprintf(`
+terraform {
+  before_hook "first" {
+    commands    = ["apply"]
+    execute     = ["sh", "-c", "touch first; i=0; while [ ! -f second ]; do i=$((i+1)); [ $i -gt 50 ] && exit 1; sleep 0.1; done"]
+    working_dir = %[1]q
+    parallel    = true
+  }
+
+  before_hook "second" {
+    commands    = ["apply"]
+    execute     = ["sh", "-c", "touch second; i=0; while [ ! -f first ]; do i=$((i+1)); [ $i -gt 50 ] && exit 1; sleep 0.1; done"]
+    working_dir = %[1]q
+    parallel    = true
+  }
+
+  before_hook "after_parallel" {
+    commands    = ["apply"]
+    execute     = ["sh", "-c", "test -f first && test -f second && touch done"]
+    working_dir = %[1]q
+  }
+}
+`, tmpDir)
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(tmpDir, config.DefaultTerragruntConfigPath))
+	require.NoError(t, err)
+
+	opts.TerraformCommand = "apply"
+
+	ctx := config.NewParsingContext(context.Background(), opts)
+	terragruntConfig, err := config.ParseConfigString(ctx, opts.TerragruntConfigPath, cfg, nil)
+	require.NoError(t, err)
+
+	err = processHooks(context.Background(), terragruntConfig.Terraform.GetBeforeHooks(), opts, terragruntConfig, nil)
+	require.NoError(t, err)
+
+	assert.FileExists(t, filepath.Join(tmpDir, "done"))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
 	"regexp"
 	"strings"
 
@@ -88,9 +89,19 @@ func PopulateTerraformVersion(ctx context.Context, terragruntOptions *options.Te
 	// the arguments supplied on the command line and cause issues when running
 	// the --version command.
 	// https://www.terraform.io/docs/commands/environment-variables.html#tf_cli_args-and-tf_cli_args_name
+	// They are set to empty values rather than deleted, since the command also inherits the environment of the
+	// Terragrunt process when `InheritEnv` is set.
 	for key := range terragruntOptionsCopy.Env {
 		if strings.HasPrefix(key, "TF_CLI_ARGS") {
-			delete(terragruntOptionsCopy.Env, key)
+			terragruntOptionsCopy.Env[key] = ""
+		}
+	}
+
+	if terragruntOptionsCopy.InheritEnv {
+		for _, envVar := range os.Environ() {
+			if key, _, _ := strings.Cut(envVar, "="); strings.HasPrefix(key, "TF_CLI_ARGS") {
+				terragruntOptionsCopy.Env[key] = ""
+			}
 		}
 	}
 
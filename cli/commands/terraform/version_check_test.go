@@ -2,16 +2,47 @@
 package terraform_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/gruntwork-io/go-commons/errors"
 	"github.com/gruntwork-io/terragrunt/cli/commands/terraform"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/shell"
+	"github.com/gruntwork-io/terragrunt/util"
 	"github.com/hashicorp/go-version"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 // Terraform Version Checking
+// The test is not parallel, since it sets an env var of the process.
+func TestPopulateTerraformVersionWithoutTFCLIArgs(t *testing.T) {
+	t.Setenv("TF_CLI_ARGS", "-no-color")
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	terragruntOptions.Env = map[string]string{"TF_CLI_ARGS_plan": "-lock=false"}
+
+	var env map[string]string
+
+	ctx := shell.ContextWithShellCommandHook(context.Background(), func(_ context.Context, opts *options.TerragruntOptions, _ string, _ []string) (*util.CmdOutput, error) {
+		env = opts.Env
+		return &util.CmdOutput{Stdout: "Terraform v1.5.7\n"}, nil
+	})
+
+	require.NoError(t, terraform.PopulateTerraformVersion(ctx, terragruntOptions))
+	assert.Equal(t, "1.5.7", terragruntOptions.TerraformVersion.String())
+
+	// the inherited env vars are overridden with empty values
+	for _, key := range []string{"TF_CLI_ARGS", "TF_CLI_ARGS_plan"} {
+		require.Contains(t, env, key)
+		assert.Empty(t, env[key])
+	}
+	assert.Equal(t, map[string]string{"TF_CLI_ARGS_plan": "-lock=false"}, terragruntOptions.Env)
+}
+
 func TestCheckTerraformVersionMeetsConstraintEqual(t *testing.T) {
 	t.Parallel()
 	testCheckTerraformVersionMeetsConstraint(t, "v0.9.3", ">= v0.9.3", true)
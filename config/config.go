@@ -62,6 +62,8 @@ const (
 	MetadataLocal                       = "local"
 	MetadataCatalog                     = "catalog"
 	MetadataEngine                      = "engine"
+	MetadataSetEnv                      = "set_env"
+	MetadataEngineMeta                  = "engine_meta"
 	MetadataGenerateConfigs             = "generate"
 	MetadataRetryableErrors             = "retryable_errors"
 	MetadataRetryMaxAttempts            = "retry_max_attempts"
@@ -115,6 +117,8 @@ type TerragruntConfig struct {
 	RetryMaxAttempts            *int
 	RetrySleepIntervalSec       *int
 	Engine                      *EngineConfig
+	SetEnv                      map[string]string
+	EngineMeta                  map[string]string
 
 	// Fields used for internal tracking
 	// Indicates whether this is the result of a partial evaluation
@@ -208,6 +212,20 @@ type terragruntConfigFile struct {
 	RetryMaxAttempts      *int     `hcl:"retry_max_attempts,optional"`
 	RetrySleepIntervalSec *int     `hcl:"retry_sleep_interval_sec,optional"`
 
+	// Environment variables that are set only for the commands run in this module:
+	//
+	// set_env {
+	//   FOO = "bar"
+	// }
+	SetEnv *terragruntStringMapBlock `hcl:"set_env,block"`
+
+	// Metadata passed to the engine plugin, in addition to the `meta` of the `engine` block:
+	//
+	// engine_meta {
+	//   registry_token = "..."
+	// }
+	EngineMeta *terragruntStringMapBlock `hcl:"engine_meta,block"`
+
 	// This struct is used for validating and parsing the entire terragrunt config. Since locals and include are
 	// evaluated in a completely separate cycle, it should not be evaluated here. Otherwise, we can't support self
 	// referencing other elements in the same block.
@@ -284,6 +302,12 @@ type remoteStateConfigGenerate struct {
 	IfExists string `cty:"if_exists"`
 }
 
+// Struct used to parse the blocks whose attributes are arbitrary string values, such as `set_env` and `engine_meta`. gohcl only decodes
+// blocks into structs, the attributes are collected by the `remain` map.
+type terragruntStringMapBlock struct {
+	Values map[string]string `hcl:",remain"`
+}
+
 // Struct used to parse generate blocks. This will later be converted to GenerateConfig structs so that we can go
 // through the codegen routine.
 type terragruntGenerateBlock struct {
@@ -407,6 +431,7 @@ type Hook struct {
 	RunOnError     *bool    `hcl:"run_on_error,attr" cty:"run_on_error"`
 	SuppressStdout *bool    `hcl:"suppress_stdout,attr" cty:"suppress_stdout"`
 	WorkingDir     *string  `hcl:"working_dir,attr" cty:"working_dir"`
+	Parallel       *bool    `hcl:"parallel,attr" cty:"parallel"`
 }
 
 type ErrorHook struct {
@@ -1141,6 +1166,16 @@ func convertToTerragruntConfig(ctx *ParsingContext, configPath string, terragrun
 		terragruntConfig.SetFieldMetadata(MetadataEngine, defaultMetadata)
 	}
 
+	if terragruntConfigFromFile.SetEnv != nil {
+		terragruntConfig.SetEnv = terragruntConfigFromFile.SetEnv.Values
+		terragruntConfig.SetFieldMetadata(MetadataSetEnv, defaultMetadata)
+	}
+
+	if terragruntConfigFromFile.EngineMeta != nil {
+		terragruntConfig.EngineMeta = terragruntConfigFromFile.EngineMeta.Values
+		terragruntConfig.SetFieldMetadata(MetadataEngineMeta, defaultMetadata)
+	}
+
 	generateBlocks := []terragruntGenerateBlock{}
 	generateBlocks = append(generateBlocks, terragruntConfigFromFile.GenerateBlocks...)
 
@@ -1404,5 +1439,7 @@ func (cfg *TerragruntConfig) EngineOptions() (*options.EngineOptions, error) {
 		Version: version,
 		Type:    engineType,
 		Meta:    meta,
+
+		SkipChecksumCheck: cfg.Engine.SkipChecksumCheck,
 	}, nil
 }
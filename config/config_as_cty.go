@@ -105,6 +105,24 @@ func TerragruntConfigAsCty(config *TerragruntConfig) (cty.Value, error) {
 		output[MetadataRetryableErrors] = retryableCty
 	}
 
+	setEnvCty, err := goTypeToCty(config.SetEnv)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	if setEnvCty != cty.NilVal {
+		output[MetadataSetEnv] = setEnvCty
+	}
+
+	engineMetaCty, err := goTypeToCty(config.EngineMeta)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	if engineMetaCty != cty.NilVal {
+		output[MetadataEngineMeta] = engineMetaCty
+	}
+
 	iamAssumeRoleDurationCty, err := goTypeToCty(config.IamAssumeRoleDuration)
 	if err != nil {
 		return cty.NilVal, err
@@ -206,6 +224,14 @@ func TerragruntConfigAsCtyWithMetadata(config *TerragruntConfig) (cty.Value, err
 		return cty.NilVal, err
 	}
 
+	if err := wrapWithMetadata(config, config.SetEnv, MetadataSetEnv, &output); err != nil {
+		return cty.NilVal, err
+	}
+
+	if err := wrapWithMetadata(config, config.EngineMeta, MetadataEngineMeta, &output); err != nil {
+		return cty.NilVal, err
+	}
+
 	if err := wrapWithMetadata(config, config.IamAssumeRoleDuration, MetadataIamAssumeRoleDuration, &output); err != nil {
 		return cty.NilVal, err
 	}
@@ -447,6 +473,8 @@ type ctyEngineConfig struct {
 	Version string    `cty:"version"`
 	Type    string    `cty:"type"`
 	Meta    cty.Value `cty:"meta"`
+
+	SkipChecksumCheck *bool `cty:"skip_checksum_check"`
 }
 
 // Serialize CatalogConfig to a cty Value, but with maps instead of lists for the blocks.
@@ -487,6 +515,8 @@ func engineConfigAsCty(config *EngineConfig) (cty.Value, error) {
 		Version: v,
 		Type:    t,
 		Meta:    ctyMetaVal,
+
+		SkipChecksumCheck: config.SkipChecksumCheck,
 	}
 
 	return goTypeToCty(configCty)
@@ -90,6 +90,12 @@ func TestTerragruntConfigAsCtyDrift(t *testing.T) {
 		Locals: map[string]interface{}{
 			"quote": "the answer is 42",
 		},
+		SetEnv: map[string]string{
+			"FOO": "bar",
+		},
+		EngineMeta: map[string]string{
+			"registry_token": "token",
+		},
 		DependentModulesPath: dependentModulesPath,
 		TerragruntDependencies: config.Dependencies{
 			config.Dependency{
@@ -249,6 +255,10 @@ func terragruntConfigStructFieldToMapKey(t *testing.T, fieldName string) (string
 		return "dependent_modules", true
 	case "Engine":
 		return "engine", true
+	case "SetEnv":
+		return "set_env", true
+	case "EngineMeta":
+		return "engine_meta", true
 	default:
 		t.Fatalf("Unknown struct property: %s", fieldName)
 		// This should not execute
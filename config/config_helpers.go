@@ -1,6 +1,8 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	goErrors "errors"
 	"fmt"
@@ -9,6 +11,7 @@ import (
 	"reflect"
 	"regexp"
 	"runtime"
+	"sort"
 	"strings"
 	"unicode/utf8"
 
@@ -17,6 +20,7 @@ import (
 	"github.com/getsops/sops/v3/cmd/sops/formats"
 	"github.com/getsops/sops/v3/decrypt"
 	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl/v2"
 	tflang "github.com/hashicorp/terraform/lang"
 	"github.com/zclconf/go-cty/cty"
@@ -66,12 +70,17 @@ const (
 	FuncNameGetDefaultRetryableErrors               = "get_default_retryable_errors"
 	FuncNameReadTfvarsFile                          = "read_tfvars_file"
 	FuncNameGetWorkingDir                           = "get_working_dir"
+	FuncNameGetWorkingDirHash                       = "get_working_dir_hash"
 	FuncNameStartsWith                              = "startswith"
 	FuncNameEndsWith                                = "endswith"
 	FuncNameStrContains                             = "strcontains"
 	FuncNameTimeCmp                                 = "timecmp"
+	FuncNameSemverTags                              = "semver_tags"
 
 	sopsCacheName = "sopsCache"
+
+	// workingDirHashLength is the number of hex characters returned by get_working_dir_hash.
+	workingDirHashLength = 6
 )
 
 // TerraformCommandsNeedLocking is a list of terraform commands that accept -lock-timeout
@@ -169,6 +178,8 @@ func createTerragruntEvalContext(ctx *ParsingContext, configPath string) (*hcl.E
 		FuncNameGetDefaultRetryableErrors:               wrapVoidToStringSliceAsFuncImpl(ctx, getDefaultRetryableErrors),
 		FuncNameReadTfvarsFile:                          wrapStringSliceToStringAsFuncImpl(ctx, readTFVarsFile),
 		FuncNameGetWorkingDir:                           wrapVoidToStringAsFuncImpl(ctx, getWorkingDir),
+		FuncNameGetWorkingDirHash:                       wrapVoidToStringAsFuncImpl(ctx, getWorkingDirHash),
+		FuncNameSemverTags:                              wrapStringListToStringSliceAsFuncImpl(ctx, SemverTags),
 
 		// Map with HCL functions introduced in Terraform after v0.15.3, since upgrade to a later version is not supported
 		// https://github.com/gruntwork-io/terragrunt/blob/master/go.mod#L22
@@ -366,7 +377,7 @@ func RunCommand(ctx *ParsingContext, args []string) (string, error) {
 		return cachedValue, nil
 	}
 
-	cmdOutput, err := shell.RunShellCommandWithOutput(ctx, ctx.TerragruntOptions, currentPath, suppressOutput, false, args[0], args[1:]...)
+	cmdOutput, err := shell.RunShellCommandWithOutput(ctx, ctx.TerragruntOptions, currentPath, suppressOutput, false, false, args[0], args[1:]...)
 	if err != nil {
 		return "", errors.WithStackTrace(err)
 	}
@@ -567,6 +578,23 @@ func getWorkingDir(ctx *ParsingContext) (string, error) {
 	return source.WorkingDir, nil
 }
 
+// getWorkingDirHash returns a short hex hash of the absolute working dir, which can be added to resource names to
+// avoid collisions when the same config runs in different directory trees.
+func getWorkingDirHash(ctx *ParsingContext) (string, error) {
+	if !ctx.TerragruntOptions.WorkingDirHash {
+		return "", errors.WithStackTrace(WorkingDirHashDisabledError{})
+	}
+
+	workingDir, err := filepath.Abs(ctx.TerragruntOptions.WorkingDir)
+	if err != nil {
+		return "", errors.WithStackTrace(err)
+	}
+
+	hash := sha256.Sum256([]byte(workingDir))
+
+	return hex.EncodeToString(hash[:])[:workingDirHashLength], nil
+}
+
 // getTerraformCliArgs returns cli args for terraform
 func getTerraformCliArgs(ctx *ParsingContext) ([]string, error) {
 	return ctx.TerragruntOptions.TerraformCliArgs, nil
@@ -956,6 +984,20 @@ func StrContains(ctx *ParsingContext, args []string) (bool, error) {
 	return false, nil
 }
 
+// SemverTags returns the semver-valid tags of the given list sorted in ascending order, the `refs/tags/` prefix is
+// removed and non-semver tags are skipped.
+func SemverTags(ctx *ParsingContext, tags []string) ([]string, error) {
+	semverTags := shell.ExtractSemVerTags(tags)
+	sort.Sort(version.Collection(semverTags))
+
+	out := make([]string, 0, len(semverTags))
+	for _, tag := range semverTags {
+		out = append(out, tag.Original())
+	}
+
+	return out, nil
+}
+
 // readTFVarsFile reads a *.tfvars or *.tfvars.json file and returns the contents as a JSON encoded string
 func readTFVarsFile(ctx *ParsingContext, args []string) (string, error) {
 	if len(args) != 1 {
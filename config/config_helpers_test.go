@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"testing"
 
 	"github.com/gruntwork-io/go-commons/errors"
@@ -1205,6 +1206,63 @@ func TestStrContains(t *testing.T) {
 	}
 }
 
+func TestSemverTags(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		expr     string
+		expected []interface{}
+	}{
+		{`semver_tags(["refs/tags/v1.10.0", "refs/tags/v1.2.0", "v1.9.0-rc.1"])`, []interface{}{"v1.2.0", "v1.9.0-rc.1", "v1.10.0"}},
+		{`semver_tags(["latest", "refs/tags/v0.1.0", "refs/heads/main", "release"])`, []interface{}{"v0.1.0"}},
+		{`semver_tags(["latest", "stable"])`, []interface{}{}},
+		{`semver_tags([])`, []interface{}{}},
+	}
+
+	for i, tt := range tc {
+		tt := tt
+
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			cfg := fmt.Sprintf("inputs = {\n  tags = %s\n}", tt.expr)
+
+			ctx := config.NewParsingContext(context.Background(), terragruntOptionsForTest(t, config.DefaultTerragruntConfigPath))
+			terragruntConfig, err := config.ParseConfigString(ctx, config.DefaultTerragruntConfigPath, cfg, nil)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, terragruntConfig.Inputs["tags"])
+		})
+	}
+}
+
+func TestGetWorkingDirHash(t *testing.T) {
+	t.Parallel()
+
+	cfg := "inputs = {\n  hash = get_working_dir_hash()\n}"
+
+	hashes := make([]interface{}, 2)
+
+	for i := range hashes {
+		opts := terragruntOptionsForTest(t, config.DefaultTerragruntConfigPath)
+		opts.WorkingDir = t.TempDir()
+		opts.WorkingDirHash = true
+
+		ctx := config.NewParsingContext(context.Background(), opts)
+		terragruntConfig, err := config.ParseConfigString(ctx, config.DefaultTerragruntConfigPath, cfg, nil)
+		require.NoError(t, err)
+
+		hashes[i] = terragruntConfig.Inputs["hash"]
+		assert.Regexp(t, "^[0-9a-f]{6}$", hashes[i])
+	}
+
+	assert.NotEqual(t, hashes[0], hashes[1])
+
+	ctx := config.NewParsingContext(context.Background(), terragruntOptionsForTest(t, config.DefaultTerragruntConfigPath))
+	_, err := config.ParseConfigString(ctx, config.DefaultTerragruntConfigPath, cfg, nil)
+	require.Error(t, err)
+}
+
 func TestReadTFVarsFiles(t *testing.T) {
 	t.Parallel()
 
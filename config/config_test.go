@@ -209,6 +209,65 @@ retryable_errors = [
 	}
 }
 
+func TestParseTerragruntHclConfigSetEnv(t *testing.T) {
+	t.Parallel()
+
+	cfg := `
+locals {
+  region = "us-east-1"
+}
+
+set_env {
+  AWS_REGION = local.region
+  FOO        = "bar"
+}
+`
+	ctx := config.NewParsingContext(context.Background(), mockOptionsForTest(t))
+	terragruntConfig, err := config.ParseConfigString(ctx, config.DefaultTerragruntConfigPath, cfg, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"AWS_REGION": "us-east-1", "FOO": "bar"}, terragruntConfig.SetEnv)
+}
+
+func TestParseTerragruntHclConfigEngineMeta(t *testing.T) {
+	t.Parallel()
+
+	cfg := `
+engine {
+  source = "github.com/gruntwork-io/terragrunt-engine-opentofu"
+}
+
+engine_meta {
+  registry_token = "token"
+  feature_flag   = "enabled"
+}
+`
+	ctx := config.NewParsingContext(context.Background(), mockOptionsForTest(t))
+	terragruntConfig, err := config.ParseConfigString(ctx, config.DefaultTerragruntConfigPath, cfg, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"registry_token": "token", "feature_flag": "enabled"}, terragruntConfig.EngineMeta)
+}
+
+func TestParseTerragruntHclConfigEngineSkipChecksumCheck(t *testing.T) {
+	t.Parallel()
+
+	cfg := `
+engine {
+  source              = "/home/users/iac-engines/terragrunt-iac-engine-opentofu_v0.0.1"
+  skip_checksum_check = true
+}
+`
+	ctx := config.NewParsingContext(context.Background(), mockOptionsForTest(t))
+	terragruntConfig, err := config.ParseConfigString(ctx, config.DefaultTerragruntConfigPath, cfg, nil)
+	require.NoError(t, err)
+
+	engineOptions, err := terragruntConfig.EngineOptions()
+	require.NoError(t, err)
+	require.NotNil(t, engineOptions.SkipChecksumCheck)
+	assert.True(t, *engineOptions.SkipChecksumCheck)
+}
+
 func TestParseTerragruntJsonConfigRetryConfiguration(t *testing.T) {
 	t.Parallel()
 
@@ -129,6 +129,33 @@ func wrapVoidToStringSliceAsFuncImpl(
 	})
 }
 
+// Create a cty Function that takes as input parameter a list of strings and returns as output a string slice. The
+// implementation of the function calls the given toWrap function, passing it the input list as a string slice.
+func wrapStringListToStringSliceAsFuncImpl(
+	ctx *ParsingContext,
+	toWrap func(ctx *ParsingContext, params []string) ([]string, error),
+) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{{Name: "list", Type: cty.List(cty.String)}},
+		Type:   function.StaticReturnType(cty.List(cty.String)),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			params, err := ctySliceToStringSlice(args[0].AsValueSlice())
+			if err != nil {
+				return cty.ListValEmpty(cty.String), err
+			}
+			outVals, err := toWrap(ctx, params)
+			if err != nil || len(outVals) == 0 {
+				return cty.ListValEmpty(cty.String), err
+			}
+			outCtyVals := []cty.Value{}
+			for _, val := range outVals {
+				outCtyVals = append(outCtyVals, cty.StringVal(val))
+			}
+			return cty.ListVal(outCtyVals), nil
+		},
+	})
+}
+
 // Create a cty Function that takes no input parameters and returns as output a string slice. The implementation of the
 // function returns the given string slice.
 func wrapStaticValueToStringSliceAsFuncImpl(out []string) function.Function {
@@ -10,6 +10,8 @@ type EngineConfig struct {
 	Version *string    `hcl:"version,attr" cty:"version"`
 	Type    *string    `hcl:"type,attr" cty:"type"`
 	Meta    *cty.Value `hcl:"meta,attr" cty:"meta"`
+
+	SkipChecksumCheck *bool `hcl:"skip_checksum_check,attr" cty:"skip_checksum_check"`
 }
 
 // Clone returns a copy of the EngineConfig used in deep copy
@@ -19,6 +21,8 @@ func (c *EngineConfig) Clone() *EngineConfig {
 		Version: c.Version,
 		Type:    c.Type,
 		Meta:    c.Meta,
+
+		SkipChecksumCheck: c.SkipChecksumCheck,
 	}
 }
 
@@ -39,4 +43,8 @@ func (c *EngineConfig) Merge(engine *EngineConfig) {
 	if engine.Meta != nil {
 		c.Meta = engine.Meta
 	}
+
+	if engine.SkipChecksumCheck != nil {
+		c.SkipChecksumCheck = engine.SkipChecksumCheck
+	}
 }
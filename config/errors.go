@@ -238,6 +238,12 @@ func (err TerragruntOutputTargetNoOutputs) Error() string {
 	)
 }
 
+type WorkingDirHashDisabledError struct{}
+
+func (err WorkingDirHashDisabledError) Error() string {
+	return "The get_working_dir_hash() function requires the --terragrunt-working-dir-hash flag to be set."
+}
+
 type DependencyCycleError []string
 
 func (err DependencyCycleError) Error() string {
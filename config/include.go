@@ -319,6 +319,14 @@ func (cfg *TerragruntConfig) Merge(sourceConfig *TerragruntConfig, terragruntOpt
 		cfg.Inputs = mergeInputs(sourceConfig.Inputs, cfg.Inputs)
 	}
 
+	if sourceConfig.SetEnv != nil {
+		cfg.SetEnv = mergeStringMaps(sourceConfig.SetEnv, cfg.SetEnv)
+	}
+
+	if sourceConfig.EngineMeta != nil {
+		cfg.EngineMeta = mergeStringMaps(sourceConfig.EngineMeta, cfg.EngineMeta)
+	}
+
 	CopyFieldsMetadata(sourceConfig, cfg)
 
 	return nil
@@ -475,6 +483,14 @@ func (cfg *TerragruntConfig) DeepMerge(sourceConfig *TerragruntConfig, terragrun
 		cfg.Inputs = mergedInputs
 	}
 
+	if sourceConfig.SetEnv != nil {
+		cfg.SetEnv = mergeStringMaps(sourceConfig.SetEnv, cfg.SetEnv)
+	}
+
+	if sourceConfig.EngineMeta != nil {
+		cfg.EngineMeta = mergeStringMaps(sourceConfig.EngineMeta, cfg.EngineMeta)
+	}
+
 	// MAINTAINER'S NOTE: The following structs cannot be deep merged due to an implementation detail (they do not
 	// support nil attributes, so we can't determine if an attribute was intentionally set, or was defaulted from
 	// unspecified - this is especially problematic for bool attributes).
@@ -618,6 +634,20 @@ func mergeInputs(childInputs map[string]interface{}, parentInputs map[string]int
 	return out
 }
 
+// mergeStringMaps merges string maps of the child and parent configs, such as the `set_env` env vars, the child values take precedence.
+func mergeStringMaps(childMap map[string]string, parentMap map[string]string) map[string]string {
+	out := util.CloneStringMap(parentMap)
+	if out == nil {
+		out = make(map[string]string, len(childMap))
+	}
+
+	for key, value := range childMap {
+		out[key] = value
+	}
+
+	return out
+}
+
 func deepMergeInputs(childInputs map[string]interface{}, parentInputs map[string]interface{}) (map[string]interface{}, error) {
 	out := map[string]interface{}{}
 	for key, value := range parentInputs {
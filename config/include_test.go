@@ -144,6 +144,16 @@ func TestMergeConfigIntoIncludedConfig(t *testing.T) {
 			&config.TerragruntConfig{IamWebIdentityToken: "token"},
 			&config.TerragruntConfig{IamWebIdentityToken: "token"},
 		},
+		{
+			&config.TerragruntConfig{SetEnv: map[string]string{"FOO": "child", "BAR": "child"}},
+			&config.TerragruntConfig{SetEnv: map[string]string{"FOO": "parent", "BAZ": "parent"}},
+			&config.TerragruntConfig{SetEnv: map[string]string{"FOO": "child", "BAR": "child", "BAZ": "parent"}},
+		},
+		{
+			&config.TerragruntConfig{EngineMeta: map[string]string{"token": "child"}},
+			&config.TerragruntConfig{EngineMeta: map[string]string{"token": "parent", "region": "parent"}},
+			&config.TerragruntConfig{EngineMeta: map[string]string{"token": "child", "region": "parent"}},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -81,3 +81,9 @@ type DependencyNotFoundWhileCrossLinkingError struct {
 func (err DependencyNotFoundWhileCrossLinkingError) Error() string {
 	return fmt.Sprintf("Module %v specifies a dependency on module %v, but could not find that module while cross-linking dependencies. This is most likely a bug in Terragrunt. Please report it.", err.Module, err.Dependency)
 }
+
+type UnsupportedRunAllReportFormatError string
+
+func (err UnsupportedRunAllReportFormatError) Error() string {
+	return fmt.Sprintf("Unsupported run-all report format %q, supported formats are %s", string(err), strings.Join(runAllReportFormats, ", "))
+}
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
@@ -24,6 +26,9 @@ import (
 const maxLevelsOfRecursion = 20
 const existingModulesCacheName = "existingModules"
 
+// gzipPlanFileExt is appended to the plan file name when the plan output is gzip-compressed.
+const gzipPlanFileExt = ".gz"
+
 // TerraformModule represents a single module (i.e. folder with Terraform templates), including the Terragrunt configuration for that
 // module and the list of other modules that this module depends on
 type TerraformModule struct {
@@ -112,7 +117,75 @@ func (module *TerraformModule) outputFile(opts *options.TerragruntOptions) strin
 	return planFile
 }
 
-// outputJSONFile - return plan JSON file location, if JSON output folder is set
+// compressPlanFile - gzip-compresses the plan file saved in the output folder and removes the uncompressed one
+func (module *TerraformModule) compressPlanFile(opts *options.TerragruntOptions) error {
+	planFile := module.outputFile(opts)
+
+	if !opts.GzipPlanOutput || planFile == "" || !util.FileExists(planFile) {
+		return nil
+	}
+
+	opts.Logger.Debugf("Compressing plan file %s for module %s", planFile, module.Path)
+
+	if err := util.GzipFile(planFile, planFile+gzipPlanFileExt); err != nil {
+		return err
+	}
+
+	return errors.WithStackTrace(os.Remove(planFile))
+}
+
+// decompressPlanFile - replaces the gzip-compressed plan file of the module in the terraform cli args with its
+// decompressed copy stored in a temporary directory, returns a function that removes the temporary directory and
+// restores the args. The other args are left as is, even if they look like compressed files.
+func (module *TerraformModule) decompressPlanFile(rootOpts *options.TerragruntOptions) (func(), error) {
+	opts := module.TerragruntOptions
+
+	outputFile := module.outputFile(rootOpts)
+	if !rootOpts.GzipPlanOutput || outputFile == "" {
+		return func() {}, nil
+	}
+
+	compressedFile := outputFile + gzipPlanFileExt
+
+	index := slices.Index(opts.TerraformCliArgs, compressedFile)
+	if index < 0 || !util.FileExists(compressedFile) {
+		return func() {}, nil
+	}
+
+	tempDir, err := os.MkdirTemp("", "terragrunt-plan-")
+	if err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	removeTempDir := func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			opts.Logger.Warnf("Failed to remove temp dir %s: %v", tempDir, err)
+		}
+	}
+
+	planFile := filepath.Join(tempDir, filepath.Base(outputFile))
+
+	opts.Logger.Debugf("Decompressing plan file %s to %s", compressedFile, planFile)
+
+	if err := util.GunzipFile(compressedFile, planFile); err != nil {
+		removeTempDir()
+		return nil, err
+	}
+
+	originalArgs := opts.TerraformCliArgs
+
+	opts.TerraformCliArgs = util.CloneStringList(originalArgs)
+	opts.TerraformCliArgs[index] = planFile
+
+	return func() {
+		opts.TerraformCliArgs = originalArgs
+
+		removeTempDir()
+	}, nil
+}
+
+// outputJSONFile - return plan JSON file location, if JSON output folder is set or if the JSON plan should be saved
+// alongside the plan file in the output folder
 func (module *TerraformModule) outputJSONFile(opts *options.TerragruntOptions) string {
 	jsonPlanFile := ""
 
@@ -120,20 +193,17 @@ func (module *TerraformModule) outputJSONFile(opts *options.TerragruntOptions) s
 		path, _ := filepath.Rel(opts.WorkingDir, module.Path)
 		dir := filepath.Join(opts.JSONOutputFolder, path)
 		jsonPlanFile = filepath.Join(dir, terraform.TerraformPlanJSONFile)
+	} else if opts.SavePlanJSON && opts.OutputFolder != "" {
+		dir := filepath.Dir(module.outputFile(opts))
+		jsonPlanFile = filepath.Join(dir, terraform.TerraformSavedPlanJSONFile)
 	}
 
 	return jsonPlanFile
 }
 
-// findModuleInPath returns true if a module is located under one of the target directories
+// findModuleInPath returns true if a module is located under one of the target directories, which can be glob patterns.
 func (module *TerraformModule) findModuleInPath(targetDirs []string) bool {
-	for _, targetDir := range targetDirs {
-		if module.Path == targetDir {
-			return true
-		}
-	}
-
-	return false
+	return util.MatchesAnyPath(targetDirs, module.Path)
 }
 
 // Confirm with the user whether they want Terragrunt to assume the given dependency of the given module is already
@@ -323,6 +393,46 @@ func (modules TerraformModules) WriteDot(w io.Writer, terragruntOptions *options
 	return nil
 }
 
+// ModuleGraphNode is a module and the modules it depends on, in the JSON representation of the dependency graph written
+// by `WriteJSONGraph`.
+type ModuleGraphNode struct {
+	Module       string   `json:"module"`
+	Dependencies []string `json:"dependencies"`
+}
+
+// WriteJSONGraph writes the dependency graph of the modules as a JSON adjacency list, in the same order and with the
+// same paths as `WriteDot`.
+func (modules TerraformModules) WriteJSONGraph(w io.Writer, terragruntOptions *options.TerragruntOptions) error {
+	// all paths are relative to the TerragruntConfigPath
+	prefix := filepath.Dir(terragruntOptions.TerragruntConfigPath) + "/"
+
+	nodes := make([]ModuleGraphNode, 0, len(modules))
+
+	for _, source := range modules {
+		node := ModuleGraphNode{
+			Module:       strings.TrimPrefix(source.Path, prefix),
+			Dependencies: make([]string, 0, len(source.Dependencies)),
+		}
+
+		for _, target := range source.Dependencies {
+			node.Dependencies = append(node.Dependencies, strings.TrimPrefix(target.Path, prefix))
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	content, err := json.MarshalIndent(nodes, "", "  ")
+	if err != nil {
+		return errors.WithStackTrace(err)
+	}
+
+	if _, err := w.Write(append(content, '\n')); err != nil {
+		return errors.WithStackTrace(err)
+	}
+
+	return nil
+}
+
 // RunModules runs the given map of module path to runningModule. To "run" a module, execute the RunTerragrunt command in its
 // TerragruntOptions object. The modules will be executed in an order determined by their inter-dependencies, using
 // as much concurrency as possible.
@@ -445,6 +555,55 @@ func (modules TerraformModules) flagIncludedDirs(terragruntOptions *options.Terr
 	return modules
 }
 
+// pruneDisconnectedModules removes all modules that have no dependency path, in either direction, to a module matched by
+// the terragrunt-include-dir CLI flag, so that unrelated parts of the graph are dropped before execution.
+func (modules TerraformModules) pruneDisconnectedModules(terragruntOptions *options.TerragruntOptions) TerraformModules {
+	if !terragruntOptions.ExcludeByDefault || !terragruntOptions.ModuleDependencyGraphPrune {
+		return modules
+	}
+
+	neighbours := map[string][]string{}
+
+	for _, module := range modules {
+		for _, dependency := range module.Dependencies {
+			neighbours[module.Path] = append(neighbours[module.Path], dependency.Path)
+			neighbours[dependency.Path] = append(neighbours[dependency.Path], module.Path)
+		}
+	}
+
+	connected := map[string]bool{}
+	queue := []string{}
+
+	for _, module := range modules {
+		if module.findModuleInPath(terragruntOptions.IncludeDirs) {
+			connected[module.Path] = true
+			queue = append(queue, module.Path)
+		}
+	}
+
+	for len(queue) > 0 {
+		path := queue[0]
+		queue = queue[1:]
+
+		for _, neighbour := range neighbours[path] {
+			if !connected[neighbour] {
+				connected[neighbour] = true
+				queue = append(queue, neighbour)
+			}
+		}
+	}
+
+	prunedModules := TerraformModules{}
+
+	for _, module := range modules {
+		if connected[module.Path] {
+			prunedModules = append(prunedModules, module)
+		}
+	}
+
+	return prunedModules
+}
+
 // flagModulesThatDontInclude iterates over a module slice and flags all modules that don't include at least one file in
 // the specified include list on the TerragruntOptions ModulesThatInclude attribute. Flagged modules will be filtered
 // out of the set.
@@ -512,6 +671,50 @@ func (modules TerraformModules) flagModulesThatDontInclude(terragruntOptions *op
 	return modules, nil
 }
 
+// flagModulesNotIncludedByPrefix flags as excluded the modules whose path, relative to the working dir, does not start
+// with any of the prefixes specified via the terragrunt-modules-include-by-prefix CLI flag.
+func (modules TerraformModules) flagModulesNotIncludedByPrefix(terragruntOptions *options.TerragruntOptions) (TerraformModules, error) {
+	if len(terragruntOptions.ModulesIncludeByPrefix) == 0 {
+		return modules, nil
+	}
+
+	workingDir, err := util.CanonicalPath(terragruntOptions.WorkingDir, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	prefixes := make([]string, 0, len(terragruntOptions.ModulesIncludeByPrefix))
+	for _, prefix := range terragruntOptions.ModulesIncludeByPrefix {
+		prefixes = append(prefixes, strings.TrimPrefix(filepath.ToSlash(prefix), "./"))
+	}
+
+	for _, module := range modules {
+		// Like the other filters, this only narrows down the modules that are not excluded yet.
+		if module.FlagExcluded {
+			continue
+		}
+
+		relPath, err := filepath.Rel(workingDir, module.Path)
+		if err != nil {
+			return nil, errors.WithStackTrace(err)
+		}
+
+		// The trailing slash lets the `services/` prefix match the `services` module itself.
+		relPath = filepath.ToSlash(relPath) + "/"
+
+		module.FlagExcluded = true
+
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(relPath, prefix) {
+				module.FlagExcluded = false
+				break
+			}
+		}
+	}
+
+	return modules, nil
+}
+
 var existingModules = cache.NewCache[*TerraformModulesMap](existingModulesCacheName)
 
 type TerraformModulesMap map[string]*TerraformModule
@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/configstack"
 	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -131,6 +134,27 @@ digraph {
 	assert.True(t, strings.Contains(stdout.String(), expected))
 }
 
+func TestJSONGraph(t *testing.T) {
+	t.Parallel()
+
+	a := &configstack.TerraformModule{Path: "/config/a"}
+	b := &configstack.TerraformModule{Path: "/config/alpha/b", Dependencies: []*configstack.TerraformModule{a}}
+	c := &configstack.TerraformModule{Path: "/config/c", Dependencies: []*configstack.TerraformModule{a, b}}
+
+	modules := configstack.TerraformModules{a, b, c}
+
+	var stdout bytes.Buffer
+	terragruntOptions, _ := options.NewTerragruntOptionsWithConfigPath("/config/terragrunt.hcl")
+	require.NoError(t, modules.WriteJSONGraph(&stdout, terragruntOptions))
+
+	expected := `[
+	{"module": "a", "dependencies": []},
+	{"module": "alpha/b", "dependencies": ["a"]},
+	{"module": "c", "dependencies": ["a", "alpha/b"]}
+]`
+	assert.JSONEq(t, expected, stdout.String())
+}
+
 func TestCheckForCycles(t *testing.T) {
 	t.Parallel()
 
@@ -1238,3 +1262,66 @@ func TestRunModulesReverseOrderMultipleModulesWithDependenciesLargeGraphPartialF
 	assert.True(t, eRan)
 	assert.True(t, fRan)
 }
+
+func TestRunModulesApplyGzipPlanFile(t *testing.T) {
+	t.Parallel()
+
+	stackDir := t.TempDir()
+	outputDir := t.TempDir()
+
+	planContents := []byte("plan contents")
+	planFile := filepath.Join(outputDir, "a", "tfplan.tfplan")
+	require.NoError(t, os.MkdirAll(filepath.Dir(planFile), os.ModePerm))
+	require.NoError(t, os.WriteFile(planFile, planContents, 0644))
+	require.NoError(t, util.GzipFile(planFile, planFile+".gz"))
+
+	// a compressed file that is not the plan file of the module is passed as is
+	otherFile := filepath.Join(t.TempDir(), "vars.gz")
+	require.NoError(t, os.WriteFile(otherFile, []byte{}, 0644))
+
+	rootOpts, err := options.NewTerragruntOptionsForTest(filepath.Join(stackDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	rootOpts.OutputFolder = outputDir
+	rootOpts.GzipPlanOutput = true
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(stackDir, "a", "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	var appliedArgs []string
+
+	args := []string{"apply", "-var-file=" + otherFile, otherFile, planFile + ".gz"}
+
+	opts.TerraformCommand = "apply"
+	opts.TerraformCliArgs = util.CloneStringList(args)
+	opts.RunTerragrunt = func(_ context.Context, opts *options.TerragruntOptions) error {
+		appliedArgs = util.CloneStringList(opts.TerraformCliArgs)
+
+		contents, err := os.ReadFile(appliedArgs[3])
+		require.NoError(t, err)
+		assert.Equal(t, planContents, contents)
+
+		return nil
+	}
+
+	moduleA := &configstack.TerraformModule{
+		Path:              filepath.Join(stackDir, "a"),
+		Dependencies:      configstack.TerraformModules{},
+		Config:            config.TerragruntConfig{},
+		TerragruntOptions: opts,
+	}
+
+	modules := configstack.TerraformModules{moduleA}
+	err = modules.RunModules(context.Background(), rootOpts, options.DefaultParallelism)
+	require.NoError(t, err, "Unexpected error: %v", err)
+
+	require.Len(t, appliedArgs, len(args))
+	assert.Equal(t, args[:3], appliedArgs[:3])
+	assert.Equal(t, "tfplan.tfplan", filepath.Base(appliedArgs[3]))
+	assert.NoFileExists(t, appliedArgs[3], "temporary plan file should be removed")
+
+	// the args of the module and the compressed files are left as is
+	assert.Equal(t, args, opts.TerraformCliArgs)
+	assert.FileExists(t, planFile+".gz")
+	assert.FileExists(t, otherFile)
+}
@@ -0,0 +1,156 @@
+package configstack
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/gruntwork-io/go-commons/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/util"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	RunAllReportFormatJSON = "json"
+	RunAllReportFormatYAML = "yaml"
+
+	// reportOutputExcerptSize is the maximum number of trailing bytes of stdout/stderr kept for each module in the report.
+	reportOutputExcerptSize = 4096
+
+	reportStatusSucceeded = "succeeded"
+	reportStatusFailed    = "failed"
+	reportStatusSkipped   = "skipped"
+)
+
+var runAllReportFormats = []string{RunAllReportFormatJSON, RunAllReportFormatYAML}
+
+// RunAllReport is the structured record of a `run-all` execution written to the file set by --terragrunt-run-all-report-file.
+type RunAllReport struct {
+	Command string          `json:"command" yaml:"command"`
+	Modules []*ModuleReport `json:"modules" yaml:"modules"`
+}
+
+// ModuleReport describes the execution result of a single module.
+type ModuleReport struct {
+	Path         string    `json:"path" yaml:"path"`
+	Dependencies []string  `json:"dependencies" yaml:"dependencies"`
+	Status       string    `json:"status" yaml:"status"`
+	StartedAt    time.Time `json:"started_at" yaml:"started_at"`
+	FinishedAt   time.Time `json:"finished_at" yaml:"finished_at"`
+	Duration     float64   `json:"duration_seconds" yaml:"duration_seconds"`
+	ExitCode     int       `json:"exit_code" yaml:"exit_code"`
+	Error        string    `json:"error,omitempty" yaml:"error,omitempty"`
+	Stdout       string    `json:"stdout" yaml:"stdout"`
+	Stderr       string    `json:"stderr" yaml:"stderr"`
+}
+
+// moduleOutput holds the tails of the stdout and stderr streams of a module captured for the report. The writers are
+// safe for concurrent use, since the hooks of a module may run in parallel.
+type moduleOutput struct {
+	stdout *util.TailWriter
+	stderr *util.TailWriter
+}
+
+// newRunAllReport assembles the report from the modules that have finished running.
+func newRunAllReport(command string, modules RunningModules, outputs map[string]*moduleOutput) *RunAllReport {
+	report := &RunAllReport{
+		Command: command,
+		Modules: []*ModuleReport{},
+	}
+
+	for _, module := range modules {
+		moduleReport := &ModuleReport{
+			Path:         module.Module.Path,
+			Dependencies: []string{},
+			Status:       reportStatusSucceeded,
+			StartedAt:    module.StartedAt,
+			FinishedAt:   module.FinishedAt,
+			Duration:     module.FinishedAt.Sub(module.StartedAt).Seconds(),
+		}
+
+		for _, dependency := range module.Module.Dependencies {
+			moduleReport.Dependencies = append(moduleReport.Dependencies, dependency.Path)
+		}
+
+		sort.Strings(moduleReport.Dependencies)
+
+		if module.Module.AssumeAlreadyApplied {
+			moduleReport.Status = reportStatusSkipped
+		}
+
+		if module.Err != nil {
+			moduleReport.Status = reportStatusFailed
+			moduleReport.Error = module.Err.Error()
+			moduleReport.ExitCode = 1
+
+			if exitCode, err := util.GetExitCode(module.Err); err == nil && exitCode != 0 {
+				moduleReport.ExitCode = exitCode
+			}
+		}
+
+		if output, ok := outputs[module.Module.Path]; ok {
+			moduleReport.Stdout = output.stdout.String()
+			moduleReport.Stderr = output.stderr.String()
+		}
+
+		report.Modules = append(report.Modules, moduleReport)
+	}
+
+	sort.Slice(report.Modules, func(i, j int) bool {
+		return report.Modules[i].Path < report.Modules[j].Path
+	})
+
+	return report
+}
+
+// WriteFile encodes the report in the given format and writes it to the given path.
+func (report *RunAllReport) WriteFile(path, format string) error {
+	var (
+		content []byte
+		err     error
+	)
+
+	switch format {
+	case RunAllReportFormatJSON, "":
+		content, err = json.MarshalIndent(report, "", "  ")
+	case RunAllReportFormatYAML:
+		content, err = yaml.Marshal(report)
+	default:
+		return errors.WithStackTrace(UnsupportedRunAllReportFormatError(format))
+	}
+
+	if err != nil {
+		return errors.WithStackTrace(err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return errors.WithStackTrace(err)
+	}
+
+	const ownerWriteGlobalReadPerms = 0644
+
+	if err := os.WriteFile(path, content, ownerWriteGlobalReadPerms); err != nil {
+		return errors.WithStackTrace(err)
+	}
+
+	return nil
+}
+
+// writeRunAllReport writes the report of the finished modules if --terragrunt-run-all-report-file is set.
+func writeRunAllReport(opts *options.TerragruntOptions, modules RunningModules, outputs map[string]*moduleOutput) error {
+	if opts.RunAllReportFile == "" {
+		return nil
+	}
+
+	path := opts.RunAllReportFile
+	if !filepath.IsAbs(path) {
+		path = util.JoinPath(opts.WorkingDir, path)
+	}
+
+	opts.Logger.Debugf("Writing run-all report to %s", path)
+
+	return newRunAllReport(opts.TerraformCommand, modules, outputs).WriteFile(path, opts.RunAllReportFormat)
+}
@@ -7,11 +7,14 @@ import (
 	"path/filepath"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/gruntwork-io/go-commons/errors"
 	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/shell"
 	"github.com/gruntwork-io/terragrunt/telemetry"
 	"github.com/gruntwork-io/terragrunt/terraform"
+	"github.com/gruntwork-io/terragrunt/util"
 	"github.com/hashicorp/go-multierror"
 )
 
@@ -45,6 +48,8 @@ type RunningModule struct {
 	Dependencies   map[string]*RunningModule
 	NotifyWhenDone []*RunningModule
 	FlagExcluded   bool
+	StartedAt      time.Time
+	FinishedAt     time.Time
 }
 
 // Create a new RunningModule struct for the given module. This will initialize all fields to reasonable defaults,
@@ -62,7 +67,7 @@ func newRunningModule(module *TerraformModule) *RunningModule {
 }
 
 // Run a module once all of its dependencies have finished executing.
-func (module *RunningModule) runModuleWhenReady(ctx context.Context, opts *options.TerragruntOptions, semaphore chan struct{}) {
+func (module *RunningModule) runModuleWhenReady(ctx context.Context, opts *options.TerragruntOptions, semaphore Semaphore, slots *parallelismSlots) {
 	err := telemetry.Telemetry(ctx, opts, "wait_for_module_ready", map[string]interface{}{
 		"path":             module.Module.Path,
 		"terraformCommand": module.Module.TerragruntOptions.TerraformCommand,
@@ -70,10 +75,25 @@ func (module *RunningModule) runModuleWhenReady(ctx context.Context, opts *optio
 		return module.waitForDependencies()
 	})
 
-	semaphore <- struct{}{} // Add one to the buffered channel. Will block if parallelism limit is met
-	defer func() {
-		<-semaphore // Remove one from the buffered channel
-	}()
+	// Will block if parallelism limit is met
+	if acquireErr := semaphore.Acquire(ctx, module.Module.Path); acquireErr != nil {
+		if err == nil {
+			err = acquireErr
+		}
+
+		module.moduleFinished(err)
+
+		return
+	}
+	defer semaphore.Release()
+
+	slot := slots.acquire()
+	defer slots.release(slot)
+
+	ctx = shell.ContextWithModulePath(ctx, module.Module.Path)
+	ctx = shell.ContextWithParallelismSlot(ctx, slot)
+
+	module.StartedAt = time.Now()
 
 	if err == nil {
 		err = telemetry.Telemetry(ctx, opts, "run_module", map[string]interface{}{
@@ -121,6 +141,12 @@ func (module *RunningModule) runNow(ctx context.Context, rootOptions *options.Te
 	} else {
 		module.Module.TerragruntOptions.Logger.Debugf("Running module %s now", module.Module.Path)
 
+		cleanupPlanFile, err := module.Module.decompressPlanFile(rootOptions)
+		if err != nil {
+			return err
+		}
+		defer cleanupPlanFile()
+
 		if err := module.Module.TerragruntOptions.RunTerragrunt(ctx, module.Module.TerragruntOptions); err != nil {
 			return err
 		}
@@ -156,6 +182,10 @@ func (module *RunningModule) runNow(ctx context.Context, rootOptions *options.Te
 			}
 		}
 
+		if module.Module.TerragruntOptions.TerraformCommand == terraform.CommandNamePlan {
+			return module.Module.compressPlanFile(rootOptions)
+		}
+
 		return nil
 	}
 }
@@ -170,6 +200,7 @@ func (module *RunningModule) moduleFinished(moduleErr error) {
 
 	module.Status = Finished
 	module.Err = moduleErr
+	module.FinishedAt = time.Now()
 
 	for _, toNotify := range module.NotifyWhenDone {
 		toNotify.DependencyDone <- module
@@ -304,15 +335,24 @@ func (modules RunningModules) RemoveFlagExcluded() map[string]*RunningModule {
 func (modules RunningModules) runModules(ctx context.Context, opts *options.TerragruntOptions, parallelism int) error {
 	var (
 		waitGroup sync.WaitGroup
-		semaphore = make(chan struct{}, parallelism) // Make a semaphore from a buffered channel
+		graph     = make(TerraformModules, 0, len(modules))
+		slots     = &parallelismSlots{used: make(map[int]bool)}
 	)
 
+	for _, module := range modules {
+		graph = append(graph, module.Module)
+	}
+
+	semaphore := DependencyAwareSemaphore(graph, parallelism)
+
+	ctx = shell.ContextWithRunID(ctx, util.UniqueID())
+
 	for _, module := range modules {
 		waitGroup.Add(1)
 
 		go func(module *RunningModule) {
 			defer waitGroup.Done()
-			module.runModuleWhenReady(ctx, opts, semaphore)
+			module.runModuleWhenReady(ctx, opts, semaphore, slots)
 		}(module)
 	}
 
@@ -321,6 +361,34 @@ func (modules RunningModules) runModules(ctx context.Context, opts *options.Terr
 	return modules.collectErrors()
 }
 
+// parallelismSlots assigns a number to each running module, so that the modules running at the same time can be told
+// apart in the logs. A module gets the lowest slot number that is not used by another running module.
+type parallelismSlots struct {
+	used map[int]bool
+	mu   sync.Mutex
+}
+
+func (slots *parallelismSlots) acquire() int {
+	slots.mu.Lock()
+	defer slots.mu.Unlock()
+
+	slot := 0
+	for slots.used[slot] {
+		slot++
+	}
+
+	slots.used[slot] = true
+
+	return slot
+}
+
+func (slots *parallelismSlots) release(slot int) {
+	slots.mu.Lock()
+	defer slots.mu.Unlock()
+
+	delete(slots.used, slot)
+}
+
 // Collect the errors from the given modules and return a single error object to represent them, or nil if no errors
 // occurred
 func (modules RunningModules) collectErrors() error {
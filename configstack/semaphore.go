@@ -0,0 +1,134 @@
+package configstack
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gruntwork-io/go-commons/errors"
+)
+
+// Semaphore limits the number of modules that run at the same time.
+type Semaphore interface {
+	// Acquire blocks until the module at the given path can run, or until ctx is done.
+	Acquire(ctx context.Context, modulePath string) error
+	// Release frees the slot taken by a successful Acquire.
+	Release()
+}
+
+// dependencyAwareSemaphore is a counting semaphore that, when several modules are waiting for a free slot, hands it to
+// the module with the most dependents first, so that the modules on the critical path of the graph start as early
+// as possible. Modules with the same number of dependents are served in the order they started waiting.
+type dependencyAwareSemaphore struct {
+	weights  map[string]int
+	waiters  []*semaphoreWaiter
+	capacity int
+	used     int
+	mu       sync.Mutex
+}
+
+type semaphoreWaiter struct {
+	ready  chan struct{}
+	weight int
+}
+
+// DependencyAwareSemaphore returns a semaphore with the given capacity that prioritizes modules of the graph by the
+// number of modules that directly or indirectly depend on them.
+func DependencyAwareSemaphore(graph TerraformModules, capacity int) Semaphore {
+	return &dependencyAwareSemaphore{
+		weights:  dependentsCount(graph),
+		capacity: capacity,
+	}
+}
+
+// Acquire implements Semaphore interface.
+func (sem *dependencyAwareSemaphore) Acquire(ctx context.Context, modulePath string) error {
+	sem.mu.Lock()
+
+	if sem.used < sem.capacity && len(sem.waiters) == 0 {
+		sem.used++
+		sem.mu.Unlock()
+
+		return nil
+	}
+
+	waiter := &semaphoreWaiter{
+		ready:  make(chan struct{}),
+		weight: sem.weights[modulePath],
+	}
+	sem.waiters = append(sem.waiters, waiter)
+	sem.mu.Unlock()
+
+	select {
+	case <-waiter.ready:
+		return nil
+	case <-ctx.Done():
+		sem.mu.Lock()
+
+		for i, w := range sem.waiters {
+			if w == waiter {
+				sem.waiters = append(sem.waiters[:i], sem.waiters[i+1:]...)
+				sem.mu.Unlock()
+
+				return errors.WithStackTrace(ctx.Err())
+			}
+		}
+
+		sem.mu.Unlock()
+
+		// The slot was handed over while the context was being cancelled, give it back.
+		sem.Release()
+
+		return errors.WithStackTrace(ctx.Err())
+	}
+}
+
+// Release implements Semaphore interface.
+func (sem *dependencyAwareSemaphore) Release() {
+	sem.mu.Lock()
+	defer sem.mu.Unlock()
+
+	if len(sem.waiters) == 0 {
+		sem.used--
+		return
+	}
+
+	next := 0
+
+	for i, waiter := range sem.waiters {
+		if waiter.weight > sem.waiters[next].weight {
+			next = i
+		}
+	}
+
+	waiter := sem.waiters[next]
+	sem.waiters = append(sem.waiters[:next], sem.waiters[next+1:]...)
+
+	// The slot passes straight to the waiter, so the number of used slots stays the same.
+	close(waiter.ready)
+}
+
+// dependentsCount returns the number of modules that directly or indirectly depend on each module of the graph.
+func dependentsCount(graph TerraformModules) map[string]int {
+	counts := map[string]int{}
+
+	for _, module := range graph {
+		visited := map[string]bool{}
+		queue := append(TerraformModules{}, module.Dependencies...)
+
+		for len(queue) > 0 {
+			dependency := queue[0]
+			queue = queue[1:]
+
+			if visited[dependency.Path] {
+				continue
+			}
+
+			visited[dependency.Path] = true
+			counts[dependency.Path]++
+
+			queue = append(queue, dependency.Dependencies...)
+		}
+	}
+
+	return counts
+}
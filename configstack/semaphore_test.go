@@ -0,0 +1,113 @@
+package configstack_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/configstack"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependencyAwareSemaphorePrioritizesModulesWithMoreDependents(t *testing.T) {
+	t.Parallel()
+
+	moduleA := &configstack.TerraformModule{Path: "a"}
+	moduleB := &configstack.TerraformModule{Path: "b", Dependencies: configstack.TerraformModules{moduleA}}
+	moduleC := &configstack.TerraformModule{Path: "c", Dependencies: configstack.TerraformModules{moduleA}}
+	moduleD := &configstack.TerraformModule{Path: "d", Dependencies: configstack.TerraformModules{moduleA}}
+
+	semaphore := configstack.DependencyAwareSemaphore(configstack.TerraformModules{moduleA, moduleB, moduleC, moduleD}, 1)
+
+	// Hold the only slot so that all the other modules have to wait for it.
+	require.NoError(t, semaphore.Acquire(context.Background(), "blocker"))
+
+	var (
+		order   []string
+		orderMu sync.Mutex
+		wg      sync.WaitGroup
+	)
+
+	// Module "a" starts waiting last, but it is the one all the others depend on.
+	for _, path := range []string{"b", "c", "d", "a"} {
+		wg.Add(1)
+
+		go func(path string) {
+			defer wg.Done()
+
+			assert.NoError(t, semaphore.Acquire(context.Background(), path))
+
+			orderMu.Lock()
+			order = append(order, path)
+			orderMu.Unlock()
+
+			semaphore.Release()
+		}(path)
+
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	semaphore.Release()
+	wg.Wait()
+
+	assert.Equal(t, []string{"a", "b", "c", "d"}, order)
+}
+
+func TestDependencyAwareSemaphoreAcquireCancelled(t *testing.T) {
+	t.Parallel()
+
+	semaphore := configstack.DependencyAwareSemaphore(configstack.TerraformModules{}, 1)
+	require.NoError(t, semaphore.Acquire(context.Background(), "a"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, semaphore.Acquire(ctx, "b"), context.DeadlineExceeded)
+
+	semaphore.Release()
+	require.NoError(t, semaphore.Acquire(context.Background(), "c"))
+}
+
+func TestRunModulesDependencyAwareOrder(t *testing.T) {
+	t.Parallel()
+
+	var (
+		order   []string
+		orderMu sync.Mutex
+	)
+
+	newModule := func(path string, dependencies ...*configstack.TerraformModule) *configstack.TerraformModule {
+		opts, err := options.NewTerragruntOptionsForTest(path)
+		require.NoError(t, err)
+
+		opts.RunTerragrunt = func(_ context.Context, _ *options.TerragruntOptions) error {
+			orderMu.Lock()
+			defer orderMu.Unlock()
+
+			order = append(order, path)
+
+			return nil
+		}
+
+		return &configstack.TerraformModule{Path: path, Dependencies: dependencies, TerragruntOptions: opts}
+	}
+
+	moduleA := newModule("a")
+	modules := configstack.TerraformModules{
+		newModule("b", moduleA),
+		newModule("c", moduleA),
+		newModule("d", moduleA),
+		moduleA,
+	}
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	require.NoError(t, modules.RunModules(context.Background(), opts, 1))
+
+	require.Len(t, order, 4)
+	assert.Equal(t, "a", order[0])
+}
@@ -206,14 +206,66 @@ func (stack *Stack) Run(ctx context.Context, terragruntOptions *options.Terragru
 		defer stack.summarizePlanAllErrors(terragruntOptions, errorStreams)
 	}
 
+	if terragruntOptions.RunAllReportFile == "" {
+		switch {
+		case terragruntOptions.IgnoreDependencyOrder:
+			return stack.Modules.RunModulesIgnoreOrder(ctx, terragruntOptions, terragruntOptions.Parallelism)
+		case stackCmd == terraform.CommandNameDestroy:
+			return stack.Modules.RunModulesReverseOrder(ctx, terragruntOptions, terragruntOptions.Parallelism)
+		default:
+			return stack.Modules.RunModules(ctx, terragruntOptions, terragruntOptions.Parallelism)
+		}
+	}
+
+	return stack.runWithReport(ctx, terragruntOptions)
+}
+
+// runWithReport runs the modules the same way as Run does, additionally capturing the output of every module to write
+// the report set by --terragrunt-run-all-report-file once all of them have finished.
+func (stack *Stack) runWithReport(ctx context.Context, terragruntOptions *options.TerragruntOptions) error {
+	if format := terragruntOptions.RunAllReportFormat; format != "" && !util.ListContainsElement(runAllReportFormats, format) {
+		return errors.WithStackTrace(UnsupportedRunAllReportFormatError(format))
+	}
+
+	outputs := make(map[string]*moduleOutput, len(stack.Modules))
+
+	for _, module := range stack.Modules {
+		output := &moduleOutput{
+			stdout: util.NewTailWriter(module.TerragruntOptions.Writer, reportOutputExcerptSize),
+			stderr: util.NewTailWriter(module.TerragruntOptions.ErrWriter, reportOutputExcerptSize),
+		}
+		outputs[module.Path] = output
+
+		module.TerragruntOptions.Writer = output.stdout
+		module.TerragruntOptions.ErrWriter = output.stderr
+	}
+
+	dependencyOrder := NormalOrder
+
 	switch {
 	case terragruntOptions.IgnoreDependencyOrder:
-		return stack.Modules.RunModulesIgnoreOrder(ctx, terragruntOptions, terragruntOptions.Parallelism)
-	case stackCmd == terraform.CommandNameDestroy:
-		return stack.Modules.RunModulesReverseOrder(ctx, terragruntOptions, terragruntOptions.Parallelism)
-	default:
-		return stack.Modules.RunModules(ctx, terragruntOptions, terragruntOptions.Parallelism)
+		dependencyOrder = IgnoreOrder
+	case terragruntOptions.TerraformCommand == terraform.CommandNameDestroy:
+		dependencyOrder = ReverseOrder
 	}
+
+	runningModules, err := stack.Modules.ToRunningModules(dependencyOrder)
+	if err != nil {
+		return err
+	}
+
+	runErr := runningModules.runModules(ctx, terragruntOptions, terragruntOptions.Parallelism)
+
+	if err := writeRunAllReport(terragruntOptions, runningModules, outputs); err != nil {
+		if runErr != nil {
+			terragruntOptions.Logger.Errorf("Failed to write run-all report: %v", err)
+			return runErr
+		}
+
+		return err
+	}
+
+	return runErr
 }
 
 // We inspect the error streams to give an explicit message if the plan failed because there were references to
@@ -257,6 +309,11 @@ func (stack *Stack) syncTerraformCliArgs(terragruntOptions *options.TerragruntOp
 				// for plan command add -out=<file> to the terraform cli args
 				module.TerragruntOptions.TerraformCliArgs = util.StringListInsert(module.TerragruntOptions.TerraformCliArgs, "-out="+planFile, len(module.TerragruntOptions.TerraformCliArgs))
 			} else {
+				// the plan file is only compressed if the compression succeeded when it was written
+				if terragruntOptions.GzipPlanOutput && planFile == module.outputFile(terragruntOptions) && util.FileExists(planFile+gzipPlanFileExt) {
+					planFile += gzipPlanFileExt
+				}
+
 				module.TerragruntOptions.TerraformCliArgs = util.StringListInsert(module.TerragruntOptions.TerraformCliArgs, planFile, len(module.TerragruntOptions.TerraformCliArgs))
 			}
 		}
@@ -409,12 +466,25 @@ func (stack *Stack) ResolveTerraformModules(ctx context.Context, terragruntConfi
 		return nil, err
 	}
 
+	var prunedModules TerraformModules
+
+	err = telemetry.Telemetry(ctx, stack.terragruntOptions, "prune_disconnected_modules", map[string]interface{}{
+		"working_dir": stack.terragruntOptions.WorkingDir,
+	}, func(childCtx context.Context) error {
+		prunedModules = includedModules.pruneDisconnectedModules(stack.terragruntOptions)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
 	var includedModulesWithExcluded TerraformModules
 
 	err = telemetry.Telemetry(ctx, stack.terragruntOptions, "flag_excluded_dirs", map[string]interface{}{
 		"working_dir": stack.terragruntOptions.WorkingDir,
 	}, func(childCtx context.Context) error {
-		includedModulesWithExcluded = includedModules.flagExcludedDirs(stack.terragruntOptions)
+		includedModulesWithExcluded = prunedModules.flagExcludedDirs(stack.terragruntOptions)
 		return nil
 	})
 
@@ -440,6 +510,22 @@ func (stack *Stack) ResolveTerraformModules(ctx context.Context, terragruntConfi
 		return nil, err
 	}
 
+	err = telemetry.Telemetry(ctx, stack.terragruntOptions, "flag_modules_not_included_by_prefix", map[string]interface{}{
+		"working_dir": stack.terragruntOptions.WorkingDir,
+	}, func(childCtx context.Context) error {
+		result, err := finalModules.flagModulesNotIncludedByPrefix(stack.terragruntOptions)
+		if err != nil {
+			return err
+		}
+
+		finalModules = result
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	return finalModules, nil
 }
 
@@ -540,7 +626,7 @@ func (stack *Stack) resolveTerraformModule(ctx context.Context, terragruntConfig
 		opts.TerragruntConfigPath = stack.terragruntOptions.OriginalTerragruntConfigPath
 	}
 
-	if collections.ListContainsElement(opts.ExcludeDirs, modulePath) {
+	if util.MatchesAnyPath(opts.ExcludeDirs, modulePath) {
 		// module is excluded
 		return &TerraformModule{Path: modulePath, TerragruntOptions: opts, FlagExcluded: true}, nil
 	}
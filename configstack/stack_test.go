@@ -2,6 +2,7 @@ package configstack_test
 
 import (
 	"context"
+	"encoding/json"
 	goErrors "errors"
 	"os"
 	"path/filepath"
@@ -16,7 +17,9 @@ import (
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/terraform"
 	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestFindStackInSubfolders(t *testing.T) {
@@ -308,6 +311,7 @@ func TestResolveTerraformModulesReadConfigFromParentConfig(t *testing.T) {
 		localsConfigs[name] = map[string]interface{}{
 			"dependencies":                  interface{}(nil),
 			"download_dir":                  "",
+			"engine_meta":                   interface{}(nil),
 			"generate":                      map[string]interface{}{},
 			"iam_assume_role_duration":      interface{}(nil),
 			"iam_assume_role_session_name":  "",
@@ -318,6 +322,7 @@ func TestResolveTerraformModulesReadConfigFromParentConfig(t *testing.T) {
 			"retry_max_attempts":            interface{}(nil),
 			"retry_sleep_interval_sec":      interface{}(nil),
 			"retryable_errors":              interface{}(nil),
+			"set_env":                       interface{}(nil),
 			"skip":                          false,
 			"terraform_binary":              "",
 			"terraform_version_constraint":  "",
@@ -773,6 +778,54 @@ func TestResolveTerraformModulesTwoModulesWithDependenciesIncludedDirsWithNoDepe
 	assertModuleListsEqual(t, expected, actualModules)
 }
 
+func TestResolveTerraformModulesIncludedDirsPrunesDisconnectedModules(t *testing.T) {
+	t.Parallel()
+
+	opts, _ := options.NewTerragruntOptionsForTest("running_module_test")
+	opts.IncludeDirs = []string{canonical(t, "../test/fixtures/modules/module-a")}
+	opts.ExcludeByDefault = true
+
+	moduleA := &configstack.TerraformModule{
+		Path:         canonical(t, "../test/fixtures/modules/module-a"),
+		Dependencies: configstack.TerraformModules{},
+		Config: config.TerragruntConfig{
+			Terraform:       &config.TerraformConfig{Source: ptr("test")},
+			IsPartial:       true,
+			GenerateConfigs: make(map[string]codegen.GenerateConfig),
+		},
+		TerragruntOptions: cloneOptions(t, mockOptions, "../test/fixtures/modules/module-a/"+config.DefaultTerragruntConfigPath),
+	}
+
+	moduleC := &configstack.TerraformModule{
+		Path:         canonical(t, "../test/fixtures/modules/module-c"),
+		Dependencies: configstack.TerraformModules{moduleA},
+		Config: config.TerragruntConfig{
+			Dependencies:    &config.ModuleDependencies{Paths: []string{"../module-a"}},
+			Terraform:       &config.TerraformConfig{Source: ptr("temp")},
+			IsPartial:       true,
+			GenerateConfigs: make(map[string]codegen.GenerateConfig),
+		},
+		TerragruntOptions: cloneOptions(t, mockOptions, "../test/fixtures/modules/module-c/"+config.DefaultTerragruntConfigPath),
+	}
+
+	configPaths := []string{"../test/fixtures/modules/module-a/" + config.DefaultTerragruntConfigPath, "../test/fixtures/modules/module-c/" + config.DefaultTerragruntConfigPath, "../test/fixtures/modules/module-f/" + config.DefaultTerragruntConfigPath}
+
+	stack := configstack.NewStack(opts)
+	actualModules, actualErr := stack.ResolveTerraformModules(context.Background(), configPaths)
+	require.NoError(t, actualErr, "Unexpected error: %v", actualErr)
+
+	// module-f has no dependency path to module-a, so it must be pruned from the graph
+	moduleC.FlagExcluded = true
+	expected := configstack.TerraformModules{moduleA, moduleC}
+	assertModuleListsEqual(t, expected, actualModules)
+
+	runningModules, err := actualModules.ToRunningModules(configstack.NormalOrder)
+	require.NoError(t, err)
+	require.Len(t, runningModules, 1)
+	require.Contains(t, runningModules, moduleA.Path)
+	require.NotContains(t, runningModules, canonical(t, "../test/fixtures/modules/module-f"))
+}
+
 func TestResolveTerraformModulesTwoModulesWithDependenciesIncludedDirsWithDependencyExcludeModuleWithNoDependency(t *testing.T) {
 	t.Parallel()
 
@@ -1194,3 +1247,122 @@ func TestCircularDependencies(t *testing.T) {
 		t.Errorf("Expected %v, got %v", expected, result)
 	}
 }
+
+func TestStackRunWritesRunAllReport(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		format    string
+		unmarshal func([]byte, any) error
+	}{
+		{configstack.RunAllReportFormatJSON, json.Unmarshal},
+		{configstack.RunAllReportFormatYAML, yaml.Unmarshal},
+	}
+
+	for _, tt := range tc {
+		tt := tt
+
+		t.Run(tt.format, func(t *testing.T) {
+			t.Parallel()
+
+			newModule := func(path string, runErr error, dependencies ...*configstack.TerraformModule) *configstack.TerraformModule {
+				opts, err := options.NewTerragruntOptionsForTest(path)
+				require.NoError(t, err)
+
+				opts.TerraformCommand = terraform.CommandNameOutput
+				opts.RunTerragrunt = func(_ context.Context, opts *options.TerragruntOptions) error {
+					_, _ = opts.Writer.Write([]byte("stdout of " + path))
+					_, _ = opts.ErrWriter.Write([]byte("stderr of " + path))
+
+					return runErr
+				}
+
+				return &configstack.TerraformModule{
+					Path:              path,
+					Dependencies:      dependencies,
+					TerragruntOptions: opts,
+				}
+			}
+
+			moduleA := newModule("a", nil)
+			moduleB := newModule("b", goErrors.New("module b failed"), moduleA)
+
+			opts, err := options.NewTerragruntOptionsForTest("")
+			require.NoError(t, err)
+
+			opts.TerraformCommand = terraform.CommandNameOutput
+			opts.RunAllReportFile = filepath.Join(t.TempDir(), "report."+tt.format)
+			opts.RunAllReportFormat = tt.format
+
+			stack := configstack.NewStack(opts)
+			stack.Modules = configstack.TerraformModules{moduleA, moduleB}
+
+			err = stack.Run(context.Background(), opts)
+			require.Error(t, err)
+
+			content, err := os.ReadFile(opts.RunAllReportFile)
+			require.NoError(t, err)
+
+			var report configstack.RunAllReport
+			require.NoError(t, tt.unmarshal(content, &report))
+
+			assert.Equal(t, terraform.CommandNameOutput, report.Command)
+			require.Len(t, report.Modules, 2)
+
+			reportA, reportB := report.Modules[0], report.Modules[1]
+
+			assert.Equal(t, "a", reportA.Path)
+			assert.Empty(t, reportA.Dependencies)
+			assert.Equal(t, "succeeded", reportA.Status)
+			assert.Equal(t, 0, reportA.ExitCode)
+			assert.Equal(t, "stdout of a", reportA.Stdout)
+			assert.Equal(t, "stderr of a", reportA.Stderr)
+			assert.False(t, reportA.StartedAt.IsZero())
+			assert.False(t, reportA.FinishedAt.Before(reportA.StartedAt))
+
+			assert.Equal(t, "b", reportB.Path)
+			assert.Equal(t, []string{"a"}, reportB.Dependencies)
+			assert.Equal(t, "failed", reportB.Status)
+			assert.Equal(t, 1, reportB.ExitCode)
+			assert.Contains(t, reportB.Error, "module b failed")
+			assert.Equal(t, "stdout of b", reportB.Stdout)
+			assert.Equal(t, "stderr of b", reportB.Stderr)
+		})
+	}
+}
+
+func TestResolveTerraformModulesModulesIncludeByPrefix(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+
+	modulePaths := []string{"services/api", "services/web", "infra/vpc"}
+	configPaths := make([]string, 0, len(modulePaths))
+
+	for _, modulePath := range modulePaths {
+		moduleDir := filepath.Join(workingDir, modulePath)
+		require.NoError(t, os.MkdirAll(moduleDir, os.ModePerm))
+
+		configPath := filepath.Join(moduleDir, config.DefaultTerragruntConfigPath)
+		require.NoError(t, os.WriteFile(configPath, []byte(`terraform { source = "test" }`), 0644))
+
+		configPaths = append(configPaths, configPath)
+	}
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, config.DefaultTerragruntConfigPath))
+	require.NoError(t, err)
+
+	opts.WorkingDir = workingDir
+	opts.ModulesIncludeByPrefix = []string{"services/"}
+
+	stack := configstack.NewStack(opts)
+	modules, err := stack.ResolveTerraformModules(context.Background(), configPaths)
+	require.NoError(t, err)
+
+	runningModules, err := modules.ToRunningModules(configstack.NormalOrder)
+	require.NoError(t, err)
+	require.Len(t, runningModules, 2)
+	assert.Contains(t, runningModules, canonical(t, filepath.Join(workingDir, "services/api")))
+	assert.Contains(t, runningModules, canonical(t, filepath.Join(workingDir, "services/web")))
+	assert.NotContains(t, runningModules, canonical(t, filepath.Join(workingDir, "infra/vpc")))
+}
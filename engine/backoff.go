@@ -0,0 +1,96 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"github.com/gruntwork-io/go-commons/errors"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// BackoffPolicy defines how many times an action is attempted and how long to wait between the attempts.
+type BackoffPolicy interface {
+	// Delay returns the time to wait after the given failed attempt, attempts are numbered starting from 1.
+	Delay(attempt int) time.Duration
+	// MaxAttempts returns the maximum number of attempts, including the first one.
+	MaxAttempts() int
+}
+
+// ExponentialBackoff doubles the delay after each failed attempt, starting from `Base` and never exceeding `Max`.
+type ExponentialBackoff struct {
+	Base     time.Duration
+	Max      time.Duration
+	Attempts int
+}
+
+// Delay implements BackoffPolicy.Delay
+func (backoff ExponentialBackoff) Delay(attempt int) time.Duration {
+	delay := backoff.Base
+
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+
+		if backoff.Max > 0 && delay >= backoff.Max {
+			return backoff.Max
+		}
+	}
+
+	if backoff.Max > 0 && delay > backoff.Max {
+		return backoff.Max
+	}
+
+	return delay
+}
+
+// MaxAttempts implements BackoffPolicy.MaxAttempts
+func (backoff ExponentialBackoff) MaxAttempts() int {
+	return backoff.Attempts
+}
+
+// ConstantBackoff waits the same `Interval` after each failed attempt.
+type ConstantBackoff struct {
+	Interval time.Duration
+	Attempts int
+}
+
+// Delay implements BackoffPolicy.Delay
+func (backoff ConstantBackoff) Delay(_ int) time.Duration {
+	return backoff.Interval
+}
+
+// MaxAttempts implements BackoffPolicy.MaxAttempts
+func (backoff ConstantBackoff) MaxAttempts() int {
+	return backoff.Attempts
+}
+
+// DoWithBackoff runs the given action until it succeeds or the attempts allowed by the policy are exhausted,
+// in which case the last error is returned. If the policy is nil, the action is run only once.
+func DoWithBackoff(ctx context.Context, policy BackoffPolicy, logger log.Logger, actionDescription string, action func() error) error {
+	maxAttempts := 1
+	if policy != nil && policy.MaxAttempts() > 1 {
+		maxAttempts = policy.MaxAttempts()
+	}
+
+	var err error
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if err = action(); err == nil {
+			return nil
+		}
+
+		if attempt == maxAttempts {
+			break
+		}
+
+		delay := policy.Delay(attempt)
+		logger.Warnf("%s failed: %v. Attempt %d of %d. Sleeping for %s and will try again.", actionDescription, err, attempt, maxAttempts, delay)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return errors.WithStackTrace(ctx.Err())
+		}
+	}
+
+	return err
+}
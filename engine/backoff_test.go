@@ -0,0 +1,93 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoWithBackoff(t *testing.T) {
+	t.Parallel()
+
+	errStartup := errors.New("fork/exec terragrunt-iac-engine-opentofu: permission denied")
+
+	tc := []struct {
+		policy           engine.BackoffPolicy
+		failures         int
+		expectedAttempts int
+		expectedErr      error
+	}{
+		{
+			policy:           engine.ConstantBackoff{Interval: time.Millisecond, Attempts: 3},
+			failures:         2,
+			expectedAttempts: 3,
+		},
+		{
+			policy:           engine.ConstantBackoff{Interval: time.Millisecond, Attempts: 2},
+			failures:         2,
+			expectedAttempts: 2,
+			expectedErr:      errStartup,
+		},
+		{
+			policy:           nil,
+			failures:         1,
+			expectedAttempts: 1,
+			expectedErr:      errStartup,
+		},
+		{
+			policy:           engine.ExponentialBackoff{Base: time.Millisecond, Max: 2 * time.Millisecond, Attempts: 5},
+			failures:         4,
+			expectedAttempts: 5,
+		},
+	}
+
+	for i, tt := range tc {
+		tt := tt
+
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			logger := log.New(log.WithOutput(io.Discard))
+
+			attempts := 0
+			startEngine := func() error {
+				attempts++
+				if attempts <= tt.failures {
+					return errStartup
+				}
+
+				return nil
+			}
+
+			err := engine.DoWithBackoff(context.Background(), tt.policy, logger, "Starting engine", startEngine)
+			assert.Equal(t, tt.expectedAttempts, attempts)
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestExponentialBackoffDelay(t *testing.T) {
+	t.Parallel()
+
+	backoff := engine.ExponentialBackoff{Base: time.Second, Max: 5 * time.Second, Attempts: 5}
+
+	assert.Equal(t, 5, backoff.MaxAttempts())
+	assert.Equal(t, time.Second, backoff.Delay(1))
+	assert.Equal(t, 2*time.Second, backoff.Delay(2))
+	assert.Equal(t, 4*time.Second, backoff.Delay(3))
+	assert.Equal(t, 5*time.Second, backoff.Delay(4))
+}
@@ -0,0 +1,80 @@
+package engine
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+
+	"github.com/gruntwork-io/go-commons/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// engineBlobsDir is the directory, in the engine cache, of the content-addressable store of the engine binaries, each
+// stored once as `blobs/sha256/<hash>` whatever the number of versions that resolve to it.
+const engineBlobsDir = "blobs/sha256"
+
+// engineBlobPath returns the path of the blob with the given SHA-256 hash.
+func engineBlobPath(opts *options.TerragruntOptions, checksum []byte) (string, error) {
+	cacheDir, err := engineCacheDir(opts)
+	if err != nil {
+		return "", err
+	}
+
+	// the symlinks to the blob must not depend on the directory they are in
+	blobPath, err := filepath.Abs(filepath.Join(cacheDir, EngineCacheDir, engineBlobsDir, hex.EncodeToString(checksum)))
+	if err != nil {
+		return "", errors.WithStackTrace(err)
+	}
+
+	return blobPath, nil
+}
+
+// storeEngineBlob moves the engine binary to the content-addressable store and replaces it with a symlink to the
+// blob. If the blob already exists, the binary is a duplicate and is removed. If symlinks are not supported, e.g. on
+// Windows without developer mode, the binary is left as is. The SHA-256 hash of the binary is returned, or nil if the
+// binary was not hashed.
+func storeEngineBlob(opts *options.TerragruntOptions, engineFile string) ([]byte, error) {
+	info, err := os.Lstat(engineFile)
+	if err != nil || !info.Mode().IsRegular() {
+		// the binary is missing, e.g. the archive contains several files, or it is already a symlink
+		return nil, nil
+	}
+
+	checksum, err := util.FileSHA256(engineFile)
+	if err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	blobPath, err := engineBlobPath(opts, checksum)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := util.EnsureDirectory(filepath.Dir(blobPath)); err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	tempLink := engineFile + ".link"
+
+	if err := os.Symlink(blobPath, tempLink); err != nil {
+		opts.Logger.Debugf("Not storing %s in the engine blobs, symlinks are not supported: %v", engineFile, err)
+		return checksum, nil
+	}
+
+	if util.FileExists(blobPath) {
+		opts.Logger.Debugf("Engine %s is the same binary as %s", engineFile, blobPath)
+
+		if err := os.Remove(engineFile); err != nil {
+			return nil, errors.WithStackTrace(err)
+		}
+	} else if err := os.Rename(engineFile, blobPath); err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	if err := os.Rename(tempLink, engineFile); err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	return checksum, nil
+}
@@ -0,0 +1,69 @@
+package engine
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreEngineBlob(t *testing.T) {
+	cacheDir := t.TempDir()
+	t.Setenv(EngineCachePathEnv, cacheDir)
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	// the same binary downloaded under two version strings
+	var engineFiles []string
+
+	for _, version := range []string{"v0.0.1", "0.0.1"} {
+		engineFile := filepath.Join(cacheDir, EngineCacheDir, "rpc", version, "linux", "amd64", "engine")
+		require.NoError(t, os.MkdirAll(filepath.Dir(engineFile), os.ModePerm))
+		require.NoError(t, os.WriteFile(engineFile, []byte("engine"), 0755))
+
+		checksum, err := storeEngineBlob(opts, engineFile)
+		require.NoError(t, err)
+		assert.Equal(t, "ed9f6f25068608efd412958da4dfc19328ca3511251fa6d5f9c42baf230e32f8", hex.EncodeToString(checksum))
+
+		engineFiles = append(engineFiles, engineFile)
+	}
+
+	info, err := os.Lstat(engineFiles[0])
+	require.NoError(t, err)
+
+	if info.Mode()&os.ModeSymlink == 0 {
+		t.Skip("Symlinks are not supported")
+	}
+
+	blobs, err := os.ReadDir(filepath.Join(cacheDir, EngineCacheDir, engineBlobsDir))
+	require.NoError(t, err)
+	require.Len(t, blobs, 1)
+
+	// SHA-256 hash of "engine"
+	const checksum = "ed9f6f25068608efd412958da4dfc19328ca3511251fa6d5f9c42baf230e32f8"
+
+	assert.Equal(t, checksum, blobs[0].Name())
+
+	for _, engineFile := range engineFiles {
+		content, err := os.ReadFile(engineFile)
+		require.NoError(t, err)
+		assert.Equal(t, "engine", string(content))
+
+		actual, err := util.FileSHA256(engineFile)
+		require.NoError(t, err)
+		assert.Equal(t, checksum, hex.EncodeToString(actual))
+	}
+
+	// a tampered blob no longer matches its name, the hash is computed from the content
+	require.NoError(t, os.WriteFile(filepath.Join(cacheDir, EngineCacheDir, engineBlobsDir, checksum), []byte("tampered"), 0755))
+
+	actual, err := util.FileSHA256(engineFiles[0])
+	require.NoError(t, err)
+	assert.NotEqual(t, checksum, hex.EncodeToString(actual))
+}
@@ -0,0 +1,66 @@
+package engine
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/pkg/cli"
+	"github.com/hashicorp/go-version"
+)
+
+const (
+	commandNameRefresh  = "refresh"
+	flagNameRefreshOnly = "-refresh-only"
+	flagNameAutoApprove = "-auto-approve"
+
+	// refreshDeprecatedVersion is the first OpenTofu version, every OpenTofu release treats `refresh` as a deprecated alias.
+	refreshDeprecatedVersion = "1.6.0"
+)
+
+// CommandRewriter translates the IaC command and its arguments before they are passed to the engine.
+type CommandRewriter func(cmd string, args []string) (string, []string)
+
+// OpenTofuCommandRewriter returns a `CommandRewriter` that canonicalizes arguments for the given OpenTofu version:
+// double dash flags are converted to single dash flags, for example `--refresh=false` becomes `-refresh=false`,
+// and the deprecated `refresh` command is translated to `apply -refresh-only -auto-approve`.
+func OpenTofuCommandRewriter(tofuVersion string) CommandRewriter {
+	ver, err := version.NewVersion(tofuVersion)
+	refreshDeprecated := err == nil && ver.GreaterThanOrEqual(version.Must(version.NewVersion(refreshDeprecatedVersion)))
+
+	return func(cmd string, args []string) (string, []string) {
+		newArgs := cli.Args(args).Normalize(cli.SingleDashFlag).Slice()
+
+		if !refreshDeprecated {
+			return cmd, newArgs
+		}
+
+		// the command name follows the global flags, such as `-chdir=DIR`
+		for i, arg := range newArgs {
+			if strings.HasPrefix(arg, "-") {
+				continue
+			}
+
+			if arg == commandNameRefresh {
+				rewrittenArgs := append(slices.Clone(newArgs[:i]), "apply", flagNameRefreshOnly, flagNameAutoApprove)
+				newArgs = append(rewrittenArgs, newArgs[i+1:]...)
+			}
+
+			break
+		}
+
+		return cmd, newArgs
+	}
+}
+
+// rewriteCommand returns a copy of the given execution options with `CommandRewriter` applied to the command and
+// arguments, or the execution options as is if it is not set. The execution options of the caller are not modified.
+func rewriteCommand(runOptions *ExecutionOptions) *ExecutionOptions {
+	if runOptions.CommandRewriter == nil {
+		return runOptions
+	}
+
+	rewrittenOptions := *runOptions
+	rewrittenOptions.Command, rewrittenOptions.Args = runOptions.CommandRewriter(runOptions.Command, runOptions.Args)
+
+	return &rewrittenOptions
+}
@@ -0,0 +1,83 @@
+package engine
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	// downloadProgressInterval is the minimum time between two progress lines of a download.
+	downloadProgressInterval = time.Second
+
+	bytesPerMB = 1024 * 1024
+)
+
+// downloadProgressTracker is the `getter.ProgressTracker` that logs the number of downloaded bytes at most once per
+// second, e.g. `Downloading engine.zip: 12.5 MB / 40.0 MB`, so that large downloads over slow connections give some
+// feedback. The progress lines go through the logger, so the downloads of parallel units are not interleaved and are
+// prefixed by their unit.
+type downloadProgressTracker struct {
+	logger log.Logger
+}
+
+// TrackProgress implements `getter.ProgressTracker` interface.
+func (tracker *downloadProgressTracker) TrackProgress(src string, currentSize, totalSize int64, stream io.ReadCloser) io.ReadCloser {
+	return &downloadProgressReader{
+		ReadCloser: stream,
+		logger:     tracker.logger,
+		src:        src,
+		downloaded: currentSize,
+		total:      totalSize,
+		reportedAt: time.Now(),
+	}
+}
+
+// downloadProgressReader counts the bytes read from the stream and reports them at most once per
+// `downloadProgressInterval`, and when the stream is closed.
+type downloadProgressReader struct {
+	io.ReadCloser
+
+	logger     log.Logger
+	src        string
+	downloaded int64
+	total      int64
+	reportedAt time.Time
+	closeOnce  sync.Once
+}
+
+// Read implements `io.Reader` interface.
+func (reader *downloadProgressReader) Read(p []byte) (int, error) {
+	n, err := reader.ReadCloser.Read(p)
+	reader.downloaded += int64(n)
+
+	if time.Since(reader.reportedAt) >= downloadProgressInterval {
+		reader.report()
+	}
+
+	return n, err
+}
+
+// Close implements `io.Closer` interface.
+func (reader *downloadProgressReader) Close() error {
+	// the last line always shows the whole size
+	reader.closeOnce.Do(reader.report)
+
+	return reader.ReadCloser.Close()
+}
+
+func (reader *downloadProgressReader) report() {
+	reader.reportedAt = time.Now()
+
+	downloaded := float64(reader.downloaded) / bytesPerMB
+
+	// the size is unknown if the server doesn't send the Content-Length header
+	if reader.total <= 0 {
+		reader.logger.Infof("Downloading %s: %.1f MB", reader.src, downloaded)
+		return
+	}
+
+	reader.logger.Infof("Downloading %s: %.1f MB / %.1f MB", reader.src, downloaded, float64(reader.total)/bytesPerMB)
+}
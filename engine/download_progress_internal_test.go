@@ -0,0 +1,50 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/hashicorp/go-getter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloadProgressTracker(t *testing.T) {
+	t.Parallel()
+
+	content := bytes.Repeat([]byte("e"), 3*1024*1024)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		w.Write(content) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	destPath := filepath.Join(t.TempDir(), "engine.zip")
+
+	var logs bytes.Buffer
+
+	client := &getter.Client{
+		Ctx:              context.Background(),
+		Src:              server.URL + "/engine.zip",
+		Dst:              destPath,
+		Mode:             getter.ClientModeFile,
+		Decompressors:    map[string]getter.Decompressor{},
+		ProgressListener: &downloadProgressTracker{logger: log.New(log.WithOutput(&logs), log.WithLevel(log.InfoLevel))},
+	}
+	require.NoError(t, client.Get())
+
+	downloaded, err := os.ReadFile(destPath)
+	require.NoError(t, err)
+	assert.Equal(t, content, downloaded)
+
+	// the progress is logged, the last line shows the whole size
+	assert.Contains(t, logs.String(), "3.0 MB / 3.0 MB")
+}
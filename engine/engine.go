@@ -12,10 +12,10 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
-	"runtime"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gruntwork-io/terragrunt/internal/cache"
 
@@ -24,15 +24,13 @@ import (
 
 	"github.com/hashicorp/go-hclog"
 
-	"google.golang.org/grpc/credentials/insecure"
-
 	"github.com/gruntwork-io/go-commons/errors"
-	"github.com/gruntwork-io/terragrunt-engine-go/engine"
 	"github.com/gruntwork-io/terragrunt-engine-go/proto"
 	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/telemetry"
+	"github.com/gruntwork-io/terragrunt/terraform"
 	"github.com/gruntwork-io/terragrunt/util"
 	"github.com/hashicorp/go-plugin"
-	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/structpb"
 )
@@ -41,7 +39,7 @@ const (
 	engineVersion                                    = 1
 	engineCookieKey                                  = "engine"
 	engineCookieValue                                = "terragrunt"
-	EnableExperimentalEngineEnvName                  = "TG_EXPERIMENTAL_ENGINE"
+	EnableExperimentalEngineEnvName                  = options.EnableExperimentalEngineEnvName
 	DefaultCacheDir                                  = ".cache"
 	EngineCacheDir                                   = "terragrunt/plugins/iac-engine"
 	PrefixTrim                                       = "terragrunt-"
@@ -57,6 +55,14 @@ const (
 
 type engineClientsKey byte
 type engineLocksKey byte
+type engineEnabledKey byte
+
+// engineEnabledContextKey holds the value set by `WithEngineEnabled`.
+const engineEnabledContextKey engineEnabledKey = 0
+
+// engineShutdownTimeout is how long the engine plugin that exceeded the run timeout may take to shut down, it is a var
+// so that the tests can shorten it.
+var engineShutdownTimeout = 30 * time.Second
 
 type ExecutionOptions struct {
 	TerragruntOptions *options.TerragruntOptions
@@ -67,19 +73,120 @@ type ExecutionOptions struct {
 	AllocatePseudoTty bool
 	Command           string
 	Args              []string
+	// CommandRewriter, if set, is called to translate the command and arguments before they are passed to the engine.
+	CommandRewriter CommandRewriter
+	// BackoffPolicy, if set, is used to retry starting the engine plugin when it fails to start.
+	BackoffPolicy BackoffPolicy
+	// StateEncryption, if set, encrypts the state written to stdout by `state pull` and decrypts the state file passed
+	// to `state push`, so that the state never leaves the working directory in plaintext. If `state pull` fails, its
+	// stdout is dropped instead. Only these two commands are covered, the state printed by other commands, such as
+	// `show -json` or `output`, is not encrypted.
+	StateEncryption StateEncryption
+	// PluginGRPCOptions, if set, configures the gRPC connection to the engine plugin.
+	PluginGRPCOptions *PluginGRPCOptions
+	// PreflightCheck, if set, is called before the engine is started or invoked, and the run is aborted if it fails.
+	PreflightCheck PreflightCheck
+	// Timeout, if set, is the maximum duration of the run. When it is exceeded, the engine plugin is asked to shut
+	// down and is then killed.
+	Timeout time.Duration
+	// ProgressCallback, if set, is called for every message streamed by the engine during the run.
+	ProgressCallback ProgressCallback
+	// Meta is passed to the engine plugin in addition to the `meta` of the engine config, and takes precedence over it.
+	Meta map[string]string
+}
+
+// ExecutionResult is the result of a command run with the engine.
+type ExecutionResult struct {
+	util.CmdOutput
+	// EngineVersion is the version of the engine set in the configuration, empty for local engines.
+	EngineVersion string
+	// PluginAddress is the address the engine plugin serves the gRPC connection on.
+	PluginAddress string
+	StartedAt     time.Time
+	FinishedAt    time.Time
+	// Usage is the resource usage of the engine plugin process, nil if it is not available on the current platform.
+	Usage *ProcessUsage
 }
 
 type engineInstance struct {
 	terragruntEngine *proto.EngineClient
 	client           *plugin.Client
+	cmd              *exec.Cmd
 	executionOptions *ExecutionOptions
 }
 
-// Run executes the given command with the experimental engine.
+// ping checks that the engine plugin process is alive and responds over RPC.
+func (instance *engineInstance) ping() error {
+	if instance.client.Exited() {
+		return errors.WithStackTrace(EnginePluginExitedError{ExitCode: instance.exitCode()})
+	}
+
+	rpcClient, err := instance.client.Client()
+	if err != nil {
+		return errors.WithStackTrace(err)
+	}
+
+	return errors.WithStackTrace(rpcClient.Ping())
+}
+
+// exitCode returns the exit code of the engine plugin process, or -1 if the process has not exited.
+func (instance *engineInstance) exitCode() int {
+	if instance.cmd == nil || instance.cmd.ProcessState == nil {
+		return -1
+	}
+
+	return instance.cmd.ProcessState.ExitCode()
+}
+
+// pluginAddress returns the address of the gRPC server of the engine plugin.
+func (instance *engineInstance) pluginAddress() string {
+	if instance.client == nil {
+		return ""
+	}
+
+	if reattach := instance.client.ReattachConfig(); reattach != nil && reattach.Addr != nil {
+		return reattach.Addr.String()
+	}
+
+	return ""
+}
+
+// Run executes the given command with the experimental engine. If `DryRun` is set and the command changes the state or
+// the infrastructure, such as `apply`, the engine plugin is started and pinged over RPC, to check that it works, but the
+// command is not run and the output is empty.
 func Run(
 	ctx context.Context,
 	runOptions *ExecutionOptions,
-) (*util.CmdOutput, error) {
+) (*ExecutionResult, error) {
+	if runOptions.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, runOptions.Timeout)
+		defer cancel()
+	}
+
+	result, err := run(ctx, runOptions)
+	if err != nil && goErrors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, errors.WithStackTrace(EngineTimeoutError{WorkingDir: runOptions.TerragruntOptions.WorkingDir, Timeout: runOptions.Timeout, Err: err})
+	}
+
+	if result != nil {
+		telemetry.SetSpanAttributes(ctx, map[string]interface{}{
+			"engine_version":        result.EngineVersion,
+			"engine_plugin_address": result.PluginAddress,
+			"engine_started_at":     result.StartedAt.Format(time.RFC3339Nano),
+			"engine_finished_at":    result.FinishedAt.Format(time.RFC3339Nano),
+		})
+	}
+
+	return result, err
+}
+
+func run(ctx context.Context, runOptions *ExecutionOptions) (*ExecutionResult, error) {
+	if err := runPreflightCheck(ctx, runOptions); err != nil {
+		return nil, err
+	}
+
 	engineClients, err := engineClientsFromContext(ctx)
 	if err != nil {
 		return nil, errors.WithStackTrace(err)
@@ -94,20 +201,7 @@ func Run(
 			return nil, errors.WithStackTrace(err)
 		}
 
-		terragruntEngine, client, err := createEngine(runOptions.TerragruntOptions)
-		if err != nil {
-			return nil, errors.WithStackTrace(err)
-		}
-
-		engineClients.Store(workingDir, &engineInstance{
-			terragruntEngine: terragruntEngine,
-			client:           client,
-			executionOptions: runOptions,
-		})
-
-		instance, _ = engineClients.Load(workingDir)
-
-		if err := initialize(ctx, runOptions, terragruntEngine); err != nil {
+		if instance, err = startEngine(ctx, engineClients, runOptions); err != nil {
 			return nil, errors.WithStackTrace(err)
 		}
 	}
@@ -117,22 +211,82 @@ func Run(
 		return nil, errors.WithStackTrace(fmt.Errorf("failed to fetch engine instance %s", workingDir))
 	}
 
+	engInst, err = ensureEngineHealthy(ctx, engineClients, runOptions, engInst)
+	if err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	// in a dry run, the engine is only checked to be reachable over RPC, by the ping of the health check above, but the
+	// read-only commands, such as `output -json`, are still run since their output is parsed
+	if runOptions.TerragruntOptions.DryRun && terraform.IsMutatingCommand(runOptions.Args) {
+		runOptions.TerragruntOptions.Logger.Infof("Dry run: engine %s is reachable for %s, not running %s %s", runOptions.TerragruntOptions.Engine.Source, workingDir, runOptions.Command, strings.Join(runOptions.Args, " "))
+
+		now := time.Now()
+
+		return &ExecutionResult{
+			EngineVersion: runOptions.TerragruntOptions.Engine.Version,
+			PluginAddress: engInst.pluginAddress(),
+			StartedAt:     now,
+			FinishedAt:    now,
+		}, nil
+	}
+
 	terragruntEngine := engInst.terragruntEngine
 
+	runOptions = rewriteCommand(runOptions)
+
+	startedAt := time.Now()
+
 	cmdOutput, err := invoke(ctx, runOptions, terragruntEngine)
 	if err != nil {
+		if goErrors.Is(ctx.Err(), context.DeadlineExceeded) {
+			stopTimedOutEngine(ctx, engineClients, runOptions, engInst)
+		}
+
 		return nil, errors.WithStackTrace(err)
 	}
 
-	return cmdOutput, nil
+	finishedAt := time.Now()
+	cmdOutput.Duration = finishedAt.Sub(startedAt)
+
+	result := &ExecutionResult{
+		CmdOutput:     *cmdOutput,
+		EngineVersion: runOptions.TerragruntOptions.Engine.Version,
+		PluginAddress: engInst.pluginAddress(),
+		StartedAt:     startedAt,
+		FinishedAt:    finishedAt,
+	}
+
+	usage, err := engInst.processUsage()
+	if err != nil {
+		runOptions.TerragruntOptions.Logger.Debugf("Failed to read the resource usage of the engine plugin for %s: %v", workingDir, err)
+	}
+
+	result.Usage = usage
+
+	return result, nil
 }
 
-// WithEngineValues add to context default values for engine.
-func WithEngineValues(ctx context.Context) context.Context {
-	if !IsEngineEnabled() {
-		return ctx
+// stopTimedOutEngine asks the engine plugin that exceeded the run timeout to shut down, then kills it and removes it
+// from the engine clients, so that the next run in the working directory starts a new plugin.
+func stopTimedOutEngine(ctx context.Context, engineClients *sync.Map, runOptions *ExecutionOptions, instance *engineInstance) {
+	opts := runOptions.TerragruntOptions
+
+	opts.Logger.Warnf("Engine run in %s exceeded the timeout of %v, shutting down the engine", opts.WorkingDir, runOptions.Timeout)
+
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), engineShutdownTimeout)
+	defer cancel()
+
+	if err := shutdown(shutdownCtx, runOptions, instance.terragruntEngine); err != nil {
+		opts.Logger.Debugf("Error shutting down engine for %s: %v", opts.WorkingDir, err)
 	}
 
+	instance.client.Kill()
+	engineClients.Delete(opts.WorkingDir)
+}
+
+// WithEngineValues add to context default values for engine.
+func WithEngineValues(ctx context.Context) context.Context {
 	ctx = context.WithValue(ctx, TerraformCommandContextKey, &sync.Map{})
 	ctx = context.WithValue(ctx, LocksContextKey, util.NewKeyLocks())
 	ctx = context.WithValue(ctx, LatestVersionsContextKey, cache.NewCache[string]("engineVersions"))
@@ -142,7 +296,7 @@ func WithEngineValues(ctx context.Context) context.Context {
 
 // DownloadEngine downloads the engine for the given options.
 func DownloadEngine(ctx context.Context, opts *options.TerragruntOptions) error {
-	if !IsEngineEnabled() {
+	if !IsEngineEnabled(ctx, opts) {
 		return nil
 	}
 
@@ -153,6 +307,26 @@ func DownloadEngine(ctx context.Context, opts *options.TerragruntOptions) error
 		return nil
 	}
 
+	platform, err := enginePlatform(opts)
+	if err != nil {
+		return errors.WithStackTrace(err)
+	}
+
+	// use the version pinned in the lock file if not specified
+	if len(e.Version) == 0 {
+		version, err := lockedEngineVersion(opts, platform)
+		if err != nil {
+			return err
+		}
+
+		e.Version = version
+	}
+
+	// object storage sources are laid out per version, the version can't be inferred
+	if len(e.Version) == 0 && isObjectStorageSource(e.Source) {
+		return errors.WithStackTrace(EngineVersionRequiredError{Source: e.Source})
+	}
+
 	// identify engine version if not specified
 	if len(e.Version) == 0 {
 		if !strings.Contains(e.Source, "://") {
@@ -165,7 +339,7 @@ func DownloadEngine(ctx context.Context, opts *options.TerragruntOptions) error
 		}
 	}
 
-	path, err := engineDir(e)
+	path, err := engineDir(opts, e, platform)
 	if err != nil {
 		return errors.WithStackTrace(err)
 	}
@@ -174,7 +348,7 @@ func DownloadEngine(ctx context.Context, opts *options.TerragruntOptions) error
 		return errors.WithStackTrace(err)
 	}
 
-	localEngineFile := filepath.Join(path, engineFileName(e))
+	localEngineFile := filepath.Join(path, engineFileName(e, platform))
 
 	// lock downloading process for only one instance
 	locks, err := downloadLocksFromContext(ctx)
@@ -187,31 +361,44 @@ func DownloadEngine(ctx context.Context, opts *options.TerragruntOptions) error
 	defer locks.Unlock(localEngineFile)
 
 	if util.FileExists(localEngineFile) {
-		return nil
+		return checkEngineLock(ctx, opts, platform, localEngineFile, nil)
 	}
 
-	downloadFile := filepath.Join(path, enginePackageName(e))
+	downloadFile := filepath.Join(path, enginePackageName(e, platform))
 
 	downloads := make(map[string]string)
 	checksumFile := ""
 	checksumSigFile := ""
 
-	if strings.Contains(e.Source, "://") {
+	if strings.Contains(e.Source, "://") && !isObjectStorageSource(e.Source) {
 		// if source starts with absolute path, download as is
 		downloads[e.Source] = downloadFile
 	} else {
 		baseURL := fmt.Sprintf("https://%s/releases/download/%s", e.Source, e.Version)
+		if isObjectStorageSource(e.Source) {
+			// object storage buckets mirror the layout of the GitHub releases
+			baseURL = fmt.Sprintf("%s/%s", strings.TrimSuffix(e.Source, "/"), e.Version)
+		}
 
 		// URLs and their corresponding local paths
 		checksumFile = filepath.Join(path, engineChecksumName(e))
 		checksumSigFile = filepath.Join(path, engineChecksumSigName(e))
-		downloads[fmt.Sprintf("%s/%s", baseURL, enginePackageName(e))] = downloadFile
+		downloads[fmt.Sprintf("%s/%s", baseURL, enginePackageName(e, platform))] = downloadFile
 		downloads[fmt.Sprintf("%s/%s", baseURL, engineChecksumName(e))] = checksumFile
 		downloads[fmt.Sprintf("%s/%s.sig", baseURL, engineChecksumName(e))] = checksumSigFile
 	}
 
 	for url, path := range downloads {
 		opts.Logger.Infof("Downloading %s to %s", url, path)
+
+		if isObjectStorageSource(url) {
+			if err := downloadFromObjectStorage(ctx, url, path); err != nil {
+				return errors.WithStackTrace(err)
+			}
+
+			continue
+		}
+
 		client := &getter.Client{
 			Ctx:           ctx,
 			Src:           url,
@@ -220,12 +407,17 @@ func DownloadEngine(ctx context.Context, opts *options.TerragruntOptions) error
 			Decompressors: map[string]getter.Decompressor{},
 		}
 
+		// the package is the large download, its progress is logged
+		if path == downloadFile {
+			client.ProgressListener = &downloadProgressTracker{logger: opts.Logger}
+		}
+
 		if err := client.Get(); err != nil {
 			return errors.WithStackTrace(err)
 		}
 	}
 
-	if !skipEngineCheck() && checksumFile != "" && checksumSigFile != "" {
+	if !skipEngineCheck(opts.Engine) && checksumFile != "" && checksumSigFile != "" {
 		opts.Logger.Infof("Verifying checksum for %s", downloadFile)
 
 		if err := verifyFile(downloadFile, checksumFile, checksumSigFile); err != nil {
@@ -239,6 +431,15 @@ func DownloadEngine(ctx context.Context, opts *options.TerragruntOptions) error
 		return errors.WithStackTrace(err)
 	}
 
+	checksum, err := storeEngineBlob(opts, localEngineFile)
+	if err != nil {
+		return err
+	}
+
+	if err := checkEngineLock(ctx, opts, platform, localEngineFile, checksum); err != nil {
+		return err
+	}
+
 	opts.Logger.Infof("Engine available as %s", path)
 
 	return nil
@@ -350,12 +551,27 @@ func extractArchive(opts *options.TerragruntOptions, downloadFile string, engine
 }
 
 // engineDir returns the directory path where engine files are stored.
-func engineDir(e *options.EngineOptions) (string, error) {
+func engineDir(opts *options.TerragruntOptions, e *options.EngineOptions, platform Platform) (string, error) {
 	if util.FileExists(e.Source) {
 		return filepath.Dir(e.Source), nil
 	}
 
-	cacheDir := os.Getenv(EngineCachePathEnv)
+	cacheDir, err := engineCacheDir(opts)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(cacheDir, EngineCacheDir, e.Type, e.Version, platform.OS, platform.Arch), nil
+}
+
+// engineCacheDir returns the root directory of the engine cache, set by `--terragrunt-engine-cache-path` or, if the flag
+// is not set, by `TG_ENGINE_CACHE_PATH`.
+func engineCacheDir(opts *options.TerragruntOptions) (string, error) {
+	cacheDir := opts.EngineCachePath
+	if cacheDir == "" {
+		cacheDir = os.Getenv(EngineCachePathEnv)
+	}
+
 	if cacheDir == "" {
 		homeDir, err := os.UserHomeDir()
 		if err != nil {
@@ -365,25 +581,20 @@ func engineDir(e *options.EngineOptions) (string, error) {
 		cacheDir = filepath.Join(homeDir, DefaultCacheDir)
 	}
 
-	platform := runtime.GOOS
-	arch := runtime.GOARCH
-
-	return filepath.Join(cacheDir, EngineCacheDir, e.Type, e.Version, platform, arch), nil
+	return cacheDir, nil
 }
 
 // engineFileName returns the file name for the engine.
-func engineFileName(e *options.EngineOptions) string {
+func engineFileName(e *options.EngineOptions, platform Platform) string {
 	engineName := filepath.Base(e.Source)
 	if util.FileExists(e.Source) {
 		// return file name if source is absolute path
 		return engineName
 	}
 
-	platform := runtime.GOOS
-	arch := runtime.GOARCH
 	engineName = strings.TrimPrefix(engineName, PrefixTrim)
 
-	return fmt.Sprintf(FileNameFormat, engineName, e.Type, e.Version, platform, arch)
+	return fmt.Sprintf(FileNameFormat, engineName, e.Type, e.Version, platform.OS, platform.Arch)
 }
 
 // engineChecksumName returns the file name of engine checksum file
@@ -401,8 +612,8 @@ func engineChecksumSigName(e *options.EngineOptions) string {
 }
 
 // enginePackageName returns the package name for the engine.
-func enginePackageName(e *options.EngineOptions) string {
-	return engineFileName(e) + ".zip"
+func enginePackageName(e *options.EngineOptions, platform Platform) string {
+	return engineFileName(e, platform) + ".zip"
 }
 
 // isArchiveByHeader checks if a file is an archive by examining its header.
@@ -462,15 +673,25 @@ func engineVersionsCacheFromContext(ctx context.Context) (*cache.Cache[string],
 	return result, nil
 }
 
-// IsEngineEnabled returns true if the experimental engine is enabled.
-func IsEngineEnabled() bool {
-	ok, _ := strconv.ParseBool(os.Getenv(EnableExperimentalEngineEnvName)) //nolint:errcheck
-	return ok
+// IsEngineEnabled returns true if the experimental engine is enabled by `WithEngineEnabled` in the given context or,
+// if the context doesn't say, in the given options.
+func IsEngineEnabled(ctx context.Context, opts *options.TerragruntOptions) bool {
+	if enabled, ok := ctx.Value(engineEnabledContextKey).(bool); ok {
+		return enabled
+	}
+
+	return opts != nil && opts.EngineEnabled
+}
+
+// WithEngineEnabled returns a context that enables or disables the experimental engine regardless of the options, e.g.
+// to run engine-enabled and engine-disabled code paths in the same test binary.
+func WithEngineEnabled(ctx context.Context, enabled bool) context.Context {
+	return context.WithValue(ctx, engineEnabledContextKey, enabled)
 }
 
 // Shutdown shuts down the experimental engine.
-func Shutdown(ctx context.Context) error {
-	if !IsEngineEnabled() {
+func Shutdown(ctx context.Context, opts *options.TerragruntOptions) error {
+	if !IsEngineEnabled(ctx, opts) {
 		return nil
 	}
 
@@ -497,20 +718,30 @@ func Shutdown(ctx context.Context) error {
 }
 
 // createEngine create engine for working directory
-func createEngine(terragruntOptions *options.TerragruntOptions) (*proto.EngineClient, *plugin.Client, error) {
-	path, err := engineDir(terragruntOptions.Engine)
+func createEngine(terragruntOptions *options.TerragruntOptions, grpcOptions *PluginGRPCOptions) (*engineInstance, error) {
+	platform, err := enginePlatform(terragruntOptions)
+	if err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	path, err := engineDir(terragruntOptions, terragruntOptions.Engine, platform)
 	if err != nil {
-		return nil, nil, errors.WithStackTrace(err)
+		return nil, errors.WithStackTrace(err)
 	}
 
-	localEnginePath := filepath.Join(path, engineFileName(terragruntOptions.Engine))
+	localEnginePath := filepath.Join(path, engineFileName(terragruntOptions.Engine, platform))
 	localChecksumFile := filepath.Join(path, engineChecksumName(terragruntOptions.Engine))
 	localChecksumSigFile := filepath.Join(path, engineChecksumSigName(terragruntOptions.Engine))
 
 	// validate engine before loading if verification is not disabled
-	if !skipEngineCheck() && util.FileExists(localEnginePath) && util.FileExists(localChecksumFile) && util.FileExists(localChecksumSigFile) {
+	if !skipEngineCheck(terragruntOptions.Engine) && util.FileExists(localEnginePath) && util.FileExists(localChecksumFile) && util.FileExists(localChecksumSigFile) {
 		if err := verifyFile(localEnginePath, localChecksumFile, localChecksumSigFile); err != nil {
-			return nil, nil, errors.WithStackTrace(err)
+			var checksumErr ErrEngineBinaryChecksum
+			if goErrors.As(err, &checksumErr) {
+				terragruntOptions.Logger.Errorf("The engine binary %s may be corrupted or tampered with. Remove it so that Terragrunt downloads it again, or set `skip_checksum_check = true` in the engine block or %s=true to skip verification for locally built engines.", checksumErr.BinaryPath, EngineSkipCheckEnv)
+			}
+
+			return nil, errors.WithStackTrace(err)
 		}
 	} else {
 		terragruntOptions.Logger.Warnf("Skipping verification for %s", localEnginePath)
@@ -518,6 +749,8 @@ func createEngine(terragruntOptions *options.TerragruntOptions) (*proto.EngineCl
 
 	terragruntOptions.Logger.Debugf("Creating engine %s", localEnginePath)
 
+	cmd := exec.Command(localEnginePath)
+
 	logger := hclog.NewInterceptLogger(&hclog.LoggerOptions{
 		Level:  hclog.Debug,
 		Output: terragruntOptions.Logger.Writer(),
@@ -525,44 +758,117 @@ func createEngine(terragruntOptions *options.TerragruntOptions) (*proto.EngineCl
 	client := plugin.NewClient(&plugin.ClientConfig{
 		Logger: logger,
 		HandshakeConfig: plugin.HandshakeConfig{
-			ProtocolVersion:  engineVersion,
 			MagicCookieKey:   engineCookieKey,
 			MagicCookieValue: engineCookieValue,
 		},
-		Plugins: map[string]plugin.Plugin{
-			"plugin": &engine.TerragruntGRPCEngine{},
-		},
-		Cmd: exec.Command(localEnginePath),
-		GRPCDialOptions: []grpc.DialOption{
-			grpc.WithTransportCredentials(insecure.NewCredentials()),
-		},
+		VersionedPlugins: engineVersionedPlugins(),
+		Cmd:              cmd,
+		GRPCDialOptions:  grpcOptions.DialOptions(),
 		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
 	})
 
 	rpcClient, err := client.Client()
 	if err != nil {
-		return nil, nil, errors.WithStackTrace(err)
+		client.Kill()
+		return nil, engineHandshakeError(err)
 	}
 
+	terragruntOptions.Logger.Debugf("Engine %s negotiated RPC protocol version %d, Terragrunt supports version %s", localEnginePath, client.NegotiatedVersion(), runtimeProtocolVersions())
+
 	rawClient, err := rpcClient.Dispense("plugin")
 	if err != nil {
-		return nil, nil, errors.WithStackTrace(err)
+		client.Kill()
+		return nil, errors.WithStackTrace(err)
 	}
 
 	terragruntEngine := rawClient.(proto.EngineClient)
 
-	return &terragruntEngine, client, nil
+	return &engineInstance{
+		terragruntEngine: &terragruntEngine,
+		client:           client,
+		cmd:              cmd,
+	}, nil
+}
+
+// startEngine creates the engine plugin process for the working directory, stores it in the engine clients and
+// initializes it.
+func startEngine(ctx context.Context, engineClients *sync.Map, runOptions *ExecutionOptions) (*engineInstance, error) {
+	var instance *engineInstance
+
+	err := DoWithBackoff(ctx, runOptions.BackoffPolicy, runOptions.TerragruntOptions.Logger, "Starting engine", func() error {
+		var err error
+
+		instance, err = createEngine(runOptions.TerragruntOptions, runOptions.PluginGRPCOptions)
+
+		return err
+	})
+	if err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	instance.executionOptions = runOptions
+	engineClients.Store(runOptions.TerragruntOptions.WorkingDir, instance)
+
+	if err := initialize(ctx, runOptions, instance.terragruntEngine); err != nil {
+		return instance, errors.WithStackTrace(err)
+	}
+
+	return instance, nil
+}
+
+// ensureEngineHealthy pings the engine plugin and, if it does not respond, restarts it up to
+// --terragrunt-engine-restart-attempts times.
+func ensureEngineHealthy(ctx context.Context, engineClients *sync.Map, runOptions *ExecutionOptions, instance *engineInstance) (*engineInstance, error) {
+	var (
+		opts = runOptions.TerragruntOptions
+		err  = instance.ping()
+	)
+
+	for attempt := 1; err != nil; attempt++ {
+		if attempt > opts.EngineRestartAttempts {
+			return nil, errors.WithStackTrace(EngineUnhealthyError{WorkingDir: opts.WorkingDir, Attempts: opts.EngineRestartAttempts, Err: err})
+		}
+
+		exitCode := -1
+
+		if instance != nil {
+			exitCode = instance.exitCode()
+			instance.client.Kill()
+		}
+
+		opts.Logger.Warnf("Engine plugin for %s failed health check (exit code %d): %v. Restarting it, attempt %d of %d", opts.WorkingDir, exitCode, err, attempt, opts.EngineRestartAttempts)
+
+		if instance, err = startEngine(ctx, engineClients, runOptions); err == nil {
+			err = instance.ping()
+		}
+	}
+
+	return instance, nil
 }
 
 // invoke engine for working directory
 func invoke(ctx context.Context, runOptions *ExecutionOptions, client *proto.EngineClient) (*util.CmdOutput, error) {
 	terragruntOptions := runOptions.TerragruntOptions
 
-	meta, err := ConvertMetaToProtobuf(runOptions.TerragruntOptions.Engine.Meta)
+	meta, err := ConvertMetaToProtobuf(engineMeta(runOptions))
 	if err != nil {
 		return nil, errors.WithStackTrace(err)
 	}
 
+	cleanupStateFile, err := decryptStatePushFile(runOptions)
+	if err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+	defer cleanupStateFile()
+
+	// The plaintext state printed by `state pull` is held back and only written out once it is encrypted.
+	encryptStdout := runOptions.StateEncryption != nil && isStatePullCommand(runOptions.Args)
+
+	cmdStdout := runOptions.CmdStdout
+	if encryptStdout {
+		cmdStdout = io.Discard
+	}
+
 	response, err := (*client).Run(ctx, &proto.RunRequest{
 		Command:           runOptions.Command,
 		Args:              runOptions.Args,
@@ -575,13 +881,15 @@ func invoke(ctx context.Context, runOptions *ExecutionOptions, client *proto.Eng
 		return nil, errors.WithStackTrace(err)
 	}
 
-	var stdoutBuf, stderrBuf bytes.Buffer
-	stdout := io.MultiWriter(runOptions.CmdStdout, &stdoutBuf)
-	stderr := io.MultiWriter(runOptions.CmdStderr, &stderrBuf)
+	var (
+		stdout = util.NewTeeBuffer(cmdStdout)
+		stderr = util.NewTeeBuffer(runOptions.CmdStderr)
+	)
 
 	var (
 		stdoutLineBuf, stderrLineBuf bytes.Buffer
 		resultCode                   int
+		progress                     applyProgress
 	)
 
 	for {
@@ -599,6 +907,27 @@ func invoke(ctx context.Context, runOptions *ExecutionOptions, client *proto.Eng
 		}
 
 		resultCode = int(runResp.GetResultCode())
+
+		if runOptions.ProgressCallback != nil {
+			// the plaintext state must not be shown as progress either
+			progressStdout := runResp.GetStdout()
+			if encryptStdout {
+				progressStdout = ""
+			}
+
+			runOptions.ProgressCallback(ProgressEvent{
+				Percentage: progress.update(progressStdout),
+				Message:    progressMessage(progressStdout, runResp.GetStderr()),
+				Timestamp:  time.Now(),
+			})
+		}
+	}
+
+	if runOptions.ProgressCallback != nil {
+		runOptions.ProgressCallback(ProgressEvent{
+			Percentage: CompletedProgress,
+			Timestamp:  time.Now(),
+		})
 	}
 
 	if err := flushBuffer(&stdoutLineBuf, stdout); err != nil {
@@ -611,20 +940,46 @@ func invoke(ctx context.Context, runOptions *ExecutionOptions, client *proto.Eng
 
 	terragruntOptions.Logger.Debugf("Engine execution done in %v", terragruntOptions.WorkingDir)
 
+	// the output stream also ends when the run is cancelled, e.g. when it exceeds `Timeout`
+	if err := ctx.Err(); err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
 	if resultCode != 0 {
+		// the stdout of a failed `state pull` may still hold the plaintext state, which is not encrypted
+		failedStdout := stdout.String()
+		if encryptStdout {
+			failedStdout = ""
+		}
+
 		err = util.ProcessExecutionError{
 			Err:        fmt.Errorf("command failed with exit code %d", resultCode),
-			Stdout:     stdoutBuf.String(),
-			Stderr:     stderrBuf.String(),
+			Stdout:     failedStdout,
+			Stderr:     stderr.String(),
 			WorkingDir: terragruntOptions.WorkingDir,
 		}
 
 		return nil, errors.WithStackTrace(err)
 	}
 
+	stdoutContent := stdout.String()
+
+	if encryptStdout {
+		ciphertext, err := runOptions.StateEncryption.Encrypt([]byte(stdoutContent))
+		if err != nil {
+			return nil, errors.WithStackTrace(err)
+		}
+
+		if _, err := runOptions.CmdStdout.Write(ciphertext); err != nil {
+			return nil, errors.WithStackTrace(err)
+		}
+
+		stdoutContent = string(ciphertext)
+	}
+
 	cmdOutput := util.CmdOutput{
-		Stdout: stdoutBuf.String(),
-		Stderr: stderrBuf.String(),
+		Stdout: stdoutContent,
+		Stderr: stderr.String(),
 	}
 
 	return &cmdOutput, nil
@@ -662,7 +1017,7 @@ func flushBuffer(lineBuf *bytes.Buffer, output io.Writer) error {
 func initialize(ctx context.Context, runOptions *ExecutionOptions, client *proto.EngineClient) error {
 	terragruntOptions := runOptions.TerragruntOptions
 
-	meta, err := ConvertMetaToProtobuf(runOptions.TerragruntOptions.Engine.Meta)
+	meta, err := ConvertMetaToProtobuf(engineMeta(runOptions))
 	if err != nil {
 		return errors.WithStackTrace(err)
 	}
@@ -701,7 +1056,7 @@ func initialize(ctx context.Context, runOptions *ExecutionOptions, client *proto
 func shutdown(ctx context.Context, runOptions *ExecutionOptions, terragruntEngine *proto.EngineClient) error {
 	terragruntOptions := runOptions.TerragruntOptions
 
-	meta, err := ConvertMetaToProtobuf(runOptions.TerragruntOptions.Engine.Meta)
+	meta, err := ConvertMetaToProtobuf(engineMeta(runOptions))
 	if err != nil {
 		return errors.WithStackTrace(err)
 	}
@@ -771,6 +1126,25 @@ func ReadEngineOutput(runOptions *ExecutionOptions, output outputFn) error {
 	return nil //nolint:nilerr
 }
 
+// engineMeta returns the `meta` of the engine config merged with the metadata of the execution options.
+func engineMeta(runOptions *ExecutionOptions) map[string]interface{} {
+	if len(runOptions.Meta) == 0 {
+		return runOptions.TerragruntOptions.Engine.Meta
+	}
+
+	meta := make(map[string]interface{}, len(runOptions.TerragruntOptions.Engine.Meta)+len(runOptions.Meta))
+
+	for key, value := range runOptions.TerragruntOptions.Engine.Meta {
+		meta[key] = value
+	}
+
+	for key, value := range runOptions.Meta {
+		meta[key] = value
+	}
+
+	return meta
+}
+
 // ConvertMetaToProtobuf converts metadata map to protobuf map
 func ConvertMetaToProtobuf(meta map[string]interface{}) (map[string]*anypb.Any, error) {
 	protoMeta := make(map[string]*anypb.Any)
@@ -800,8 +1174,13 @@ func ConvertMetaToProtobuf(meta map[string]interface{}) (map[string]*anypb.Any,
 	return protoMeta, nil
 }
 
-// skipChecksumCheck returns true if the engine checksum check is skipped.
-func skipEngineCheck() bool {
+// skipEngineCheck returns true if the checksum check of the given engine is skipped, either by the
+// `skip_checksum_check` attribute of its block or, if it is not set, by the `TG_ENGINE_SKIP_CHECK` env var.
+func skipEngineCheck(engine *options.EngineOptions) bool {
+	if engine != nil && engine.SkipChecksumCheck != nil {
+		return *engine.SkipChecksumCheck
+	}
+
 	ok, _ := strconv.ParseBool(os.Getenv(EngineSkipCheckEnv)) //nolint:errcheck
 	return ok
 }
@@ -0,0 +1,145 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	goErrors "errors"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunStateEncryptionFailedStatePull(t *testing.T) {
+	t.Parallel()
+
+	const state = `{"version": 4, "lineage": "3f1e3b7a-8c3a-4a4f-9c2f-1f6b2d5e7a90", "resources": []}`
+
+	ctx := WithEngineValues(context.Background())
+
+	opts := newFakeEngineOptions(ctx, t)
+	opts.Env = map[string]string{
+		fakeEngineStdoutEnv:   state + "\n",
+		fakeEngineExitCodeEnv: "1",
+	}
+
+	enc, err := NewAESGCMStateEncryption([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+
+	var (
+		stdout   bytes.Buffer
+		progress []string
+	)
+
+	_, err = Run(ctx, &ExecutionOptions{
+		TerragruntOptions: opts,
+		CmdStdout:         &stdout,
+		CmdStderr:         &bytes.Buffer{},
+		WorkingDir:        opts.WorkingDir,
+		Command:           "tofu",
+		Args:              []string{"state", "pull"},
+		StateEncryption:   enc,
+		ProgressCallback: func(event ProgressEvent) {
+			progress = append(progress, event.Message)
+		},
+	})
+
+	var processErr util.ProcessExecutionError
+	require.True(t, goErrors.As(err, &processErr), err)
+
+	// the plaintext state is not returned, written or shown as progress
+	assert.NotContains(t, processErr.Stdout, "lineage")
+	assert.NotContains(t, stdout.String(), "lineage")
+
+	for _, message := range progress {
+		assert.NotContains(t, message, "lineage")
+	}
+}
+
+// TestRunTimeoutStopsEngine is not parallel since it shortens the package level engine shutdown timeout.
+func TestRunTimeoutStopsEngine(t *testing.T) { //nolint:paralleltest
+	defaultShutdownTimeout := engineShutdownTimeout
+	engineShutdownTimeout = time.Second
+
+	t.Cleanup(func() {
+		engineShutdownTimeout = defaultShutdownTimeout
+	})
+
+	ctx := WithEngineValues(context.Background())
+
+	opts := newFakeEngineOptions(ctx, t)
+
+	runOptions := &ExecutionOptions{
+		TerragruntOptions: opts,
+		CmdStdout:         &bytes.Buffer{},
+		CmdStderr:         &bytes.Buffer{},
+		WorkingDir:        opts.WorkingDir,
+		Command:           "tofu",
+		Args:              []string{"plan"},
+	}
+
+	_, err := Run(ctx, runOptions)
+	require.NoError(t, err)
+
+	engineClients, err := engineClientsFromContext(ctx)
+	require.NoError(t, err)
+
+	instance, ok := engineClients.Load(opts.WorkingDir)
+	require.True(t, ok)
+
+	// the plugin answers neither the run nor the shutdown request
+	opts.Env = map[string]string{fakeEngineHangEnv: "true"}
+	runOptions.Timeout = time.Second
+
+	_, err = Run(ctx, runOptions)
+
+	var timeoutErr EngineTimeoutError
+	require.True(t, goErrors.As(err, &timeoutErr), err)
+
+	_, ok = engineClients.Load(opts.WorkingDir)
+	assert.False(t, ok)
+	assert.True(t, instance.(*engineInstance).client.Exited())
+}
+
+func TestRunCommandRewriter(t *testing.T) {
+	t.Parallel()
+
+	ctx := WithEngineValues(context.Background())
+
+	opts := newFakeEngineOptions(ctx, t)
+
+	var stdout bytes.Buffer
+
+	runOptions := &ExecutionOptions{
+		TerragruntOptions: opts,
+		CmdStdout:         &stdout,
+		CmdStderr:         &bytes.Buffer{},
+		WorkingDir:        opts.WorkingDir,
+		Command:           "tofu",
+		Args:              []string{"refresh", "--lock=false"},
+		CommandRewriter:   OpenTofuCommandRewriter("1.8.0"),
+	}
+
+	_, err := Run(ctx, runOptions)
+	require.NoError(t, err)
+
+	// the engine runs the rewritten command, the execution options of the caller are not modified
+	assert.Equal(t, "tofu apply -refresh-only -auto-approve -lock=false\n", stdout.String())
+	assert.Equal(t, []string{"refresh", "--lock=false"}, runOptions.Args)
+}
+
+func TestApplyProgress(t *testing.T) {
+	t.Parallel()
+
+	var progress applyProgress
+
+	assert.InDelta(t, UnknownProgress, progress.update("Refreshing state..."), 0)
+	assert.InDelta(t, 0, progress.update("\x1b[1mPlan:\x1b[0m 3 to add, 1 to change, 0 to destroy.\n"), 0)
+	assert.InDelta(t, 25, progress.update("aws_vpc.main: Creation complete after 2s [id=vpc-123]\n"), 0)
+	assert.InDelta(t, 75, progress.update("aws_subnet.a: Creation complete after 1s [id=subnet-a]\naws_instance.web: Modifications complete after 3s [id=i-123]\n"), 0)
+
+	// the run is only complete once the stream ends
+	assert.InDelta(t, 99, progress.update("aws_subnet.b: Creation complete after 1s [id=subnet-b]\n"), 0)
+}
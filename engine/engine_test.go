@@ -1,24 +1,34 @@
 package engine_test
 
 import (
+	"context"
 	"io"
 	"testing"
 
+	"github.com/gruntwork-io/go-commons/errors"
 	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestIsEngineEnabled(t *testing.T) {
-	t.Setenv("TG_EXPERIMENTAL_ENGINE", "true")
+	t.Parallel()
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
 
-	assert.True(t, engine.IsEngineEnabled())
+	ctx := context.Background()
 
-	t.Setenv("TG_EXPERIMENTAL_ENGINE", "false")
-	assert.False(t, engine.IsEngineEnabled())
+	opts.EngineEnabled = true
+	assert.True(t, engine.IsEngineEnabled(ctx, opts))
+	assert.False(t, engine.IsEngineEnabled(engine.WithEngineEnabled(ctx, false), opts))
 
-	t.Setenv("TG_EXPERIMENTAL_ENGINE", "")
-	assert.False(t, engine.IsEngineEnabled())
+	opts.EngineEnabled = false
+	assert.False(t, engine.IsEngineEnabled(ctx, opts))
+	assert.True(t, engine.IsEngineEnabled(engine.WithEngineEnabled(ctx, true), opts))
+
+	assert.False(t, engine.IsEngineEnabled(ctx, nil))
 }
 
 func TestConvertMetaToProtobuf(t *testing.T) {
@@ -56,3 +66,42 @@ func TestReadEngineOutput(t *testing.T) {
 	err := engine.ReadEngineOutput(runOptions, outputFn)
 	assert.NoError(t, err)
 }
+
+func TestOpenTofuCommandRewriter(t *testing.T) {
+	t.Parallel()
+
+	rewriter := engine.OpenTofuCommandRewriter("1.8.0")
+
+	cmd, args := rewriter("tofu", []string{"plan", "--refresh=false", "-input=false"})
+	assert.Equal(t, "tofu", cmd)
+	assert.Equal(t, []string{"plan", "-refresh=false", "-input=false"}, args)
+
+	cmd, args = rewriter("tofu", []string{"refresh", "--var-file=prod.tfvars"})
+	assert.Equal(t, "tofu", cmd)
+	assert.Equal(t, []string{"apply", "-refresh-only", "-auto-approve", "-var-file=prod.tfvars"}, args)
+
+	// the command name follows the global flags
+	_, args = rewriter("tofu", []string{"--chdir=modules/vpc", "refresh"})
+	assert.Equal(t, []string{"-chdir=modules/vpc", "apply", "-refresh-only", "-auto-approve"}, args)
+
+	// the version cannot be parsed, only flags are canonicalized
+	_, args = engine.OpenTofuCommandRewriter("unknown")("tofu", []string{"refresh", "--refresh=false"})
+	assert.Equal(t, []string{"refresh", "-refresh=false"}, args)
+}
+
+func TestErrEngineBinaryChecksum(t *testing.T) {
+	t.Parallel()
+
+	err := errors.WithStackTrace(engine.ErrEngineBinaryChecksum{
+		BinaryPath: "/tmp/terragrunt-iac-engine-opentofu",
+		Expected:   "aaaa",
+		Actual:     "bbbb",
+	})
+
+	var checksumErr engine.ErrEngineBinaryChecksum
+	require.ErrorAs(t, err, &checksumErr)
+	assert.Equal(t, "/tmp/terragrunt-iac-engine-opentofu", checksumErr.BinaryPath)
+	assert.Equal(t, "aaaa", checksumErr.Expected)
+	assert.Equal(t, "bbbb", checksumErr.Actual)
+	assert.Contains(t, err.Error(), "engine binary /tmp/terragrunt-iac-engine-opentofu has unexpected SHA-256 hash bbbb (expected aaaa)")
+}
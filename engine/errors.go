@@ -0,0 +1,144 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrStateCiphertextTooShort is returned when the encrypted state is shorter than the nonce it must start with.
+var ErrStateCiphertextTooShort = errors.New("encrypted state is too short")
+
+// EnginePluginExitedError is returned by the health check when the engine plugin process is no longer running.
+type EnginePluginExitedError struct {
+	ExitCode int
+}
+
+func (err EnginePluginExitedError) Error() string {
+	return fmt.Sprintf("engine plugin process exited with code %d", err.ExitCode)
+}
+
+// EngineUnhealthyError is returned when the engine plugin keeps failing the health check after all restart attempts.
+type EngineUnhealthyError struct {
+	WorkingDir string
+	Attempts   int
+	Err        error
+}
+
+func (err EngineUnhealthyError) Error() string {
+	return fmt.Sprintf("engine plugin for %s is not responding after %d restart attempts: %v", err.WorkingDir, err.Attempts, err.Err)
+}
+
+func (err EngineUnhealthyError) Unwrap() error {
+	return err.Err
+}
+
+// ErrEngineBinaryChecksum is returned when the SHA-256 hash of the engine binary does not match the one in the signed
+// checksum list.
+type ErrEngineBinaryChecksum struct {
+	BinaryPath string
+	Expected   string
+	Actual     string
+}
+
+func (err ErrEngineBinaryChecksum) Error() string {
+	return fmt.Sprintf("engine binary %s has unexpected SHA-256 hash %s (expected %s)", err.BinaryPath, err.Actual, err.Expected)
+}
+
+// LocalEngineBinaryError is returned by NewLocalEngine when the engine binary cannot be run.
+type LocalEngineBinaryError struct {
+	BinaryPath string
+	Reason     string
+}
+
+func (err LocalEngineBinaryError) Error() string {
+	return fmt.Sprintf("local engine binary %s cannot be used: %s", err.BinaryPath, err.Reason)
+}
+
+// UnsupportedEnginePlatformError is returned when --terragrunt-engine-platform is not one of SupportedPlatforms.
+type UnsupportedEnginePlatformError string
+
+func (err UnsupportedEnginePlatformError) Error() string {
+	return fmt.Sprintf("unsupported engine platform %q, expected os/arch, one of: %s", string(err), strings.Join(SupportedPlatforms, ", "))
+}
+
+// PreflightCheckError is returned when the preflight check of the execution options fails, before the engine is started.
+type PreflightCheckError struct {
+	WorkingDir string
+	Err        error
+}
+
+func (err PreflightCheckError) Error() string {
+	return fmt.Sprintf("engine preflight check for %s failed: %v", err.WorkingDir, err.Err)
+}
+
+func (err PreflightCheckError) Unwrap() error {
+	return err.Err
+}
+
+// InsufficientDiskSpaceError is returned by the disk space preflight check.
+type InsufficientDiskSpaceError struct {
+	Path         string
+	FreeBytes    uint64
+	MinFreeBytes int64
+}
+
+func (err InsufficientDiskSpaceError) Error() string {
+	return fmt.Sprintf("only %d bytes are free on the filesystem of %s, at least %d bytes are required", err.FreeBytes, err.Path, err.MinFreeBytes)
+}
+
+// UnsupportedEngineVersionsSourceError is returned when the versions of an engine source that is not a GitHub
+// repository are listed.
+type UnsupportedEngineVersionsSourceError string
+
+func (err UnsupportedEngineVersionsSourceError) Error() string {
+	return fmt.Sprintf("cannot list the versions of engine source %q, only GitHub repositories such as github.com/gruntwork-io/terragrunt-engine-opentofu are supported", string(err))
+}
+
+// EngineTimeoutError is returned when an engine run takes longer than the timeout of the execution options.
+type EngineTimeoutError struct {
+	WorkingDir string
+	Timeout    time.Duration
+	Err        error
+}
+
+func (err EngineTimeoutError) Error() string {
+	return fmt.Sprintf("engine run in %s did not finish within %v: %v", err.WorkingDir, err.Timeout, err.Err)
+}
+
+func (err EngineTimeoutError) Unwrap() error {
+	return err.Err
+}
+
+// EngineVersionRequiredError is returned when the engine is downloaded from a source that can't list its releases.
+type EngineVersionRequiredError struct {
+	Source string
+}
+
+func (err EngineVersionRequiredError) Error() string {
+	return fmt.Sprintf("engine version must be set to download the engine from %s", err.Source)
+}
+
+// EngineLockMismatchError is returned when the version or the hash of the engine binary differs from the lock file.
+type EngineLockMismatchError struct {
+	Path   string
+	Locked LockedEngine
+	Actual LockedEngine
+}
+
+func (err EngineLockMismatchError) Error() string {
+	return fmt.Sprintf("engine %s for %s does not match the lock file %s: locked version %s with SHA-256 %s, got version %s with SHA-256 %s. Run `terragrunt engine update-lock` to update the lock file",
+		err.Actual.Source, err.Actual.Platform, err.Path, err.Locked.Version, err.Locked.SHA256, err.Actual.Version, err.Actual.SHA256)
+}
+
+// ErrEngineProtocolMismatch is returned when the engine plugin implements none of the RPC protocol versions supported
+// by Terragrunt.
+type ErrEngineProtocolMismatch struct {
+	EngineVersion  string
+	RuntimeVersion string
+}
+
+func (err ErrEngineProtocolMismatch) Error() string {
+	return fmt.Sprintf("engine plugin implements RPC protocol version %s, but Terragrunt supports version %s: use an engine release compatible with this version of Terragrunt", err.EngineVersion, err.RuntimeVersion)
+}
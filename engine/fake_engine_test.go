@@ -0,0 +1,110 @@
+package engine
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt-engine-go/engine"
+	"github.com/gruntwork-io/terragrunt-engine-go/proto"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// fakeEngineEnv is set in the environment of the test binary when it is started as the fake engine plugin.
+	fakeEngineEnv = "TG_TEST_FAKE_ENGINE"
+
+	// The env vars of the requests that control the fake engine.
+	fakeEngineStdoutEnv   = "FAKE_ENGINE_STDOUT"
+	fakeEngineExitCodeEnv = "FAKE_ENGINE_EXIT_CODE"
+	fakeEngineHangEnv     = "FAKE_ENGINE_HANG"
+)
+
+// TestMain serves the fake engine plugin if the test binary is started as one, the engine plugins started by the tests
+// are the test binary itself.
+func TestMain(m *testing.M) {
+	if os.Getenv(fakeEngineEnv) != "" {
+		serveFakeEngine()
+		return
+	}
+
+	os.Setenv(fakeEngineEnv, "true") //nolint:errcheck
+
+	os.Exit(m.Run())
+}
+
+// fakeEngine is an engine that writes the command and the args it is asked to run to stdout, unless `FAKE_ENGINE_STDOUT`
+// is set, and exits with the `FAKE_ENGINE_EXIT_CODE` code. If `FAKE_ENGINE_HANG` is set, it never answers the run
+// and shutdown requests.
+type fakeEngine struct {
+	proto.UnimplementedEngineServer
+}
+
+func serveFakeEngine() {
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: plugin.HandshakeConfig{
+			ProtocolVersion:  EngineMaxProtocolVersion,
+			MagicCookieKey:   engineCookieKey,
+			MagicCookieValue: engineCookieValue,
+		},
+		Plugins: plugin.PluginSet{
+			"plugin": &engine.TerragruntGRPCEngine{Impl: &fakeEngine{}},
+		},
+		GRPCServer: plugin.DefaultGRPCServer,
+	})
+}
+
+func (*fakeEngine) Init(*proto.InitRequest, proto.Engine_InitServer) error {
+	return nil
+}
+
+func (*fakeEngine) Run(req *proto.RunRequest, stream proto.Engine_RunServer) error {
+	if _, ok := req.GetEnvVars()[fakeEngineHangEnv]; ok {
+		select {}
+	}
+
+	stdout, ok := req.GetEnvVars()[fakeEngineStdoutEnv]
+	if !ok {
+		stdout = strings.Join(append([]string{req.GetCommand()}, req.GetArgs()...), " ") + "\n"
+	}
+
+	exitCode, _ := strconv.Atoi(req.GetEnvVars()[fakeEngineExitCodeEnv]) //nolint:errcheck
+
+	return stream.Send(&proto.RunResponse{Stdout: stdout, ResultCode: int32(exitCode)})
+}
+
+func (*fakeEngine) Shutdown(req *proto.ShutdownRequest, stream proto.Engine_ShutdownServer) error {
+	if _, ok := req.GetEnvVars()[fakeEngineHangEnv]; ok {
+		select {}
+	}
+
+	return nil
+}
+
+// newFakeEngineOptions returns the options that run the fake engine, which is shut down at the end of the test.
+func newFakeEngineOptions(ctx context.Context, t *testing.T) *options.TerragruntOptions {
+	t.Helper()
+
+	enginePath, err := os.Executable()
+	require.NoError(t, err)
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	skipChecksumCheck := true
+
+	opts.WorkingDir = t.TempDir()
+	opts.Engine = &options.EngineOptions{Source: enginePath, Type: "rpc", SkipChecksumCheck: &skipChecksumCheck}
+	opts.EngineEnabled = true
+	opts.EngineRestartAttempts = 0
+
+	t.Cleanup(func() {
+		Shutdown(ctx, opts) //nolint:errcheck
+	})
+
+	return opts
+}
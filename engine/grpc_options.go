@@ -0,0 +1,50 @@
+package engine
+
+import (
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+)
+
+// PluginGRPCOptions configures the gRPC connection to the engine plugin. Zero values keep the gRPC defaults.
+type PluginGRPCOptions struct {
+	// MaxRecvMsgSizeBytes is the maximum size of a message Terragrunt accepts from the engine.
+	MaxRecvMsgSizeBytes int
+	// MaxSendMsgSizeBytes is the maximum size of a message Terragrunt sends to the engine.
+	MaxSendMsgSizeBytes int
+	// KeepAliveTime is the interval of inactivity after which the connection to the engine is pinged.
+	KeepAliveTime time.Duration
+}
+
+// DialOptions returns the gRPC dial options used to connect to the engine plugin.
+func (grpcOpts *PluginGRPCOptions) DialOptions() []grpc.DialOption {
+	dialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}
+
+	if grpcOpts == nil {
+		return dialOpts
+	}
+
+	var callOpts []grpc.CallOption
+
+	if grpcOpts.MaxRecvMsgSizeBytes > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(grpcOpts.MaxRecvMsgSizeBytes))
+	}
+
+	if grpcOpts.MaxSendMsgSizeBytes > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(grpcOpts.MaxSendMsgSizeBytes))
+	}
+
+	if len(callOpts) > 0 {
+		dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(callOpts...))
+	}
+
+	if grpcOpts.KeepAliveTime > 0 {
+		dialOpts = append(dialOpts, grpc.WithKeepaliveParams(keepalive.ClientParameters{Time: grpcOpts.KeepAliveTime}))
+	}
+
+	return dialOpts
+}
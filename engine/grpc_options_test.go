@@ -0,0 +1,54 @@
+package engine_test
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestPluginGRPCOptionsMaxMessageSize(t *testing.T) {
+	t.Parallel()
+
+	const bufSize = 1024 * 1024
+
+	listener := bufconn.Listen(bufSize)
+
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, health.NewServer())
+
+	go server.Serve(listener) //nolint:errcheck
+	defer server.Stop()
+
+	grpcOptions := &engine.PluginGRPCOptions{
+		MaxRecvMsgSizeBytes: 64,
+		MaxSendMsgSizeBytes: 64,
+	}
+
+	dialOptions := append(grpcOptions.DialOptions(), grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	}))
+
+	conn, err := grpc.NewClient("passthrough:///bufnet", dialOptions...)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := healthpb.NewHealthClient(conn)
+
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: strings.Repeat("a", 1024)})
+	require.Error(t, err)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
@@ -0,0 +1,54 @@
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/gruntwork-io/go-commons/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+// LocalEngineConfig is an engine built locally, whose binary is referenced by its absolute path in the `source` of
+// the engine block instead of being downloaded.
+type LocalEngineConfig struct {
+	BinaryPath string
+}
+
+// NewLocalEngine returns the local engine with the given binary, after checking that the binary exists and is
+// executable, so that a wrong path fails with a clear error rather than when the plugin process is launched.
+func NewLocalEngine(binaryPath string) (*LocalEngineConfig, error) {
+	absPath, err := filepath.Abs(binaryPath)
+	if err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return nil, errors.WithStackTrace(LocalEngineBinaryError{BinaryPath: absPath, Reason: err.Error()})
+	}
+
+	if info.IsDir() {
+		return nil, errors.WithStackTrace(LocalEngineBinaryError{BinaryPath: absPath, Reason: "it is a directory"})
+	}
+
+	// Windows has no executable bit, the extension decides whether a file can be run.
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0111 == 0 {
+		return nil, errors.WithStackTrace(LocalEngineBinaryError{BinaryPath: absPath, Reason: "it is not executable"})
+	}
+
+	return &LocalEngineConfig{BinaryPath: absPath}, nil
+}
+
+// Source returns the value of the `source` attribute of the engine block that uses the local engine.
+func (engine *LocalEngineConfig) Source() string {
+	return engine.BinaryPath
+}
+
+// EngineOptions returns the options of the local engine.
+func (engine *LocalEngineConfig) EngineOptions() *options.EngineOptions {
+	return &options.EngineOptions{
+		Source: engine.Source(),
+		Type:   "rpc",
+	}
+}
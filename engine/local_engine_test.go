@@ -0,0 +1,43 @@
+package engine_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLocalEngine(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	binaryPath := filepath.Join(dir, "terragrunt-iac-engine-opentofu")
+
+	var binaryErr engine.LocalEngineBinaryError
+
+	_, err := engine.NewLocalEngine(binaryPath)
+	require.ErrorAs(t, err, &binaryErr)
+
+	_, err = engine.NewLocalEngine(dir)
+	require.ErrorAs(t, err, &binaryErr)
+	assert.Equal(t, "it is a directory", binaryErr.Reason)
+
+	require.NoError(t, os.WriteFile(binaryPath, []byte("engine"), 0644))
+
+	if runtime.GOOS != "windows" {
+		_, err = engine.NewLocalEngine(binaryPath)
+		require.ErrorAs(t, err, &binaryErr)
+		assert.Equal(t, "it is not executable", binaryErr.Reason)
+
+		require.NoError(t, os.Chmod(binaryPath, 0755))
+	}
+
+	localEngine, err := engine.NewLocalEngine(binaryPath)
+	require.NoError(t, err)
+	assert.Equal(t, binaryPath, localEngine.Source())
+	assert.Equal(t, binaryPath, localEngine.EngineOptions().Source)
+}
@@ -0,0 +1,237 @@
+package engine
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gruntwork-io/go-commons/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// EngineLockFileName is the name of the file, next to `terragrunt.hcl`, that pins the engines used by the module, in
+// the same way `.terraform.lock.hcl` pins the providers.
+const EngineLockFileName = ".terragrunt-engine.lock"
+
+type engineLockUpdateKey byte
+
+// engineLockUpdateContextKey is set by `UpdateEngineLock`, only then the engine is recorded in the lock file.
+const engineLockUpdateContextKey engineLockUpdateKey = 0
+
+// engineChecksums caches the SHA-256 hashes of the engine binaries by path, so that a binary shared by the modules of
+// a run is hashed once. The binary is hashed again if its size or its modification time change.
+var engineChecksums sync.Map
+
+type engineChecksum struct {
+	size     int64
+	modTime  time.Time
+	checksum []byte
+}
+
+// EngineLock is the content of the engine lock file.
+type EngineLock struct {
+	Engines []*LockedEngine `json:"engines"`
+}
+
+// LockedEngine is the engine binary recorded in the lock file by `UpdateEngineLock`.
+type LockedEngine struct {
+	Source   string `json:"source"`
+	Version  string `json:"version"`
+	SHA256   string `json:"sha256"`
+	Platform string `json:"platform"`
+}
+
+// ReadEngineLock reads the lock file with the given path. An empty lock is returned if the file doesn't exist.
+func ReadEngineLock(path string) (*EngineLock, error) {
+	lock := &EngineLock{}
+
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return lock, nil
+	}
+
+	if err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	if err := json.Unmarshal(content, lock); err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	return lock, nil
+}
+
+// Find returns the locked engine with the given source and platform, or nil if there is none.
+func (lock *EngineLock) Find(source, platform string) *LockedEngine {
+	for _, locked := range lock.Engines {
+		if locked.Source == source && locked.Platform == platform {
+			return locked
+		}
+	}
+
+	return nil
+}
+
+// Set adds the given engine to the lock, replacing the one with the same source and platform.
+func (lock *EngineLock) Set(engine *LockedEngine) {
+	lock.Remove(engine.Source, engine.Platform)
+	lock.Engines = append(lock.Engines, engine)
+
+	sort.Slice(lock.Engines, func(i, j int) bool {
+		if lock.Engines[i].Source != lock.Engines[j].Source {
+			return lock.Engines[i].Source < lock.Engines[j].Source
+		}
+
+		return lock.Engines[i].Platform < lock.Engines[j].Platform
+	})
+}
+
+// Remove removes the engine with the given source and platform from the lock.
+func (lock *EngineLock) Remove(source, platform string) {
+	engines := lock.Engines[:0]
+
+	for _, locked := range lock.Engines {
+		if locked.Source != source || locked.Platform != platform {
+			engines = append(engines, locked)
+		}
+	}
+
+	lock.Engines = engines
+}
+
+// WriteFile writes the lock to the file with the given path.
+func (lock *EngineLock) WriteFile(path string) error {
+	content, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return errors.WithStackTrace(err)
+	}
+
+	const ownerWriteGlobalReadPerms = 0644
+
+	if err := os.WriteFile(path, append(content, '\n'), ownerWriteGlobalReadPerms); err != nil {
+		return errors.WithStackTrace(err)
+	}
+
+	return nil
+}
+
+// engineLockFilePath returns the path of the lock file of the module the given options run.
+func engineLockFilePath(opts *options.TerragruntOptions) string {
+	return filepath.Join(filepath.Dir(opts.TerragruntConfigPath), EngineLockFileName)
+}
+
+// lockedEngineVersion returns the version of the engine pinned in the lock file, or an empty string if it is not locked.
+func lockedEngineVersion(opts *options.TerragruntOptions, platform Platform) (string, error) {
+	lock, err := ReadEngineLock(engineLockFilePath(opts))
+	if err != nil {
+		return "", err
+	}
+
+	if locked := lock.Find(opts.Engine.Source, platform.String()); locked != nil {
+		return locked.Version, nil
+	}
+
+	return "", nil
+}
+
+// engineFileChecksum returns the SHA-256 hash of the engine binary, hashing it only if it changed since it was last
+// hashed.
+func engineFileChecksum(engineFile string) ([]byte, error) {
+	info, err := os.Stat(engineFile)
+	if err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	if cached, ok := engineChecksums.Load(engineFile); ok {
+		if cached := cached.(engineChecksum); cached.size == info.Size() && cached.modTime.Equal(info.ModTime()) {
+			return cached.checksum, nil
+		}
+	}
+
+	checksum, err := util.FileSHA256(engineFile)
+	if err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	engineChecksums.Store(engineFile, engineChecksum{size: info.Size(), modTime: info.ModTime(), checksum: checksum})
+
+	return checksum, nil
+}
+
+// checkEngineLock compares the version and the SHA-256 hash of the engine binary with the lock file. The given
+// checksum is the hash computed when the engine was downloaded, the binary is hashed if it is nil. The engine is only
+// added to the lock file by `UpdateEngineLock`, other runs warn that it is not locked.
+func checkEngineLock(ctx context.Context, opts *options.TerragruntOptions, platform Platform, engineFile string, checksum []byte) error {
+	if checksum == nil {
+		var err error
+
+		if checksum, err = engineFileChecksum(engineFile); err != nil {
+			return err
+		}
+	}
+
+	engine := &LockedEngine{
+		Source:   opts.Engine.Source,
+		Version:  opts.Engine.Version,
+		SHA256:   hex.EncodeToString(checksum),
+		Platform: platform.String(),
+	}
+
+	path := engineLockFilePath(opts)
+
+	lock, err := ReadEngineLock(path)
+	if err != nil {
+		return err
+	}
+
+	if locked := lock.Find(engine.Source, engine.Platform); locked != nil {
+		if locked.Version != engine.Version || locked.SHA256 != engine.SHA256 {
+			return errors.WithStackTrace(EngineLockMismatchError{Path: path, Locked: *locked, Actual: *engine})
+		}
+
+		return nil
+	}
+
+	if update, _ := ctx.Value(engineLockUpdateContextKey).(bool); !update {
+		opts.Logger.Warnf("Engine %s %s is not locked in %s, run `terragrunt engine update-lock` to lock it", engine.Source, engine.Version, path)
+		return nil
+	}
+
+	opts.Logger.Infof("Recording engine %s %s in %s", engine.Source, engine.Version, path)
+
+	lock.Set(engine)
+
+	return lock.WriteFile(path)
+}
+
+// UpdateEngineLock removes the engine of the given options from the lock file and downloads it again, so that the
+// resolved version is recorded in the lock file. Unless the version is set in the options, the latest release is used.
+func UpdateEngineLock(ctx context.Context, opts *options.TerragruntOptions) error {
+	platform, err := enginePlatform(opts)
+	if err != nil {
+		return errors.WithStackTrace(err)
+	}
+
+	path := engineLockFilePath(opts)
+
+	lock, err := ReadEngineLock(path)
+	if err != nil {
+		return err
+	}
+
+	if lock.Find(opts.Engine.Source, platform.String()) != nil {
+		lock.Remove(opts.Engine.Source, platform.String())
+
+		if err := lock.WriteFile(path); err != nil {
+			return err
+		}
+	}
+
+	return DownloadEngine(context.WithValue(ctx, engineLockUpdateContextKey, true), opts)
+}
@@ -0,0 +1,73 @@
+package engine_test
+
+import (
+	"context"
+	goErrors "errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloadEngineLock(t *testing.T) {
+	cacheDir := t.TempDir()
+	t.Setenv(engine.EngineCachePathEnv, cacheDir)
+
+	const (
+		source  = "github.com/gruntwork-io/terragrunt-engine-opentofu"
+		version = "v0.0.1"
+	)
+
+	// the engine binary is already cached, so nothing is downloaded
+	engineFile := filepath.Join(cacheDir, engine.EngineCacheDir, "rpc", version, "linux", "amd64", "terragrunt-iac-engine-opentofu_rpc_v0.0.1_linux_amd64")
+	require.NoError(t, os.MkdirAll(filepath.Dir(engineFile), os.ModePerm))
+	require.NoError(t, os.WriteFile(engineFile, []byte("engine"), 0755))
+
+	moduleDir := t.TempDir()
+
+	newOptions := func(version string) *options.TerragruntOptions {
+		opts, err := options.NewTerragruntOptionsForTest(filepath.Join(moduleDir, "terragrunt.hcl"))
+		require.NoError(t, err)
+
+		opts.EngineEnabled = true
+		opts.EnginePlatform = "linux/amd64"
+		opts.Engine = &options.EngineOptions{Source: source, Version: version, Type: "rpc"}
+
+		return opts
+	}
+
+	ctx := engine.WithEngineValues(context.Background())
+
+	// the engine is only recorded in the lock file by `update-lock`
+	require.NoError(t, engine.DownloadEngine(ctx, newOptions(version)))
+	assert.NoFileExists(t, filepath.Join(moduleDir, engine.EngineLockFileName))
+
+	require.NoError(t, engine.UpdateEngineLock(ctx, newOptions(version)))
+
+	lock, err := engine.ReadEngineLock(filepath.Join(moduleDir, engine.EngineLockFileName))
+	require.NoError(t, err)
+
+	locked := lock.Find(source, "linux/amd64")
+	require.NotNil(t, locked)
+	assert.Equal(t, version, locked.Version)
+	// SHA-256 hash of "engine"
+	assert.Equal(t, "ed9f6f25068608efd412958da4dfc19328ca3511251fa6d5f9c42baf230e32f8", locked.SHA256)
+
+	// the version is pinned by the lock file if it is not set
+	opts := newOptions("")
+	require.NoError(t, engine.DownloadEngine(ctx, opts))
+	assert.Equal(t, version, opts.Engine.Version)
+
+	// a different binary doesn't match the lock file
+	require.NoError(t, os.WriteFile(engineFile, []byte("another engine"), 0755))
+
+	err = engine.DownloadEngine(ctx, newOptions(version))
+
+	var mismatchErr engine.EngineLockMismatchError
+	require.True(t, goErrors.As(err, &mismatchErr))
+	assert.Equal(t, locked.SHA256, mismatchErr.Locked.SHA256)
+}
@@ -0,0 +1,133 @@
+package engine
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/gruntwork-io/go-commons/errors"
+)
+
+const (
+	s3Scheme  = "s3"
+	gcsScheme = "gs"
+
+	// defaultS3Region is the region used to look up the region of the bucket.
+	defaultS3Region = "us-east-1"
+)
+
+// isObjectStorageSource returns true if the engine source is an `s3://` or `gs://` bucket prefix, which holds the
+// engine releases in the same layout as GitHub releases: `<prefix>/<version>/<asset>`.
+func isObjectStorageSource(source string) bool {
+	return strings.HasPrefix(source, s3Scheme+"://") || strings.HasPrefix(source, gcsScheme+"://")
+}
+
+// DownloadFromS3 downloads the object with the given key from the S3 bucket into destDir and returns the path of the
+// downloaded file. The credentials are taken from the environment, the same way as the AWS CLI does.
+func DownloadFromS3(ctx context.Context, bucket, key, destDir string) (string, error) {
+	sess, err := session.NewSessionWithOptions(session.Options{SharedConfigState: session.SharedConfigEnable})
+	if err != nil {
+		return "", errors.WithStackTrace(err)
+	}
+
+	region, err := s3manager.GetBucketRegion(ctx, sess, bucket, defaultS3Region)
+	if err != nil {
+		return "", errors.WithStackTrace(err)
+	}
+
+	downloader := s3manager.NewDownloader(sess.Copy(&aws.Config{Region: aws.String(region)}))
+
+	return downloadObject(destDir, key, func(file *os.File) error {
+		_, err := downloader.DownloadWithContext(ctx, file, &s3.GetObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		})
+
+		return err
+	})
+}
+
+// DownloadFromGCS downloads the object from the GCS bucket into destDir and returns the path of the downloaded file.
+// The credentials are taken from the environment with the Application Default Credentials.
+func DownloadFromGCS(ctx context.Context, bucket, object, destDir string) (string, error) {
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return "", errors.WithStackTrace(err)
+	}
+	defer client.Close() //nolint:errcheck
+
+	return downloadObject(destDir, object, func(file *os.File) error {
+		reader, err := client.Bucket(bucket).Object(object).NewReader(ctx)
+		if err != nil {
+			return err
+		}
+		defer reader.Close() //nolint:errcheck
+
+		_, err = io.Copy(file, reader)
+
+		return err
+	})
+}
+
+// downloadFromObjectStorage downloads the object at the given `s3://` or `gs://` URL to the given file path.
+func downloadFromObjectStorage(ctx context.Context, objectURL, dest string) error {
+	parsedURL, err := url.Parse(objectURL)
+	if err != nil {
+		return errors.WithStackTrace(err)
+	}
+
+	var (
+		bucket = parsedURL.Host
+		key    = strings.TrimPrefix(parsedURL.Path, "/")
+	)
+
+	if path.Base(key) != filepath.Base(dest) {
+		return errors.Errorf("object %s does not match the file name of %s", objectURL, dest)
+	}
+
+	switch parsedURL.Scheme {
+	case s3Scheme:
+		_, err = DownloadFromS3(ctx, bucket, key, filepath.Dir(dest))
+	case gcsScheme:
+		_, err = DownloadFromGCS(ctx, bucket, key, filepath.Dir(dest))
+	default:
+		err = errors.Errorf("unsupported object storage URL %s", objectURL)
+	}
+
+	return err
+}
+
+// downloadObject creates the file named after the object in destDir and fills it with the given function. The file
+// is removed if the download fails.
+func downloadObject(destDir, object string, download func(file *os.File) error) (string, error) {
+	dest := filepath.Join(destDir, path.Base(object))
+
+	file, err := os.Create(dest)
+	if err != nil {
+		return "", errors.WithStackTrace(err)
+	}
+
+	if err := download(file); err != nil {
+		file.Close()    //nolint:errcheck
+		os.Remove(dest) //nolint:errcheck
+
+		return "", errors.WithStackTrace(err)
+	}
+
+	if err := file.Close(); err != nil {
+		os.Remove(dest) //nolint:errcheck
+
+		return "", errors.WithStackTrace(err)
+	}
+
+	return dest, nil
+}
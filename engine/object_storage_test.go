@@ -0,0 +1,35 @@
+package engine_test
+
+import (
+	"context"
+	goErrors "errors"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloadEngineObjectStorageRequiresVersion(t *testing.T) {
+	t.Parallel()
+
+	testCases := []string{
+		"s3://my-bucket/engines/terragrunt-iac-engine-opentofu",
+		"gs://my-bucket/engines/terragrunt-iac-engine-opentofu",
+	}
+
+	for _, source := range testCases {
+		opts, err := options.NewTerragruntOptionsForTest("")
+		require.NoError(t, err)
+
+		opts.Engine = &options.EngineOptions{Source: source, Type: "rpc"}
+		opts.EngineEnabled = true
+
+		err = engine.DownloadEngine(engine.WithEngineValues(context.Background()), opts)
+
+		var versionErr engine.EngineVersionRequiredError
+		require.True(t, goErrors.As(err, &versionErr), source)
+		assert.Equal(t, source, versionErr.Source)
+	}
+}
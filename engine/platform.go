@@ -0,0 +1,59 @@
+package engine
+
+import (
+	"runtime"
+	"strings"
+
+	"github.com/gruntwork-io/go-commons/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+// SupportedPlatforms lists the `os/arch` pairs engine binaries are published for.
+var SupportedPlatforms = []string{
+	"darwin/amd64",
+	"darwin/arm64",
+	"freebsd/386",
+	"freebsd/amd64",
+	"linux/386",
+	"linux/amd64",
+	"linux/arm64",
+	"windows/386",
+	"windows/amd64",
+}
+
+// Platform is the operating system and architecture an engine binary is built for.
+type Platform struct {
+	OS   string
+	Arch string
+}
+
+// CurrentPlatform returns the platform Terragrunt is running on.
+func CurrentPlatform() Platform {
+	return Platform{OS: runtime.GOOS, Arch: runtime.GOARCH}
+}
+
+// ParsePlatform parses an `os/arch` string, such as `linux/amd64`, and checks that it is one of SupportedPlatforms.
+func ParsePlatform(str string) (Platform, error) {
+	for _, supported := range SupportedPlatforms {
+		if str == supported {
+			parts := strings.SplitN(str, "/", 2) //nolint:mnd
+
+			return Platform{OS: parts[0], Arch: parts[1]}, nil
+		}
+	}
+
+	return Platform{}, errors.WithStackTrace(UnsupportedEnginePlatformError(str))
+}
+
+func (platform Platform) String() string {
+	return platform.OS + "/" + platform.Arch
+}
+
+// enginePlatform returns the platform set with --terragrunt-engine-platform, or the current platform if not set.
+func enginePlatform(opts *options.TerragruntOptions) (Platform, error) {
+	if opts.EnginePlatform == "" {
+		return CurrentPlatform(), nil
+	}
+
+	return ParsePlatform(opts.EnginePlatform)
+}
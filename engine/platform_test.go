@@ -0,0 +1,49 @@
+package engine_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePlatform(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		value       string
+		expected    engine.Platform
+		expectedErr bool
+	}{
+		{"linux/amd64", engine.Platform{OS: "linux", Arch: "amd64"}, false},
+		{"darwin/arm64", engine.Platform{OS: "darwin", Arch: "arm64"}, false},
+		{"windows/amd64", engine.Platform{OS: "windows", Arch: "amd64"}, false},
+		{"linux", engine.Platform{}, true},
+		{"linux/amd64/v2", engine.Platform{}, true},
+		{"plan9/amd64", engine.Platform{}, true},
+		{"", engine.Platform{}, true},
+	}
+
+	for i, tt := range tc {
+		tt := tt
+
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			platform, err := engine.ParsePlatform(tt.value)
+			if tt.expectedErr {
+				var platformErr engine.UnsupportedEnginePlatformError
+				require.ErrorAs(t, err, &platformErr)
+				assert.Contains(t, err.Error(), "linux/amd64")
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, platform)
+			assert.Equal(t, tt.value, platform.String())
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/gruntwork-io/go-commons/errors"
+	"github.com/gruntwork-io/terragrunt/awshelper"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+// PreflightCheck validates that the preconditions of an engine run are met, e.g. credentials or free disk space.
+type PreflightCheck func(ctx context.Context) error
+
+// NewDiskSpaceCheck returns a check that fails if the filesystem of the current directory has less than
+// `minFreeBytes` bytes available.
+func NewDiskSpaceCheck(minFreeBytes int64) PreflightCheck {
+	return func(ctx context.Context) error {
+		const path = "."
+
+		free, err := freeDiskSpace(path)
+		if err != nil {
+			return errors.WithStackTrace(err)
+		}
+
+		if free < uint64(minFreeBytes) {
+			return errors.WithStackTrace(InsufficientDiskSpaceError{Path: path, FreeBytes: free, MinFreeBytes: minFreeBytes})
+		}
+
+		return nil
+	}
+}
+
+// NewAWSCredentialsCheck returns a check that fails if the AWS credentials available to Terragrunt are not valid.
+// The options are needed to resolve the credentials the same way the rest of Terragrunt does.
+func NewAWSCredentialsCheck(opts *options.TerragruntOptions) PreflightCheck {
+	return func(ctx context.Context) error {
+		return awshelper.ValidateAwsSession(nil, opts)
+	}
+}
+
+// runPreflightCheck runs the preflight check of the execution options, if any.
+func runPreflightCheck(ctx context.Context, runOptions *ExecutionOptions) error {
+	if runOptions.PreflightCheck == nil {
+		return nil
+	}
+
+	if err := runOptions.PreflightCheck(ctx); err != nil {
+		return errors.WithStackTrace(PreflightCheckError{WorkingDir: runOptions.TerragruntOptions.WorkingDir, Err: err})
+	}
+
+	return nil
+}
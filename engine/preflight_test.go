@@ -0,0 +1,59 @@
+package engine_test
+
+import (
+	"context"
+	goErrors "errors"
+	"math"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunPreflightCheckFailure(t *testing.T) {
+	t.Parallel()
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	opts.WorkingDir = t.TempDir()
+	opts.Engine = &options.EngineOptions{Source: "/does/not/exist/terragrunt-iac-engine"}
+	opts.EngineEnabled = true
+
+	errNotReady := goErrors.New("not ready")
+	calls := 0
+
+	ctx := engine.WithEngineValues(context.Background())
+
+	_, err = engine.Run(ctx, &engine.ExecutionOptions{
+		TerragruntOptions: opts,
+		WorkingDir:        opts.WorkingDir,
+		Command:           "tofu",
+		Args:              []string{"plan"},
+		PreflightCheck: func(ctx context.Context) error {
+			calls++
+			return errNotReady
+		},
+	})
+	require.Error(t, err)
+
+	// the engine is neither downloaded nor started, the error comes from the preflight check.
+	var preflightErr engine.PreflightCheckError
+	require.True(t, goErrors.As(err, &preflightErr))
+	require.ErrorIs(t, err, errNotReady)
+	assert.Equal(t, 1, calls)
+}
+
+func TestNewDiskSpaceCheck(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, engine.NewDiskSpaceCheck(0)(context.Background()))
+
+	err := engine.NewDiskSpaceCheck(math.MaxInt64)(context.Background())
+	require.Error(t, err)
+
+	var diskSpaceErr engine.InsufficientDiskSpaceError
+	assert.True(t, goErrors.As(err, &diskSpaceErr))
+}
@@ -0,0 +1,20 @@
+//go:build !windows
+// +build !windows
+
+package engine
+
+import (
+	"syscall"
+
+	"github.com/gruntwork-io/go-commons/errors"
+)
+
+func freeDiskSpace(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, errors.WithStackTrace(err)
+	}
+
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil //nolint:unconvert
+}
@@ -0,0 +1,24 @@
+//go:build windows
+// +build windows
+
+package engine
+
+import (
+	"github.com/gruntwork-io/go-commons/errors"
+	"golang.org/x/sys/windows"
+)
+
+func freeDiskSpace(path string) (uint64, error) {
+	pathPtr, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, errors.WithStackTrace(err)
+	}
+
+	var freeBytes uint64
+
+	if err := windows.GetDiskFreeSpaceEx(pathPtr, &freeBytes, nil, nil); err != nil {
+		return 0, errors.WithStackTrace(err)
+	}
+
+	return freeBytes, nil
+}
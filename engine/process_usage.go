@@ -0,0 +1,22 @@
+package engine
+
+import "time"
+
+// ProcessUsage is the resource usage of the engine plugin process. Since the plugin serves all the runs in a working
+// directory, the values are accumulated since the plugin started.
+type ProcessUsage struct {
+	// MaxRSSBytes is the peak resident set size of the process.
+	MaxRSSBytes   int64
+	UserCPUTime   time.Duration
+	SystemCPUTime time.Duration
+}
+
+// processUsage returns the resource usage of the engine plugin process, or nil if it is not available on the current
+// platform.
+func (instance *engineInstance) processUsage() (*ProcessUsage, error) {
+	if instance.cmd == nil || instance.cmd.Process == nil {
+		return nil, nil
+	}
+
+	return processUsage(instance.cmd)
+}
@@ -0,0 +1,118 @@
+//go:build linux
+// +build linux
+
+package engine
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gruntwork-io/go-commons/errors"
+)
+
+const (
+	// clockTicksPerSecond is USER_HZ, the unit of the CPU times in `/proc/<pid>/stat`, which is 100 on all Linux platforms.
+	clockTicksPerSecond = 100
+
+	// The indexes of `utime` and `stime` in `/proc/<pid>/stat`, counted from the field that follows the command name.
+	procStatUserTimeIndex   = 11
+	procStatSystemTimeIndex = 12
+
+	bytesInKilobyte = 1024
+)
+
+// processUsage returns the resource usage of the exited process from its rusage, or of the running process from `/proc`.
+func processUsage(cmd *exec.Cmd) (*ProcessUsage, error) {
+	if cmd.ProcessState != nil {
+		rusage, ok := cmd.ProcessState.SysUsage().(*syscall.Rusage)
+		if !ok {
+			return nil, nil
+		}
+
+		return &ProcessUsage{
+			MaxRSSBytes:   rusage.Maxrss * bytesInKilobyte,
+			UserCPUTime:   time.Duration(rusage.Utime.Nano()),
+			SystemCPUTime: time.Duration(rusage.Stime.Nano()),
+		}, nil
+	}
+
+	pid := cmd.Process.Pid
+
+	maxRSS, err := procMaxRSS(pid)
+	if err != nil {
+		return nil, err
+	}
+
+	userTime, systemTime, err := procCPUTimes(pid)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProcessUsage{
+		MaxRSSBytes:   maxRSS,
+		UserCPUTime:   userTime,
+		SystemCPUTime: systemTime,
+	}, nil
+}
+
+// procMaxRSS returns the peak resident set size, `VmHWM` in `/proc/<pid>/status`.
+func procMaxRSS(pid int) (int64, error) {
+	content, err := os.ReadFile(fmt.Sprintf("/proc/%d/status", pid))
+	if err != nil {
+		return 0, errors.WithStackTrace(err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		// VmHWM:	   12345 kB
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "VmHWM:" {
+			continue
+		}
+
+		kilobytes, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return 0, errors.WithStackTrace(err)
+		}
+
+		return kilobytes * bytesInKilobyte, nil
+	}
+
+	return 0, errors.WithStackTrace(scanner.Err())
+}
+
+// procCPUTimes returns the user and system CPU times in `/proc/<pid>/stat`.
+func procCPUTimes(pid int) (time.Duration, time.Duration, error) {
+	content, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return 0, 0, errors.WithStackTrace(err)
+	}
+
+	// the command name in parentheses may contain spaces, the fields are counted after it
+	stat := string(content)
+	fields := strings.Fields(stat[strings.LastIndexByte(stat, ')')+1:])
+
+	if len(fields) <= procStatSystemTimeIndex {
+		return 0, 0, errors.WithStackTrace(fmt.Errorf("unexpected format of /proc/%d/stat", pid))
+	}
+
+	var times [2]time.Duration
+
+	for i, index := range []int{procStatUserTimeIndex, procStatSystemTimeIndex} {
+		ticks, err := strconv.ParseInt(fields[index], 10, 64)
+		if err != nil {
+			return 0, 0, errors.WithStackTrace(err)
+		}
+
+		times[i] = time.Duration(ticks) * time.Second / clockTicksPerSecond
+	}
+
+	return times[0], times[1], nil
+}
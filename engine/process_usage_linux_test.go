@@ -0,0 +1,34 @@
+//go:build linux
+// +build linux
+
+package engine
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessUsage(t *testing.T) {
+	t.Parallel()
+
+	cmd := exec.Command("sleep", "5")
+	require.NoError(t, cmd.Start())
+
+	// running process, read from /proc
+	usage, err := processUsage(cmd)
+	require.NoError(t, err)
+	require.NotNil(t, usage)
+	assert.Positive(t, usage.MaxRSSBytes)
+
+	require.NoError(t, cmd.Process.Kill())
+	_ = cmd.Wait()
+
+	// exited process, read from rusage
+	usage, err = processUsage(cmd)
+	require.NoError(t, err)
+	require.NotNil(t, usage)
+	assert.Positive(t, usage.MaxRSSBytes)
+}
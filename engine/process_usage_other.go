@@ -0,0 +1,11 @@
+//go:build !linux
+// +build !linux
+
+package engine
+
+import "os/exec"
+
+// processUsage is not supported on this platform.
+func processUsage(_ *exec.Cmd) (*ProcessUsage, error) {
+	return nil, nil
+}
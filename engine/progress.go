@@ -0,0 +1,149 @@
+package engine
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	// UnknownProgress is the percentage of the progress events of engines that do not report the completion of the run.
+	UnknownProgress = -1
+
+	// CompletedProgress is the percentage of the last progress event of a run.
+	CompletedProgress = 100
+
+	// maxSpinnerMessageLength is the number of characters of the message shown next to the spinner.
+	maxSpinnerMessageLength = 60
+)
+
+var (
+	spinnerFrames = []string{"|", "/", "-", "\\"}
+
+	// spinnerWriters are the writers a spinner is rendered on. Only one spinner is rendered on a writer at a time, so
+	// that the spinners of the units run in parallel don't overwrite each other's line.
+	spinnerWriters sync.Map
+
+	planSummaryPattern      = regexp.MustCompile(`Plan: (\d+) to add, (\d+) to change, (\d+) to destroy`)
+	resourceCompletePattern = regexp.MustCompile(`: (Creation|Modifications|Destruction) complete after`)
+)
+
+// ProgressEvent describes the progress of an engine run.
+type ProgressEvent struct {
+	// Percentage is the completion of the run, from 0 to 100, or `UnknownProgress` if it is unknown, e.g. for the
+	// commands other than `apply` and `destroy`, or before their plan is printed.
+	Percentage float64
+	// Message is the last line of output received from the engine.
+	Message   string
+	Timestamp time.Time
+}
+
+// ProgressCallback is called for every message streamed by the engine during a run, and once more with
+// `CompletedProgress` when the stream ends.
+type ProgressCallback func(event ProgressEvent)
+
+// SpinnerProgressCallback returns a callback that renders a spinner, the completion, or else the elapsed time, and the
+// last message of the engine on a single line of the given writer, which should be a terminal. The line is cleared when
+// the run completes. If the spinner of another run is already rendered on the writer, nothing is rendered.
+func SpinnerProgressCallback(writer io.Writer) ProgressCallback {
+	var (
+		frame     int
+		startedAt time.Time
+		rendering bool
+	)
+
+	return func(event ProgressEvent) {
+		if !rendering {
+			if _, loaded := spinnerWriters.LoadOrStore(writer, struct{}{}); loaded {
+				return
+			}
+
+			rendering = true
+		}
+
+		if event.Percentage >= CompletedProgress {
+			fmt.Fprint(writer, "\r\033[K") //nolint:errcheck
+			spinnerWriters.Delete(writer)
+
+			rendering = false
+
+			return
+		}
+
+		if startedAt.IsZero() {
+			startedAt = event.Timestamp
+		}
+
+		status := event.Timestamp.Sub(startedAt).Truncate(time.Second).String()
+		if event.Percentage != UnknownProgress {
+			status = fmt.Sprintf("%.0f%%", event.Percentage)
+		}
+
+		message := event.Message
+		if len(message) > maxSpinnerMessageLength {
+			message = message[:maxSpinnerMessageLength] + "..."
+		}
+
+		fmt.Fprintf(writer, "\r\033[K%s %s %s", spinnerFrames[frame%len(spinnerFrames)], status, message) //nolint:errcheck
+
+		frame++
+	}
+}
+
+// applyProgress computes the completion of an `apply` or a `destroy` from the number of changes of its plan and the
+// number of resources whose change is complete, as printed in its stdout.
+type applyProgress struct {
+	planned   int
+	completed int
+}
+
+// update reads the given stdout chunk and returns the completion of the run, `UnknownProgress` until the plan is
+// printed. `CompletedProgress` is only reached once the run ends.
+func (progress *applyProgress) update(stdout string) float64 {
+	for _, line := range strings.Split(log.RemoveAllASCISeq(stdout), "\n") {
+		if match := planSummaryPattern.FindStringSubmatch(line); match != nil {
+			progress.planned = 0
+
+			for _, count := range match[1:] {
+				n, _ := strconv.Atoi(count) //nolint:errcheck
+				progress.planned += n
+			}
+
+			continue
+		}
+
+		if resourceCompletePattern.MatchString(line) {
+			progress.completed++
+		}
+	}
+
+	if progress.planned == 0 {
+		return UnknownProgress
+	}
+
+	return min(float64(progress.completed*CompletedProgress/progress.planned), CompletedProgress-1)
+}
+
+// progressMessage returns the last non-empty line of the given output chunks.
+func progressMessage(chunks ...string) string {
+	var message string
+
+	for _, chunk := range chunks {
+		lines := strings.Split(chunk, "\n")
+
+		for i := len(lines) - 1; i >= 0; i-- {
+			if line := strings.TrimSpace(lines[i]); line != "" {
+				message = line
+				break
+			}
+		}
+	}
+
+	return message
+}
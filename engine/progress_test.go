@@ -0,0 +1,58 @@
+package engine_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpinnerProgressCallback(t *testing.T) {
+	t.Parallel()
+
+	var (
+		buf       bytes.Buffer
+		callback  = engine.SpinnerProgressCallback(&buf)
+		startedAt = time.Now()
+	)
+
+	callback(engine.ProgressEvent{Percentage: engine.UnknownProgress, Message: "Refreshing state...", Timestamp: startedAt})
+	assert.Equal(t, "\r\033[K| 0s Refreshing state...", buf.String())
+
+	buf.Reset()
+	callback(engine.ProgressEvent{Percentage: engine.UnknownProgress, Message: "Creating...", Timestamp: startedAt.Add(3 * time.Second)})
+	assert.Equal(t, "\r\033[K/ 3s Creating...", buf.String())
+
+	buf.Reset()
+	callback(engine.ProgressEvent{Percentage: 42, Message: "Applying", Timestamp: startedAt.Add(4 * time.Second)})
+	assert.Equal(t, "\r\033[K- 42% Applying", buf.String())
+
+	buf.Reset()
+	callback(engine.ProgressEvent{Percentage: engine.CompletedProgress, Timestamp: startedAt.Add(5 * time.Second)})
+	assert.Equal(t, "\r\033[K", buf.String())
+}
+
+func TestSpinnerProgressCallbackSharedWriter(t *testing.T) {
+	t.Parallel()
+
+	var (
+		buf       bytes.Buffer
+		first     = engine.SpinnerProgressCallback(&buf)
+		second    = engine.SpinnerProgressCallback(&buf)
+		startedAt = time.Now()
+	)
+
+	first(engine.ProgressEvent{Percentage: engine.UnknownProgress, Message: "first", Timestamp: startedAt})
+
+	// only one spinner is rendered on the writer at a time
+	second(engine.ProgressEvent{Percentage: engine.UnknownProgress, Message: "second", Timestamp: startedAt})
+	assert.Equal(t, "\r\033[K| 0s first", buf.String())
+
+	first(engine.ProgressEvent{Percentage: engine.CompletedProgress, Timestamp: startedAt})
+
+	buf.Reset()
+	second(engine.ProgressEvent{Percentage: engine.UnknownProgress, Message: "second", Timestamp: startedAt})
+	assert.Equal(t, "\r\033[K| 0s second", buf.String())
+}
@@ -0,0 +1,54 @@
+package engine
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/gruntwork-io/go-commons/errors"
+	"github.com/gruntwork-io/terragrunt-engine-go/engine"
+	"github.com/hashicorp/go-plugin"
+)
+
+// The range of the RPC protocol versions of the engine plugins supported by this version of Terragrunt. During the
+// go-plugin handshake, the engine plugin picks the highest version of the range that it implements.
+const (
+	EngineMinProtocolVersion = engineVersion
+	EngineMaxProtocolVersion = engineVersion
+)
+
+// incompatibleProtocolVersionReg matches the error of the go-plugin handshake when the engine plugin implements none of
+// the protocol versions supported by Terragrunt, and captures the version of the plugin.
+var incompatibleProtocolVersionReg = regexp.MustCompile(`Incompatible API version with plugin\. Plugin version: (\d+)`)
+
+// engineVersionedPlugins returns the plugins served by the engine for each supported protocol version.
+func engineVersionedPlugins() map[int]plugin.PluginSet {
+	plugins := make(map[int]plugin.PluginSet)
+
+	for version := EngineMinProtocolVersion; version <= EngineMaxProtocolVersion; version++ {
+		plugins[version] = plugin.PluginSet{
+			"plugin": &engine.TerragruntGRPCEngine{},
+		}
+	}
+
+	return plugins
+}
+
+// runtimeProtocolVersions returns the range of the protocol versions supported by Terragrunt, e.g. `1` or `1-2`.
+func runtimeProtocolVersions() string {
+	if EngineMinProtocolVersion == EngineMaxProtocolVersion {
+		return fmt.Sprint(EngineMaxProtocolVersion)
+	}
+
+	return fmt.Sprintf("%d-%d", EngineMinProtocolVersion, EngineMaxProtocolVersion)
+}
+
+// engineHandshakeError returns `ErrEngineProtocolMismatch` if the given error of the go-plugin handshake is caused by
+// incompatible protocol versions, otherwise the error is returned as is.
+func engineHandshakeError(err error) error {
+	match := incompatibleProtocolVersionReg.FindStringSubmatch(err.Error())
+	if match == nil {
+		return errors.WithStackTrace(err)
+	}
+
+	return errors.WithStackTrace(ErrEngineProtocolMismatch{EngineVersion: match[1], RuntimeVersion: runtimeProtocolVersions()})
+}
@@ -0,0 +1,39 @@
+//go:build !windows
+// +build !windows
+
+package engine
+
+import (
+	goErrors "errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateEngineProtocolMismatch(t *testing.T) {
+	t.Parallel()
+
+	const pluginProtocolVersion = EngineMaxProtocolVersion + 1
+
+	// a fake engine plugin that completes the go-plugin handshake with an unsupported protocol version
+	enginePath := filepath.Join(t.TempDir(), "engine")
+	script := "#!/bin/sh\necho '1|" + strconv.Itoa(pluginProtocolVersion) + "|tcp|127.0.0.1:1|grpc'\nexec sleep 10\n"
+	require.NoError(t, os.WriteFile(enginePath, []byte(script), 0755))
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	opts.Engine = &options.EngineOptions{Source: enginePath, Type: "rpc"}
+
+	_, err = createEngine(opts, &PluginGRPCOptions{})
+
+	var mismatchErr ErrEngineProtocolMismatch
+	require.True(t, goErrors.As(err, &mismatchErr), err)
+	assert.Equal(t, strconv.Itoa(pluginProtocolVersion), mismatchErr.EngineVersion)
+	assert.Equal(t, runtimeProtocolVersions(), mismatchErr.RuntimeVersion)
+}
@@ -0,0 +1,159 @@
+package engine
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gruntwork-io/go-commons/errors"
+)
+
+const (
+	commandNameState = "state"
+	commandNamePull  = "pull"
+	commandNamePush  = "push"
+
+	// stdinFileName is the argument of `state push` that reads the state from stdin instead of a file.
+	stdinFileName = "-"
+)
+
+// StateEncryption encrypts state before it leaves the working directory and decrypts it when it comes back.
+type StateEncryption interface {
+	Encrypt(plaintext []byte) ([]byte, error)
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+// AESGCMStateEncryption implements `StateEncryption` with AES-GCM. The random nonce is prepended to the ciphertext.
+type AESGCMStateEncryption struct {
+	aead cipher.AEAD
+}
+
+// NewAESGCMStateEncryption returns AES-GCM state encryption using the given key, which must be 16, 24 or 32 bytes long
+// to select AES-128, AES-192 or AES-256.
+func NewAESGCMStateEncryption(key []byte) (*AESGCMStateEncryption, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	return &AESGCMStateEncryption{aead: aead}, nil
+}
+
+// Encrypt implements `StateEncryption` interface.
+func (enc *AESGCMStateEncryption) Encrypt(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, enc.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	return enc.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Decrypt implements `StateEncryption` interface.
+func (enc *AESGCMStateEncryption) Decrypt(ciphertext []byte) ([]byte, error) {
+	nonceSize := enc.aead.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, errors.WithStackTrace(ErrStateCiphertextTooShort)
+	}
+
+	plaintext, err := enc.aead.Open(nil, ciphertext[:nonceSize], ciphertext[nonceSize:], nil)
+	if err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	return plaintext, nil
+}
+
+// isStatePullCommand returns true if the args run `state pull`, which writes the state to stdout.
+func isStatePullCommand(args []string) bool {
+	positional := positionalArgs(args)
+
+	return len(positional) >= 2 && positional[0] == commandNameState && positional[1] == commandNamePull
+}
+
+// decryptStatePushFile decrypts the state file passed to `state push` into a temporary file in the working directory
+// and points the args to it. The returned function removes the temporary file.
+func decryptStatePushFile(runOptions *ExecutionOptions) (func(), error) {
+	noop := func() {}
+
+	if runOptions.StateEncryption == nil {
+		return noop, nil
+	}
+
+	positional := positionalArgs(runOptions.Args)
+	if len(positional) < 3 || positional[0] != commandNameState || positional[1] != commandNamePush || positional[2] == stdinFileName {
+		return noop, nil
+	}
+
+	stateFile := positional[2]
+	if !filepath.IsAbs(stateFile) {
+		stateFile = filepath.Join(runOptions.WorkingDir, stateFile)
+	}
+
+	ciphertext, err := os.ReadFile(stateFile)
+	if err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	plaintext, err := runOptions.StateEncryption.Decrypt(ciphertext)
+	if err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	tmpFile, err := os.CreateTemp(runOptions.WorkingDir, ".terragrunt-state-*.tfstate")
+	if err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	cleanup := func() {
+		os.Remove(tmpFile.Name()) //nolint:errcheck
+	}
+
+	if _, err := tmpFile.Write(plaintext); err != nil {
+		tmpFile.Close() //nolint:errcheck
+		cleanup()
+
+		return nil, errors.WithStackTrace(err)
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		cleanup()
+
+		return nil, errors.WithStackTrace(err)
+	}
+
+	args := make([]string, len(runOptions.Args))
+	copy(args, runOptions.Args)
+
+	for i, arg := range args {
+		if arg == positional[2] {
+			args[i] = tmpFile.Name()
+			break
+		}
+	}
+
+	runOptions.Args = args
+
+	return cleanup, nil
+}
+
+func positionalArgs(args []string) []string {
+	var positional []string
+
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") || arg == stdinFileName {
+			positional = append(positional, arg)
+		}
+	}
+
+	return positional
+}
@@ -0,0 +1,45 @@
+package engine_test
+
+import (
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testState = `{
+  "version": 4,
+  "terraform_version": "1.8.0",
+  "serial": 3,
+  "lineage": "3f1e3b7a-8c3a-4a4f-9c2f-1f6b2d5e7a90",
+  "outputs": {"name": {"value": "test", "type": "string"}},
+  "resources": []
+}`
+
+func TestAESGCMStateEncryption(t *testing.T) {
+	t.Parallel()
+
+	enc, err := engine.NewAESGCMStateEncryption([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+
+	ciphertext, err := enc.Encrypt([]byte(testState))
+	require.NoError(t, err)
+	assert.NotContains(t, string(ciphertext), "terraform_version")
+
+	plaintext, err := enc.Decrypt(ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, testState, string(plaintext))
+
+	otherEnc, err := engine.NewAESGCMStateEncryption([]byte("fedcba9876543210fedcba9876543210"))
+	require.NoError(t, err)
+
+	_, err = otherEnc.Decrypt(ciphertext)
+	require.Error(t, err)
+
+	_, err = enc.Decrypt([]byte("short"))
+	require.ErrorIs(t, err, engine.ErrStateCiphertextTooShort)
+
+	_, err = engine.NewAESGCMStateEncryption([]byte("invalid key"))
+	require.Error(t, err)
+}
@@ -0,0 +1,44 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunTimeout(t *testing.T) {
+	t.Parallel()
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	opts.WorkingDir = t.TempDir()
+	opts.Engine = &options.EngineOptions{Source: "/does/not/exist/terragrunt-iac-engine"}
+	opts.EngineEnabled = true
+
+	ctx := engine.WithEngineValues(context.Background())
+
+	_, err = engine.Run(ctx, &engine.ExecutionOptions{
+		TerragruntOptions: opts,
+		WorkingDir:        opts.WorkingDir,
+		Command:           "tofu",
+		Args:              []string{"plan"},
+		Timeout:           100 * time.Millisecond,
+		// blocks until the run times out
+		PreflightCheck: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+
+	var timeoutErr engine.EngineTimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, opts.WorkingDir, timeoutErr.WorkingDir)
+	assert.Equal(t, 100*time.Millisecond, timeoutErr.Timeout)
+}
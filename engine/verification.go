@@ -55,7 +55,11 @@ func verifyFile(checkedFile, checksumsFile, signatureFile string) error {
 	}
 
 	if !bytes.Equal(expectedSHA256Sum[:], packageChecksum) {
-		return errors.Errorf("checksum list has unexpected SHA-256 hash %x (expected %x)", packageChecksum, expectedSHA256Sum)
+		return errors.WithStackTrace(ErrEngineBinaryChecksum{
+			BinaryPath: checkedFile,
+			Expected:   hex.EncodeToString(expectedSHA256Sum[:]),
+			Actual:     hex.EncodeToString(packageChecksum),
+		})
 	}
 
 	return nil
@@ -0,0 +1,114 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gruntwork-io/go-commons/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/hashicorp/go-version"
+)
+
+// githubReleasesPerPage is the maximum number of releases returned by one request to the GitHub API.
+const githubReleasesPerPage = 100
+
+// ListAvailableVersions returns the release versions of the engine published in the given source repository, newest
+// first. Only GitHub sources, such as `github.com/gruntwork-io/terragrunt-engine-opentofu`, have versions: their
+// releases are fetched from the GitHub API, the same way the latest version is resolved when none is pinned.
+func ListAvailableVersions(ctx context.Context, opts *options.TerragruntOptions, source string) ([]string, error) {
+	if !strings.HasPrefix(source, defaultEngineRepoRoot) {
+		return nil, errors.WithStackTrace(UnsupportedEngineVersionsSourceError(source))
+	}
+
+	repo := strings.TrimPrefix(source, defaultEngineRepoRoot)
+
+	var tags []string
+
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("https://api.github.com/repos/%s/releases?per_page=%d&page=%d", repo, githubReleasesPerPage, page)
+
+		opts.Logger.Debugf("Fetching engine releases from %s", url)
+
+		pageTags, err := fetchReleaseTags(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, pageTags...)
+
+		if len(pageTags) < githubReleasesPerPage {
+			break
+		}
+	}
+
+	return SortVersionTags(tags), nil
+}
+
+// SortVersionTags returns the semver tags of the given list sorted newest first, the other tags are dropped.
+func SortVersionTags(tags []string) []string {
+	type versionTag struct {
+		version *version.Version
+		tag     string
+	}
+
+	var versions []versionTag
+
+	for _, tag := range tags {
+		if v, err := version.NewSemver(tag); err == nil {
+			versions = append(versions, versionTag{version: v, tag: tag})
+		}
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].version.GreaterThan(versions[j].version)
+	})
+
+	sorted := make([]string, 0, len(versions))
+	for _, v := range versions {
+		sorted = append(sorted, v.tag)
+	}
+
+	return sorted
+}
+
+func fetchReleaseTags(ctx context.Context, url string) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("fetching %s returned %s", url, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	var releases []struct {
+		Tag string `json:"tag_name"`
+	}
+
+	if err := json.Unmarshal(body, &releases); err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	tags := make([]string, 0, len(releases))
+	for _, release := range releases {
+		tags = append(tags, release.Tag)
+	}
+
+	return tags, nil
+}
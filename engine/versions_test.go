@@ -0,0 +1,48 @@
+package engine_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortVersionTags(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		tags     []string
+		expected []string
+	}{
+		{nil, []string{}},
+		{[]string{"v0.0.1", "v0.0.10", "v0.0.2"}, []string{"v0.0.10", "v0.0.2", "v0.0.1"}},
+		{[]string{"v1.0.0-rc1", "v1.0.0", "v0.9.0"}, []string{"v1.0.0", "v1.0.0-rc1", "v0.9.0"}},
+		{[]string{"latest", "v0.1.0", "nightly"}, []string{"v0.1.0"}},
+	}
+
+	for i, tt := range tc {
+		tt := tt
+
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, engine.SortVersionTags(tt.tags))
+		})
+	}
+}
+
+func TestListAvailableVersionsUnsupportedSource(t *testing.T) {
+	t.Parallel()
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	_, err = engine.ListAvailableVersions(context.Background(), opts, "https://example.com/engine.zip")
+
+	var sourceErr engine.UnsupportedEngineVersionsSourceError
+	require.ErrorAs(t, err, &sourceErr)
+}
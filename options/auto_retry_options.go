@@ -26,3 +26,8 @@ var DefaultRetryableErrors = []string{
 	"(?s).*Could not download module.*The requested URL returned error: 429.*",
 	"(?s).*net/http: TLS.*handshake timeout.*",
 }
+
+// DefaultStateLockRetryableErrors is a list of errors returned when the state is locked by another Terraform run.
+var DefaultStateLockRetryableErrors = []string{
+	"(?s).*Error acquiring the state lock.*",
+}
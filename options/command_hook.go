@@ -0,0 +1,22 @@
+package options
+
+import (
+	"context"
+
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// CommandMockFunc runs the given command in place of the shell, see `TerragruntOptions.CommandMocks`.
+type CommandMockFunc func(ctx context.Context, command string, args []string) (*util.CmdOutput, error)
+
+// CommandHook is called around every shell command run by Terragrunt, for programmatic users of the shell package
+// that need e.g. audit logging or metrics. Unlike the hooks of the Terragrunt configuration, it is registered in Go,
+// in `TerragruntOptions.CommandHooks`.
+type CommandHook interface {
+	// Before is called before the command is run.
+	Before(ctx context.Context, command string, args []string)
+
+	// After is called once the command has finished, with its output and error. The output is nil if the command
+	// could not be run.
+	After(ctx context.Context, output *util.CmdOutput, err error)
+}
@@ -0,0 +1,64 @@
+package options
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/gruntwork-io/go-commons/errors"
+)
+
+// CommandSummaryRecord describes a single subprocess run by Terragrunt, written to the file set by --terragrunt-command-summary-file.
+type CommandSummaryRecord struct {
+	Command     string   `json:"command"`
+	Args        []string `json:"args"`
+	WorkingDir  string   `json:"workingDir"`
+	ExitCode    int      `json:"exitCode"`
+	DurationMs  int64    `json:"durationMs"`
+	StdoutBytes int      `json:"stdoutBytes"`
+	StderrBytes int      `json:"stderrBytes"`
+}
+
+// CommandSummary accumulates the records of all the commands run during a session. It is safe for concurrent use and
+// is shared by all the clones of the options.
+type CommandSummary struct {
+	records []CommandSummaryRecord
+	mu      sync.Mutex
+}
+
+// Add appends the record of a finished command.
+func (summary *CommandSummary) Add(record CommandSummaryRecord) {
+	summary.mu.Lock()
+	defer summary.mu.Unlock()
+
+	summary.records = append(summary.records, record)
+}
+
+// Records returns a copy of the records added so far.
+func (summary *CommandSummary) Records() []CommandSummaryRecord {
+	summary.mu.Lock()
+	defer summary.mu.Unlock()
+
+	return append([]CommandSummaryRecord{}, summary.records...)
+}
+
+// WriteFile writes the records to the given path as a JSON array.
+func (summary *CommandSummary) WriteFile(path string) error {
+	content, err := json.MarshalIndent(summary.Records(), "", "  ")
+	if err != nil {
+		return errors.WithStackTrace(err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return errors.WithStackTrace(err)
+	}
+
+	const ownerWriteGlobalReadPerms = 0644
+
+	if err := os.WriteFile(path, content, ownerWriteGlobalReadPerms); err != nil {
+		return errors.WithStackTrace(err)
+	}
+
+	return nil
+}
@@ -8,14 +8,19 @@ import (
 	"io"
 	"math"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime"
+	"strconv"
 	"time"
 
 	"github.com/gruntwork-io/go-commons/errors"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
 	"github.com/gruntwork-io/terragrunt/pkg/log/format"
+	"github.com/gruntwork-io/terragrunt/pkg/log/writer"
 	"github.com/gruntwork-io/terragrunt/util"
 	"github.com/hashicorp/go-version"
+	"golang.org/x/term"
 )
 
 const ContextKey ctxKey = iota
@@ -37,8 +42,29 @@ const (
 
 	DefaultTFDataDir = ".terraform"
 
+	// DefaultRunAllReportFormat is the format of the run-all report unless --terragrunt-run-all-report-format is set.
+	DefaultRunAllReportFormat = "json"
+
+	// DefaultEngineRestartAttempts is the number of times a crashed engine plugin is restarted before giving up.
+	DefaultEngineRestartAttempts = 2
+
+	// DefaultEngineTimeout is the maximum duration of a command run with the engine.
+	DefaultEngineTimeout = 30 * time.Minute
+
+	// DefaultTerminationHandlerTimeout is how long the termination handler may run before the command is terminated anyway.
+	DefaultTerminationHandlerTimeout = 10 * time.Second
+
+	// DefaultGitMaxConcurrency is the number of git subprocesses that can run at the same time.
+	DefaultGitMaxConcurrency = 8
+
 	DefaultIAMAssumeRoleDuration = 3600
 
+	// TerraformPathEnvName is the env var that overrides the default path of the OpenTofu/Terraform binary.
+	TerraformPathEnvName = "TERRAGRUNT_TFPATH"
+
+	// EnableExperimentalEngineEnvName is the env var that enables the experimental engine.
+	EnableExperimentalEngineEnvName = "TG_EXPERIMENTAL_ENGINE"
+
 	minCommandLength = 2
 
 	defaultExcludesFile = ".terragrunt-excludes"
@@ -59,6 +85,23 @@ var (
 		"force-unlock",
 		"state",
 	}
+
+	// DefaultForceForwardStdoutCommands lists the terraform commands whose stdout is always forwarded as is instead
+	// of going through the Terragrunt logger.
+	DefaultForceForwardStdoutCommands = []string{
+		"output",
+		"state",
+		"version",
+		"console",
+	}
+
+	// DefaultForceForwardStdoutFlags lists the terraform flags that make the stdout of any command forwarded as is.
+	DefaultForceForwardStdoutFlags = []string{
+		"-json",
+		"-version",
+		"-help",
+		"-h",
+	}
 )
 
 type ctxKey byte
@@ -117,16 +160,24 @@ type TerragruntOptions struct {
 	// CLI args that are intended for Terraform (i.e. all the CLI args except the --terragrunt ones)
 	TerraformCliArgs []string
 
+	// Extra args, such as `-plugin-dir`, appended to every `init` command Terragrunt runs.
+	TerraformInitArgs []string
+
 	// The working directory in which to run Terraform
 	WorkingDir string
 
+	// If true, the symlinks in the working directory are resolved before it is passed to subprocesses. Enabled by
+	// default on macOS, where e.g. `/var/folders` is a symlink to `/private/var/folders` that confuses git.
+	WorkingDirAbs bool
+
 	// Unlike `WorkingDir`, this path is the same for all dependencies and points to the root working directory specified in the CLI.
 	RootWorkingDir string
 
 	// Basic log entry
 	Logger log.Logger
 
-	// Disable Terragrunt colors
+	// Disable Terragrunt colors and the colors of the OpenTofu/Terraform output logged by Terragrunt. Set by
+	// default if stdout is not a terminal.
 	DisableLogColors bool
 
 	// Output Terragrunt logs in JSON format
@@ -147,12 +198,29 @@ type TerragruntOptions struct {
 	// Wrap Terraform logs in JSON format
 	TerraformLogsToJSON bool
 
+	// If set, the log level used for all TF_LOG output lines integrated into the Terragrunt log. If nil, each line keeps
+	// the level of its TF_LOG record, e.g. `[WARN]`.
+	TFLogLevel *log.Level
+
+	// Path to the file where TF_LOG output lines are written in addition to the Terragrunt log.
+	TFLogFile string
+
+	// If true, `TF_LOG` is set to `DEBUG` or `TRACE` for the Terraform commands when the log level is debug or trace,
+	// unless `TF_LOG` is already set.
+	AutoTFLog bool
+
+	// If true, the remediation hints that match the stderr of a failed command are appended to its error.
+	ErrorHints bool
+
 	// ValidateStrict mode for the validate-inputs command
 	ValidateStrict bool
 
 	// Environment variables at runtime
 	Env map[string]string
 
+	// Environment variables that are set in addition to `Env` only for commands run in the given working directory.
+	EnvOverrides map[string]map[string]string
+
 	// Download Terraform configurations from the specified source location into a temporary folder and run
 	// Terraform in that temporary folder
 	Source string
@@ -164,6 +232,16 @@ type TerragruntOptions struct {
 	// If set to true, delete the contents of the temporary folder before downloading Terraform source code into it
 	SourceUpdate bool
 
+	// If set to true, delete the downloaded source of Git modules pinned to a branch before every run, so that the
+	// latest commit of the branch is used. Sources pinned to a tag or a commit are not affected.
+	SourceRefreshBeforeRun bool
+
+	// If set to true, pre-release tags are ignored when looking up the latest release tag of a module source
+	SourceNoPrerelease bool
+
+	// If set, only the tags that start with this prefix, e.g. `module-name/`, are considered when looking up the latest release tag of a module source.
+	SourceTagPrefix string
+
 	// Download Terraform configurations specified in the Source parameter into this folder
 	DownloadDir string
 
@@ -208,6 +286,9 @@ type TerragruntOptions struct {
 	// RetryableErrors is an array of regular expressions with RE2 syntax (https://github.com/google/re2/wiki/Syntax) that qualify for retrying
 	RetryableErrors []string
 
+	// Maximum number of attempts for `apply` and `plan` commands that fail to acquire the state lock. Values below 2 disable the retries.
+	StateLockRetryMaxAttempts int
+
 	// Path to a file with a list of directories that need  to be excluded when running *-all commands.
 	ExcludesFile string
 
@@ -224,6 +305,10 @@ type TerragruntOptions struct {
 	// If set to true, do not include dependencies when processing IncludeDirs (unless they are in the included dirs)
 	StrictInclude bool
 
+	// If set to true, modules that have no dependency path to any module matched by IncludeDirs are removed from the
+	// graph before execution.
+	ModuleDependencyGraphPrune bool
+
 	// Parallelism limits the number of commands to run concurrently during *-all commands
 	Parallelism int
 
@@ -243,6 +328,10 @@ type TerragruntOptions struct {
 	// in this list.
 	ModulesThatInclude []string
 
+	// When used with `run-all`, restrict the modules in the stack to only those whose path, relative to the working dir,
+	// starts with one of the prefixes in this list.
+	ModulesIncludeByPrefix []string
+
 	// A command that can be used to run Terragrunt with the given options. This is useful for running Terragrunt
 	// multiple times (e.g. when spinning up a stack of Terraform modules). The actual command is normally defined
 	// in the cli package, which depends on almost all other packages, so we declare it here so that other
@@ -318,6 +407,18 @@ type TerragruntOptions struct {
 	// Folder to store JSON representation of output files.
 	JSONOutputFolder string
 
+	// If set to true, plan files saved in OutputFolder are gzip-compressed.
+	GzipPlanOutput bool
+
+	// If set to true, the JSON representation of plan files saved in OutputFolder is saved alongside them.
+	SavePlanJSON bool
+
+	// Path to a file where a report of all module execution results is written after running *-all commands.
+	RunAllReportFile string
+
+	// Format of the run-all report, either "json" or "yaml".
+	RunAllReportFormat string
+
 	// The command and arguments that can be used to fetch authentication configurations.
 	// Terragrunt invokes this command before running tofu/terraform operations for each working directory.
 	AuthProviderCmd string
@@ -325,8 +426,140 @@ type TerragruntOptions struct {
 	// Allows to skip the output of all dependencies. Intended for use with `hclvalidate` command.
 	SkipOutput bool
 
+	// If true, shell commands are logged instead of being executed.
+	DryRun bool
+
+	// Environment variables to load from AWS SSM Parameter Store, in the format `ENV_VAR=/ssm/path`.
+	EnvFromSSM []string
+
+	// Commands run by `shell.RunShellCommandWithOutputAndMutex` that share the same lock key are executed one at a time.
+	LockKey string
+
 	// Options to use engine for running IaC operations.
 	Engine *EngineOptions
+
+	// Whether the experimental engine is enabled, initialized from the `TG_EXPERIMENTAL_ENGINE` env var.
+	EngineEnabled bool
+
+	// The number of times the engine plugin process is restarted when it fails the health check before each run.
+	EngineRestartAttempts int
+
+	// The `os/arch` pair of the engine binary to use instead of the platform Terragrunt is running on.
+	EnginePlatform string
+
+	// The maximum size in bytes of the gRPC messages exchanged with the engine plugin. Zero keeps the gRPC default.
+	EngineGRPCMaxMessageSize int
+
+	// The maximum duration of a command run with the engine, after which the engine plugin is shut down.
+	EngineTimeout time.Duration
+
+	// Metadata passed to the engine plugin, merged over the `engine_meta` block of the config.
+	EngineMeta map[string]string
+
+	// The root directory of the engine cache. Takes precedence over the `TG_ENGINE_CACHE_PATH` env var.
+	EngineCachePath string
+
+	// Path to a file where a JSON line is appended for every shell command Terragrunt runs.
+	CommandAuditLog string
+
+	// Path to a file that is passed as stdin to `terraform console` instead of the terminal.
+	ConsoleInput string
+
+	// Enables the get_working_dir_hash() built-in function.
+	WorkingDirHash bool
+
+	// The terraform commands whose stdout is forwarded as is instead of going through the Terragrunt logger.
+	ForceForwardStdoutCommands []string
+
+	// The terraform flags that make the stdout of any command forwarded as is.
+	ForceForwardStdoutFlags []string
+
+	// If set, a JSON line describing every executed shell command and its result is written to it.
+	AuditTrailWriter io.Writer
+
+	// If true, the stderr of terraform commands is not displayed. It is still captured for error reporting.
+	SuppressStderr bool
+
+	// The number of rows and columns of the pseudo-terminal allocated for interactive commands such as `console`. Zero
+	// values use the size of the current terminal, or 24x80 if stdin is not a terminal.
+	PTYRows uint16
+	PTYCols uint16
+
+	// Path to a file copied into the working dir as `_terragrunt_override.tf` while terraform runs, e.g. to point
+	// providers to local mocks in tests.
+	ProviderOverrideFile string
+
+	// Git credential helper passed as `-c credential.helper=<value>` to git commands that query remote repositories.
+	GitCredentialHelper string
+
+	// If true, the working directory is not unshallowed with `git fetch --unshallow` before querying the tags of a
+	// repository, when it is a shallow clone.
+	GitAllowShallow bool
+
+	// If true, querying the tags of an SSH repository that fails to authenticate is not retried with the HTTPS URL of
+	// the repository.
+	GitNoSSHFallback bool
+
+	// If set, a command still running when the context is cancelled is terminated, and this handler is called just
+	// before, e.g. to upload partial state or send a notification.
+	TerminationHandler func(cmd *exec.Cmd) error
+
+	// Hooks called before and after every shell command run by Terragrunt.
+	CommandHooks []CommandHook
+
+	// The mocks that replace the shell commands in tests, by command name or base name of the command path. The other
+	// commands are run as usual.
+	CommandMocks map[string]CommandMockFunc
+
+	// The maximum time the termination handler may run before the command is terminated anyway.
+	TerminationHandlerTimeout time.Duration
+
+	// If true, auto-init fails unless the module declares a `required_version` constraint.
+	RequireTFVersionConstraint bool
+
+	// The time to wait before forwarding each interrupt signal to the running command. If nil, the defaults of the
+	// shell package are used.
+	SignalForwardingDelays map[os.Signal]time.Duration
+
+	// The interrupt signals forwarded to the running commands, `DefaultSignalsToForward` by default.
+	SignalsToForward []os.Signal
+
+	// The time to wait, after an interrupt signal was forwarded to the running command, before killing it. Zero
+	// disables the escalation.
+	SignalEscalationDelay time.Duration
+
+	// If true, the time is prepended to each line of the OpenTofu/Terraform output, in the `OutputTimestampFormat`
+	// layout.
+	OutputPrefixTimestamp bool
+
+	// The layout of the time prepended to the output lines, `time.RFC3339Nano` by default.
+	OutputTimestampFormat string
+
+	// If true, the matches of `LogSensitivePatterns` are redacted from the OpenTofu/Terraform output logged by Terragrunt.
+	LogStripSensitive bool
+
+	// The regular expressions of the secrets redacted when `LogStripSensitive` is set.
+	LogSensitivePatterns []string
+
+	// If true, the default, commands inherit the environment of the Terragrunt process, overridden by `Env`. If false,
+	// commands only get `Env`, e.g. for hermetic builds. Since the inherited env vars can't be removed by deleting them
+	// from `Env`, they are overridden with empty values instead.
+	InheritEnv bool
+
+	// If set, the stdout and stderr of every command run by Terragrunt are written to a new file in this directory.
+	CommandOutputDir string
+
+	// If true, commands that change the infrastructure or the state fail when the git working tree has uncommitted changes.
+	FailOnDirtyGit bool
+
+	// The maximum number of git subprocesses that can run at the same time.
+	GitMaxConcurrency int
+
+	// If set, a JSON summary of all the commands run during the session is written to this file.
+	CommandSummaryFile string
+
+	// The records of the commands run during the session, shared by all the clones of the options.
+	CommandSummary *CommandSummary
 }
 
 // TerragruntOptionsFunc is a functional option type used to pass options in certain integration tests
@@ -354,6 +587,10 @@ type IAMRoleOptions struct {
 	// The Web identity token. Used when RoleArn is also set to use AssumeRoleWithWebIdentity instead of AssumeRole.
 	WebIdentityToken string
 
+	// Path to a file with the Web identity token, such as the one set in AWS_WEB_IDENTITY_TOKEN_FILE on EKS. Used when
+	// RoleArn is also set to use AssumeRoleWithWebIdentity instead of AssumeRole.
+	WebIdentityTokenFile string
+
 	// Duration of the STS Session when assuming the role.
 	AssumeRoleDuration int64
 
@@ -380,6 +617,10 @@ func MergeIAMRoleOptions(target IAMRoleOptions, source IAMRoleOptions) IAMRoleOp
 		out.WebIdentityToken = source.WebIdentityToken
 	}
 
+	if source.WebIdentityTokenFile != "" {
+		out.WebIdentityTokenFile = source.WebIdentityTokenFile
+	}
+
 	return out
 }
 
@@ -392,8 +633,12 @@ func NewTerragruntOptions() *TerragruntOptions {
 func NewTerragruntOptionsWithWriters(stdout, stderr io.Writer) *TerragruntOptions {
 	var logFormatter = format.NewFormatter()
 
+	// colors are disabled by default if the output is redirected, e.g. to a file or a CI log
+	disableColors := !isTerminal(stdout)
+	logFormatter.DisableColors = disableColors
+
 	return &TerragruntOptions{
-		TerraformPath:                  DefaultWrappedPath,
+		TerraformPath:                  defaultTerraformPath(),
 		ExcludesFile:                   defaultExcludesFile,
 		OriginalTerraformCommand:       "",
 		TerraformCommand:               "",
@@ -403,6 +648,7 @@ func NewTerragruntOptionsWithWriters(stdout, stderr io.Writer) *TerragruntOption
 		TerraformCliArgs:               []string{},
 		LogLevel:                       defaultLogLevel,
 		LogFormatter:                   logFormatter,
+		DisableLogColors:               disableColors,
 		Logger:                         log.New(log.WithOutput(stderr), log.WithLevel(defaultLogLevel), log.WithFormatter(logFormatter)),
 		Env:                            map[string]string{},
 		Source:                         "",
@@ -419,10 +665,13 @@ func NewTerragruntOptionsWithWriters(stdout, stderr io.Writer) *TerragruntOption
 		RetryMaxAttempts:               DefaultRetryMaxAttempts,
 		RetrySleepInterval:             DefaultRetrySleepInterval,
 		RetryableErrors:                util.CloneStringList(DefaultRetryableErrors),
+		ForceForwardStdoutCommands:     util.CloneStringList(DefaultForceForwardStdoutCommands),
+		ForceForwardStdoutFlags:        util.CloneStringList(DefaultForceForwardStdoutFlags),
 		ExcludeDirs:                    []string{},
 		IncludeDirs:                    []string{},
 		ModulesThatInclude:             []string{},
 		StrictInclude:                  false,
+		ModuleDependencyGraphPrune:     true,
 		Parallelism:                    DefaultParallelism,
 		Check:                          false,
 		Diff:                           false,
@@ -432,7 +681,21 @@ func NewTerragruntOptionsWithWriters(stdout, stderr io.Writer) *TerragruntOption
 		JSONOut:                        DefaultJSONOutName,
 		TerraformImplementation:        UnknownImpl,
 		TerraformLogsToJSON:            false,
+		AutoTFLog:                      true,
+		ErrorHints:                     true,
 		JSONDisableDependentModules:    false,
+		RunAllReportFormat:             DefaultRunAllReportFormat,
+		EngineRestartAttempts:          DefaultEngineRestartAttempts,
+		EngineTimeout:                  DefaultEngineTimeout,
+		EngineEnabled:                  defaultEngineEnabled(),
+		WorkingDirAbs:                  runtime.GOOS == "darwin",
+		GitMaxConcurrency:              DefaultGitMaxConcurrency,
+		CommandSummary:                 &CommandSummary{},
+		TerminationHandlerTimeout:      DefaultTerminationHandlerTimeout,
+		OutputTimestampFormat:          time.RFC3339Nano,
+		SignalsToForward:               append([]os.Signal{}, DefaultSignalsToForward...),
+		LogSensitivePatterns:           util.CloneStringList(writer.DefaultSensitivePatterns),
+		InheritEnv:                     true,
 		RunTerragrunt: func(ctx context.Context, opts *TerragruntOptions) error {
 			return errors.WithStackTrace(ErrRunTerragruntCommandNotSet)
 		},
@@ -527,16 +790,22 @@ func (opts *TerragruntOptions) Clone(terragruntConfigPath string) (*TerragruntOp
 		RunAllAutoApprove:              opts.RunAllAutoApprove,
 		NonInteractive:                 opts.NonInteractive,
 		TerraformCliArgs:               util.CloneStringList(opts.TerraformCliArgs),
+		TerraformInitArgs:              util.CloneStringList(opts.TerraformInitArgs),
 		WorkingDir:                     workingDir,
 		RootWorkingDir:                 opts.RootWorkingDir,
+		WorkingDirAbs:                  opts.WorkingDirAbs,
 		Logger:                         opts.Logger.WithField(format.PrefixKeyName, workingDir),
 		LogLevel:                       opts.LogLevel,
 		LogFormatter:                   opts.LogFormatter,
 		ValidateStrict:                 opts.ValidateStrict,
 		Env:                            util.CloneStringMap(opts.Env),
+		EnvOverrides:                   cloneEnvOverrides(opts.EnvOverrides),
 		Source:                         opts.Source,
 		SourceMap:                      opts.SourceMap,
 		SourceUpdate:                   opts.SourceUpdate,
+		SourceRefreshBeforeRun:         opts.SourceRefreshBeforeRun,
+		SourceNoPrerelease:             opts.SourceNoPrerelease,
+		SourceTagPrefix:                opts.SourceTagPrefix,
 		DownloadDir:                    opts.DownloadDir,
 		Debug:                          opts.Debug,
 		OriginalIAMRoleOptions:         opts.OriginalIAMRoleOptions,
@@ -552,13 +821,16 @@ func (opts *TerragruntOptions) Clone(terragruntConfigPath string) (*TerragruntOp
 		RetryMaxAttempts:               opts.RetryMaxAttempts,
 		RetrySleepInterval:             opts.RetrySleepInterval,
 		RetryableErrors:                util.CloneStringList(opts.RetryableErrors),
+		StateLockRetryMaxAttempts:      opts.StateLockRetryMaxAttempts,
 		ExcludesFile:                   opts.ExcludesFile,
 		ExcludeDirs:                    opts.ExcludeDirs,
 		IncludeDirs:                    opts.IncludeDirs,
 		ExcludeByDefault:               opts.ExcludeByDefault,
 		ModulesThatInclude:             opts.ModulesThatInclude,
+		ModulesIncludeByPrefix:         opts.ModulesIncludeByPrefix,
 		Parallelism:                    opts.Parallelism,
 		StrictInclude:                  opts.StrictInclude,
+		ModuleDependencyGraphPrune:     opts.ModuleDependencyGraphPrune,
 		RunTerragrunt:                  opts.RunTerragrunt,
 		AwsProviderPatchOverrides:      opts.AwsProviderPatchOverrides,
 		HclFile:                        opts.HclFile,
@@ -573,6 +845,10 @@ func (opts *TerragruntOptions) Clone(terragruntConfigPath string) (*TerragruntOp
 		DisableBucketUpdate:            opts.DisableBucketUpdate,
 		TerraformImplementation:        opts.TerraformImplementation,
 		TerraformLogsToJSON:            opts.TerraformLogsToJSON,
+		TFLogLevel:                     opts.TFLogLevel,
+		TFLogFile:                      opts.TFLogFile,
+		AutoTFLog:                      opts.AutoTFLog,
+		ErrorHints:                     opts.ErrorHints,
 		GraphRoot:                      opts.GraphRoot,
 		ScaffoldVars:                   opts.ScaffoldVars,
 		ScaffoldVarFiles:               opts.ScaffoldVarFiles,
@@ -584,9 +860,54 @@ func (opts *TerragruntOptions) Clone(terragruntConfigPath string) (*TerragruntOp
 		DisableLogColors:               opts.DisableLogColors,
 		OutputFolder:                   opts.OutputFolder,
 		JSONOutputFolder:               opts.JSONOutputFolder,
+		GzipPlanOutput:                 opts.GzipPlanOutput,
+		SavePlanJSON:                   opts.SavePlanJSON,
+		RunAllReportFile:               opts.RunAllReportFile,
+		RunAllReportFormat:             opts.RunAllReportFormat,
 		AuthProviderCmd:                opts.AuthProviderCmd,
 		SkipOutput:                     opts.SkipOutput,
+		LockKey:                        opts.LockKey,
+		EnvFromSSM:                     util.CloneStringList(opts.EnvFromSSM),
+		DryRun:                         opts.DryRun,
 		Engine:                         cloneEngineOptions(opts.Engine),
+		EngineRestartAttempts:          opts.EngineRestartAttempts,
+		EnginePlatform:                 opts.EnginePlatform,
+		EngineGRPCMaxMessageSize:       opts.EngineGRPCMaxMessageSize,
+		EngineTimeout:                  opts.EngineTimeout,
+		EngineEnabled:                  opts.EngineEnabled,
+		EngineMeta:                     util.CloneStringMap(opts.EngineMeta),
+		EngineCachePath:                opts.EngineCachePath,
+		CommandAuditLog:                opts.CommandAuditLog,
+		ConsoleInput:                   opts.ConsoleInput,
+		WorkingDirHash:                 opts.WorkingDirHash,
+		ForceForwardStdoutCommands:     util.CloneStringList(opts.ForceForwardStdoutCommands),
+		ForceForwardStdoutFlags:        util.CloneStringList(opts.ForceForwardStdoutFlags),
+		AuditTrailWriter:               opts.AuditTrailWriter,
+		SuppressStderr:                 opts.SuppressStderr,
+		PTYRows:                        opts.PTYRows,
+		PTYCols:                        opts.PTYCols,
+		ProviderOverrideFile:           opts.ProviderOverrideFile,
+		GitCredentialHelper:            opts.GitCredentialHelper,
+		GitAllowShallow:                opts.GitAllowShallow,
+		GitNoSSHFallback:               opts.GitNoSSHFallback,
+		TerminationHandler:             opts.TerminationHandler,
+		CommandHooks:                   opts.CommandHooks,
+		CommandMocks:                   opts.CommandMocks,
+		TerminationHandlerTimeout:      opts.TerminationHandlerTimeout,
+		RequireTFVersionConstraint:     opts.RequireTFVersionConstraint,
+		SignalForwardingDelays:         opts.SignalForwardingDelays,
+		SignalsToForward:               append([]os.Signal{}, opts.SignalsToForward...),
+		SignalEscalationDelay:          opts.SignalEscalationDelay,
+		OutputPrefixTimestamp:          opts.OutputPrefixTimestamp,
+		OutputTimestampFormat:          opts.OutputTimestampFormat,
+		LogStripSensitive:              opts.LogStripSensitive,
+		LogSensitivePatterns:           util.CloneStringList(opts.LogSensitivePatterns),
+		InheritEnv:                     opts.InheritEnv,
+		CommandOutputDir:               opts.CommandOutputDir,
+		FailOnDirtyGit:                 opts.FailOnDirtyGit,
+		GitMaxConcurrency:              opts.GitMaxConcurrency,
+		CommandSummaryFile:             opts.CommandSummaryFile,
+		CommandSummary:                 opts.CommandSummary,
 	}, nil
 }
 
@@ -601,7 +922,32 @@ func cloneEngineOptions(opts *EngineOptions) *EngineOptions {
 		Version: opts.Version,
 		Type:    opts.Type,
 		Meta:    opts.Meta,
+
+		SkipChecksumCheck: opts.SkipChecksumCheck,
+	}
+}
+
+// cloneEnvOverrides creates a deep copy of the given per-directory env overrides
+func cloneEnvOverrides(envOverrides map[string]map[string]string) map[string]map[string]string {
+	if envOverrides == nil {
+		return nil
 	}
+
+	clone := make(map[string]map[string]string, len(envOverrides))
+	for dir, envs := range envOverrides {
+		clone[dir] = util.CloneStringMap(envs)
+	}
+
+	return clone
+}
+
+// SetEnvOverrides sets the env vars that are used in addition to `Env` for the commands run in the given directory.
+func (opts *TerragruntOptions) SetEnvOverrides(dir string, envs map[string]string) {
+	if opts.EnvOverrides == nil {
+		opts.EnvOverrides = make(map[string]map[string]string)
+	}
+
+	opts.EnvOverrides[dir] = envs
 }
 
 // Check if argument is planfile TODO check file format
@@ -681,6 +1027,34 @@ func (opts *TerragruntOptions) DataDir() string {
 	return util.JoinPath(opts.WorkingDir, tfDataDir)
 }
 
+// defaultTerraformPath returns the path of the OpenTofu/Terraform binary the options are initialized with.
+// The precedence chain is:
+//  1. the `--terragrunt-tfpath` flag, which the CLI sets after the options are initialized;
+//  2. the `TERRAGRUNT_TFPATH` env var;
+//  3. `tofu` if it is installed, `terraform` otherwise.
+//
+// The `terraform_binary` attribute of the Terragrunt configuration is only used if neither the flag nor the env var is set.
+func defaultTerraformPath() string {
+	if path := os.Getenv(TerraformPathEnvName); path != "" {
+		return path
+	}
+
+	return DefaultWrappedPath
+}
+
+// isTerminal returns true if the given writer is a terminal.
+func isTerminal(writer io.Writer) bool {
+	file, ok := writer.(*os.File)
+
+	return ok && term.IsTerminal(int(file.Fd()))
+}
+
+// defaultEngineEnabled returns true if the `TG_EXPERIMENTAL_ENGINE` env var enables the experimental engine.
+func defaultEngineEnabled() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv(EnableExperimentalEngineEnvName)) //nolint:errcheck
+	return enabled
+}
+
 // identifyDefaultWrappedExecutable - return default path used for wrapped executable
 func identifyDefaultWrappedExecutable() string {
 	if util.IsCommandExecutable(TofuDefaultPath, "-version") {
@@ -696,6 +1070,9 @@ type EngineOptions struct {
 	Version string
 	Type    string
 	Meta    map[string]interface{}
+
+	// If set, overrides the `TG_ENGINE_SKIP_CHECK` env var for this engine.
+	SkipChecksumCheck *bool
 }
 
 // Custom error types
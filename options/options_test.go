@@ -0,0 +1,28 @@
+package options_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisableLogColorsIfNotTerminal(t *testing.T) {
+	t.Parallel()
+
+	opts := options.NewTerragruntOptionsWithWriters(&bytes.Buffer{}, &bytes.Buffer{})
+	assert.True(t, opts.DisableLogColors)
+	assert.True(t, opts.LogFormatter.DisableColors)
+
+	// a file is not a terminal either
+	file, err := os.Create(filepath.Join(t.TempDir(), "stdout.log"))
+	require.NoError(t, err)
+	defer file.Close() //nolint:errcheck
+
+	opts = options.NewTerragruntOptionsWithWriters(file, &bytes.Buffer{})
+	assert.True(t, opts.DisableLogColors)
+}
@@ -0,0 +1,62 @@
+package options
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// UnsupportedSignalError is returned when the name of a signal to forward is unknown.
+type UnsupportedSignalError string
+
+func (err UnsupportedSignalError) Error() string {
+	return fmt.Sprintf("unsupported signal %q", string(err))
+}
+
+// ParseSignal returns the signal with the given name, with or without the `SIG` prefix, e.g. `SIGHUP` or `hup`.
+func ParseSignal(name string) (os.Signal, error) {
+	signal, ok := signalsByName[strings.TrimPrefix(strings.ToUpper(name), "SIG")]
+	if !ok {
+		return nil, UnsupportedSignalError(name)
+	}
+
+	return signal, nil
+}
+
+// AddSignalToForward adds the signal with the given name to `SignalsToForward`, unless it is already there.
+func (opts *TerragruntOptions) AddSignalToForward(name string) error {
+	signal, err := ParseSignal(name)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range opts.SignalsToForward {
+		if s == signal {
+			return nil
+		}
+	}
+
+	opts.SignalsToForward = append(opts.SignalsToForward, signal)
+
+	return nil
+}
+
+// RemoveSignalToForward removes the signal with the given name from `SignalsToForward`.
+func (opts *TerragruntOptions) RemoveSignalToForward(name string) error {
+	signal, err := ParseSignal(name)
+	if err != nil {
+		return err
+	}
+
+	signals := make([]os.Signal, 0, len(opts.SignalsToForward))
+
+	for _, s := range opts.SignalsToForward {
+		if s != signal {
+			signals = append(signals, s)
+		}
+	}
+
+	opts.SignalsToForward = signals
+
+	return nil
+}
@@ -0,0 +1,21 @@
+//go:build !windows
+// +build !windows
+
+package options
+
+import (
+	"os"
+	"syscall"
+)
+
+// DefaultSignalsToForward are the signals forwarded to the running commands unless `SignalsToForward` is changed.
+var DefaultSignalsToForward = []os.Signal{syscall.SIGTERM, syscall.SIGINT}
+
+var signalsByName = map[string]os.Signal{
+	"HUP":  syscall.SIGHUP,
+	"INT":  syscall.SIGINT,
+	"QUIT": syscall.SIGQUIT,
+	"TERM": syscall.SIGTERM,
+	"USR1": syscall.SIGUSR1,
+	"USR2": syscall.SIGUSR2,
+}
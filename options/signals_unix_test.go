@@ -0,0 +1,53 @@
+//go:build !windows
+// +build !windows
+
+package options_test
+
+import (
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSignal(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"SIGHUP", "HUP", "sighup", "hup"} {
+		signal, err := options.ParseSignal(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, syscall.SIGHUP, signal, name)
+	}
+
+	_, err := options.ParseSignal("SIGKILL")
+
+	var unsupportedErr options.UnsupportedSignalError
+	require.ErrorAs(t, err, &unsupportedErr)
+	assert.Equal(t, options.UnsupportedSignalError("SIGKILL"), unsupportedErr)
+}
+
+func TestSignalsToForward(t *testing.T) {
+	t.Parallel()
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	assert.Equal(t, []os.Signal{syscall.SIGTERM, syscall.SIGINT}, opts.SignalsToForward)
+
+	require.NoError(t, opts.AddSignalToForward("SIGHUP"))
+	// a signal is only added once
+	require.NoError(t, opts.AddSignalToForward("hup"))
+	assert.Equal(t, []os.Signal{syscall.SIGTERM, syscall.SIGINT, syscall.SIGHUP}, opts.SignalsToForward)
+
+	require.NoError(t, opts.RemoveSignalToForward("SIGINT"))
+	assert.Equal(t, []os.Signal{syscall.SIGTERM, syscall.SIGHUP}, opts.SignalsToForward)
+
+	// the defaults are not changed
+	assert.Equal(t, []os.Signal{syscall.SIGTERM, syscall.SIGINT}, options.DefaultSignalsToForward)
+
+	require.Error(t, opts.AddSignalToForward("SIGKILL"))
+	require.Error(t, opts.RemoveSignalToForward("SIGKILL"))
+}
@@ -0,0 +1,20 @@
+//go:build windows
+// +build windows
+
+package options
+
+import (
+	"os"
+	"syscall"
+)
+
+// DefaultSignalsToForward are the signals forwarded to the running commands unless `SignalsToForward` is changed.
+// Windows processes cannot be sent signals, so none is forwarded by default.
+var DefaultSignalsToForward = []os.Signal{}
+
+var signalsByName = map[string]os.Signal{
+	"HUP":  syscall.SIGHUP,
+	"INT":  syscall.SIGINT,
+	"QUIT": syscall.SIGQUIT,
+	"TERM": syscall.SIGTERM,
+}
@@ -19,10 +19,14 @@ const (
 	defaultTimestampForFormattedLayout = "15:04:05.000"
 	defaultTimestamp                   = time.RFC3339
 
-	PrefixKeyName   = "prefix"
-	TFBinaryKeyName = "tfBinary"
+	PrefixKeyName     = "prefix"
+	TFBinaryKeyName   = "tfBinary"
+	WorkingDirKeyName = "workingDir"
 )
 
+// pathKeyNames are the keys of the fields that hold a path, they are resolved to absolute paths if `ShowAbsPaths` is set.
+var pathKeyNames = []string{PrefixKeyName, WorkingDirKeyName}
+
 // Formatter implements logrus.Formatter
 var _ logrus.Formatter = new(Formatter)
 
@@ -56,6 +60,9 @@ type Formatter struct {
 	// PrefixStyle is used to assign different styles (colors) to each prefix.
 	PrefixStyle PrefixStyle
 
+	// Show the prefix and working directory fields as absolute paths.
+	ShowAbsPaths bool
+
 	// Color scheme to use.
 	colorScheme compiledColorScheme
 }
@@ -79,6 +86,10 @@ func (formatter *Formatter) Format(entry *logrus.Entry) ([]byte, error) {
 		buf = new(bytes.Buffer)
 	}
 
+	if formatter.ShowAbsPaths {
+		entry.Data = absPathFields(entry.Data)
+	}
+
 	if !formatter.DisableLogFormatting {
 		if err := formatter.printFormatted(buf, entry); err != nil {
 			return nil, err
@@ -283,3 +294,22 @@ func (formatter *Formatter) needsQuoting(text string) bool {
 
 	return false
 }
+
+// absPathFields returns a copy of the given fields with the path fields resolved to absolute paths.
+func absPathFields(data logrus.Fields) logrus.Fields {
+	fields := make(logrus.Fields, len(data))
+	maps.Copy(fields, data)
+
+	for _, key := range pathKeyNames {
+		val, ok := fields[key].(string)
+		if !ok || val == "" {
+			continue
+		}
+
+		if absPath, err := filepath.Abs(val); err == nil {
+			fields[key] = filepath.ToSlash(absPath)
+		}
+	}
+
+	return fields
+}
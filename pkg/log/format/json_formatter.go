@@ -54,13 +54,21 @@ type JSONFormatter struct {
 
 	// PrettyPrint will indent all json logs.
 	PrettyPrint bool
+
+	// ShowAbsPaths shows the prefix and working directory fields as absolute paths.
+	ShowAbsPaths bool
 }
 
 // Format implements logrus.Formatter interface.
 func (f *JSONFormatter) Format(entry *logrus.Entry) ([]byte, error) {
 	data := make(logrus.Fields, len(entry.Data)+reservedFourFields)
 
-	for k, v := range entry.Data {
+	fields := entry.Data
+	if f.ShowAbsPaths {
+		fields = absPathFields(fields)
+	}
+
+	for k, v := range fields {
 		switch v := v.(type) {
 		case error:
 			// Otherwise errors are ignored by `encoding/json`
@@ -1,6 +1,10 @@
 package writer
 
-import "github.com/gruntwork-io/terragrunt/pkg/log"
+import (
+	"regexp"
+
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
 
 // Option is a function to set options for Writer.
 type Option func(writer *Writer)
@@ -26,6 +30,29 @@ func WithMsgSeparator(sep string) Option {
 	}
 }
 
+// WithRemoveANSI configures Writer to remove the ANSI escape sequences, such as colors, from the messages.
+func WithRemoveANSI(removeANSI bool) Option {
+	return func(writer *Writer) {
+		writer.removeANSI = removeANSI
+	}
+}
+
+// WithTimestampPrefix configures Writer to prepend the time of the messages, in the given layout, to them. An empty
+// layout disables the prefix.
+func WithTimestampPrefix(layout string) Option {
+	return func(writer *Writer) {
+		writer.timestampLayout = layout
+	}
+}
+
+// WithSensitivePatterns configures Writer to replace the matches of the given patterns, such as the
+// `DefaultSensitivePatterns`, with `RedactedValue`.
+func WithSensitivePatterns(patterns []*regexp.Regexp) Option {
+	return func(writer *Writer) {
+		writer.sensitivePatterns = patterns
+	}
+}
+
 // WithParseFunc sets the parser func.
 func WithParseFunc(fn WriterParseFunc) Option {
 	return func(writer *Writer) {
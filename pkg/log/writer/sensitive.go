@@ -0,0 +1,62 @@
+package writer
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// RedactedValue replaces the sensitive values in the messages.
+const RedactedValue = "[REDACTED]"
+
+// DefaultSensitivePatterns are the regular expressions of the common secrets redacted from the messages: AWS access
+// key IDs, private key PEM blocks and Bearer tokens.
+var DefaultSensitivePatterns = []string{
+	`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`,
+	`(?s)-----BEGIN [A-Z ]*PRIVATE KEY-----.*?-----END [A-Z ]*PRIVATE KEY-----`,
+	`(?i)\bBearer\s+[A-Za-z0-9\-._~+/]+=*`,
+}
+
+const (
+	// maxPendingSize is the size above which the text held back by the writer is redacted and logged anyway, so that
+	// a PEM block that never ends doesn't hold back the rest of the output.
+	maxPendingSize = 64 * 1024
+
+	// pendingFlushDelay is how long an incomplete line is held back before it is logged anyway, so that interactive
+	// prompts, which don't end with a newline, are shown.
+	pendingFlushDelay = 250 * time.Millisecond
+)
+
+var (
+	pemBeginPattern = regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`)
+	pemEndPattern   = regexp.MustCompile(`-----END [A-Z ]*PRIVATE KEY-----`)
+)
+
+// redactableLength returns the length of the beginning of the given text that can be redacted: the complete lines, up
+// to the line of a PEM block whose end is not written yet.
+func redactableLength(str string) int {
+	if len(str) > maxPendingSize {
+		return len(str)
+	}
+
+	size := strings.LastIndex(str, "\n") + 1
+
+	if locs := pemBeginPattern.FindAllStringIndex(str[:size], -1); len(locs) > 0 {
+		lastBegin := locs[len(locs)-1]
+
+		if !pemEndPattern.MatchString(str[lastBegin[1]:size]) {
+			return strings.LastIndex(str[:lastBegin[0]], "\n") + 1
+		}
+	}
+
+	return size
+}
+
+// redactSensitive replaces the matches of the given patterns with `RedactedValue`.
+func redactSensitive(str string, patterns []*regexp.Regexp) string {
+	for _, pattern := range patterns {
+		str = pattern.ReplaceAllString(str, RedactedValue)
+	}
+
+	return str
+}
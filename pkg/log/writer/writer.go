@@ -2,7 +2,9 @@
 package writer
 
 import (
+	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gruntwork-io/terragrunt/pkg/log"
@@ -13,10 +15,20 @@ type WriterParseFunc func(str string) (msg string, time *time.Time, level *log.L
 
 // Writer redirects Write requests to configured logger and level
 type Writer struct {
-	logger       log.Logger
-	defaultLevel log.Level
-	msgSeparator string
-	parseFunc    WriterParseFunc
+	logger            log.Logger
+	defaultLevel      log.Level
+	msgSeparator      string
+	parseFunc         WriterParseFunc
+	removeANSI        bool
+	timestampLayout   string
+	sensitivePatterns []*regexp.Regexp
+
+	// pending is the text held back until it can be redacted, see `redactableLength`.
+	pending string
+	// pendingTimer logs the incomplete line held back in `pending`, such as an interactive prompt, once nothing more
+	// is written for `pendingFlushDelay`.
+	pendingTimer *time.Timer
+	mu           sync.Mutex
 }
 
 // New returns a new Writer instance with fields assigned to default values.
@@ -38,12 +50,74 @@ func (writer *Writer) SetOption(opts ...Option) {
 	}
 }
 
-// Write implements `io.Writer` interface.
+// Write implements `io.Writer` interface. If sensitive patterns are set, the text is only logged once its line, or its
+// PEM block, is complete, since a secret can be split across several writes. An incomplete line outside of a PEM block,
+// such as an interactive prompt, is logged once nothing more is written for `pendingFlushDelay`. The rest of the text
+// is logged by `Flush`.
 func (writer *Writer) Write(p []byte) (n int, err error) {
-	var (
-		str  = string(p)
-		strs = []string{str}
-	)
+	if len(writer.sensitivePatterns) == 0 {
+		return len(p), writer.writeMsgs(string(p))
+	}
+
+	writer.mu.Lock()
+	defer writer.mu.Unlock()
+
+	// the secrets are redacted before the split, since they can span several lines, e.g. PEM blocks
+	writer.pending += string(p)
+
+	size := redactableLength(writer.pending)
+	str := writer.pending[:size]
+	writer.pending = writer.pending[size:]
+
+	if err := writer.writeMsgs(redactSensitive(str, writer.sensitivePatterns)); err != nil {
+		return 0, err
+	}
+
+	writer.schedulePendingFlush()
+
+	return len(p), nil
+}
+
+// Flush logs the text held back by `Write`. It must be called once nothing more is written.
+func (writer *Writer) Flush() error {
+	writer.mu.Lock()
+	defer writer.mu.Unlock()
+
+	return writer.flushPending()
+}
+
+// schedulePendingFlush (re)starts the timer logging the incomplete line held back, unless it belongs to a PEM block
+// whose end is not written yet.
+func (writer *Writer) schedulePendingFlush() {
+	if writer.pendingTimer != nil {
+		writer.pendingTimer.Stop()
+	}
+
+	if writer.pending == "" || pemBeginPattern.MatchString(writer.pending) {
+		return
+	}
+
+	writer.pendingTimer = time.AfterFunc(pendingFlushDelay, func() {
+		writer.mu.Lock()
+		defer writer.mu.Unlock()
+
+		_ = writer.flushPending()
+	})
+}
+
+func (writer *Writer) flushPending() error {
+	if writer.pendingTimer != nil {
+		writer.pendingTimer.Stop()
+	}
+
+	str := writer.pending
+	writer.pending = ""
+
+	return writer.writeMsgs(redactSensitive(str, writer.sensitivePatterns))
+}
+
+func (writer *Writer) writeMsgs(str string) error {
+	strs := []string{str}
 
 	if writer.msgSeparator != "" {
 		strs = strings.Split(str, writer.msgSeparator)
@@ -54,18 +128,31 @@ func (writer *Writer) Write(p []byte) (n int, err error) {
 			continue
 		}
 
-		msg, time, level, err := writer.parseFunc(str)
+		msg, msgTime, level, err := writer.parseFunc(str)
 		if err != nil {
-			return 0, err
+			return err
+		}
+
+		if writer.removeANSI {
+			msg = log.RemoveAllASCISeq(msg)
+		} else {
+			// Reset ANSI styles at the end of a line so that the new line does not inherit them
+			msg = log.ResetASCISeq(msg)
 		}
 
-		// Reset ANSI styles at the end of a line so that the new line does not inherit them
-		msg = log.ResetASCISeq(msg)
+		if writer.timestampLayout != "" {
+			prefixTime := time.Now()
+			if msgTime != nil {
+				prefixTime = *msgTime
+			}
+
+			msg = prefixTime.Format(writer.timestampLayout) + " " + msg
+		}
 
 		logger := writer.logger
 
-		if time != nil {
-			logger = logger.WithTime(*time)
+		if msgTime != nil {
+			logger = logger.WithTime(*msgTime)
 		}
 
 		if level == nil {
@@ -75,5 +162,5 @@ func (writer *Writer) Write(p []byte) (n int, err error) {
 		logger.Log(*level, msg)
 	}
 
-	return len(p), nil
+	return nil
 }
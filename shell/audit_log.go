@@ -0,0 +1,74 @@
+package shell
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gruntwork-io/go-commons/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// auditLogLocks serializes writes to the same audit log file, since `run-all` runs commands concurrently.
+var auditLogLocks = util.NewKeyLocks()
+
+// CommandAuditRecord is a single line of the command audit log set by --terragrunt-command-audit-log.
+type CommandAuditRecord struct {
+	Timestamp  time.Time `json:"timestamp"`
+	WorkingDir string    `json:"workingDir"`
+	Command    string    `json:"command"`
+	Args       []string  `json:"args"`
+	EnvKeys    []string  `json:"env_keys"`
+}
+
+// appendCommandAuditLog appends a JSON line describing the command to the audit log file, if one is configured.
+// Only the names of the environment variables are recorded, never their values.
+func appendCommandAuditLog(opts *options.TerragruntOptions, workingDir, command string, args, envVars []string) error {
+	if opts.CommandAuditLog == "" {
+		return nil
+	}
+
+	envKeys := make([]string, 0, len(envVars))
+
+	for _, envVar := range envVars {
+		key, _, _ := strings.Cut(envVar, "=")
+		envKeys = append(envKeys, key)
+	}
+
+	sort.Strings(envKeys)
+
+	if args == nil {
+		args = []string{}
+	}
+
+	line, err := json.Marshal(CommandAuditRecord{
+		Timestamp:  time.Now().UTC(),
+		WorkingDir: workingDir,
+		Command:    command,
+		Args:       args,
+		EnvKeys:    envKeys,
+	})
+	if err != nil {
+		return errors.WithStackTrace(err)
+	}
+
+	auditLogLocks.Lock(opts.CommandAuditLog)
+	defer auditLogLocks.Unlock(opts.CommandAuditLog)
+
+	const ownerWriteGlobalReadPerms = 0644
+
+	file, err := os.OpenFile(opts.CommandAuditLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, ownerWriteGlobalReadPerms)
+	if err != nil {
+		return errors.WithStackTrace(err)
+	}
+	defer file.Close() //nolint:errcheck
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return errors.WithStackTrace(err)
+	}
+
+	return nil
+}
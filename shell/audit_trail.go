@@ -0,0 +1,158 @@
+package shell
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gruntwork-io/go-commons/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// AuditTrailEntry is a single NDJSON line written to `TerragruntOptions.AuditTrailWriter` after every command.
+type AuditTrailEntry struct {
+	Timestamp    time.Time `json:"ts"`
+	Command      string    `json:"command"`
+	Args         []string  `json:"args"`
+	WorkingDir   string    `json:"workingDir"`
+	ExitCode     int       `json:"exitCode"`
+	DurationMs   int64     `json:"durationMs"`
+	StdoutSha256 string    `json:"stdoutSha256"`
+	StderrSha256 string    `json:"stderrSha256"`
+}
+
+// writeAuditTrail writes the audit trail entry of a finished command, if an audit trail writer is configured.
+// The entry is written with a single `Write` call, so that every sink receives whole lines.
+func writeAuditTrail(opts *options.TerragruntOptions, startedAt time.Time, workingDir, command string, args []string, output *util.CmdOutput, cmdErr error) error {
+	if opts.AuditTrailWriter == nil {
+		return nil
+	}
+
+	if args == nil {
+		args = []string{}
+	}
+
+	if output == nil {
+		output = &util.CmdOutput{}
+	}
+
+	entry := AuditTrailEntry{
+		Timestamp:    startedAt.UTC(),
+		Command:      command,
+		Args:         args,
+		WorkingDir:   workingDir,
+		DurationMs:   time.Since(startedAt).Milliseconds(),
+		StdoutSha256: sha256Hex(output.Stdout),
+		StderrSha256: sha256Hex(output.Stderr),
+		ExitCode:     commandExitCode(cmdErr),
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return errors.WithStackTrace(err)
+	}
+
+	if _, err := opts.AuditTrailWriter.Write(append(line, '\n')); err != nil {
+		return errors.WithStackTrace(err)
+	}
+
+	return nil
+}
+
+// commandExitCode returns the exit code of a command from its error, or 1 if the error has no exit code.
+func commandExitCode(cmdErr error) int {
+	if cmdErr == nil {
+		return 0
+	}
+
+	if exitCode, err := util.GetExitCode(cmdErr); err == nil && exitCode != 0 {
+		return exitCode
+	}
+
+	return 1
+}
+
+func sha256Hex(str string) string {
+	hash := sha256.Sum256([]byte(str))
+	return hex.EncodeToString(hash[:])
+}
+
+// FileAuditTrailWriter appends audit trail entries to a file.
+type FileAuditTrailWriter struct {
+	file *os.File
+	mu   sync.Mutex
+}
+
+// NewFileAuditTrailWriter opens, or creates, the file at the given path for appending audit trail entries.
+func NewFileAuditTrailWriter(path string) (*FileAuditTrailWriter, error) {
+	const ownerWriteGlobalReadPerms = 0644
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, ownerWriteGlobalReadPerms)
+	if err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	return &FileAuditTrailWriter{file: file}, nil
+}
+
+// Write implements `io.Writer` interface.
+func (writer *FileAuditTrailWriter) Write(p []byte) (int, error) {
+	writer.mu.Lock()
+	defer writer.mu.Unlock()
+
+	return writer.file.Write(p)
+}
+
+// Close closes the underlying file.
+func (writer *FileAuditTrailWriter) Close() error {
+	return writer.file.Close()
+}
+
+// HTTPAuditTrailWriter sends every audit trail entry to an HTTP endpoint in the body of a POST request.
+type HTTPAuditTrailWriter struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewHTTPAuditTrailWriter returns a writer that posts audit trail entries to the given endpoint.
+func NewHTTPAuditTrailWriter(endpoint string) *HTTPAuditTrailWriter {
+	const requestTimeout = 10 * time.Second
+
+	return &HTTPAuditTrailWriter{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// Write implements `io.Writer` interface.
+func (writer *HTTPAuditTrailWriter) Write(p []byte) (int, error) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, writer.endpoint, bytes.NewReader(p))
+	if err != nil {
+		return 0, errors.WithStackTrace(err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	resp, err := writer.client.Do(req)
+	if err != nil {
+		return 0, errors.WithStackTrace(err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return 0, errors.WithStackTrace(fmt.Errorf("audit trail endpoint %s returned %s", writer.endpoint, resp.Status))
+	}
+
+	return len(p), nil
+}
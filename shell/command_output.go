@@ -0,0 +1,63 @@
+package shell
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gruntwork-io/go-commons/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+const commandOutputTimestampFormat = "20060102T150405.000Z"
+
+// commandOutputNameRegex matches the characters of a command name that are replaced in the output file name.
+var commandOutputNameRegex = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// writeCommandOutput writes the stdout and stderr of the command to a new `<timestamp>-<command>-<random>.log` file in
+// the directory set by --terragrunt-command-output-dir. The file is written under a `.tmp` name first and then renamed,
+// so that no partially written files are left behind.
+func writeCommandOutput(opts *options.TerragruntOptions, startedAt time.Time, workingDir, command string, args []string, output *util.CmdOutput) error {
+	if opts.CommandOutputDir == "" || output == nil {
+		return nil
+	}
+
+	if err := os.MkdirAll(opts.CommandOutputDir, os.ModePerm); err != nil {
+		return errors.WithStackTrace(err)
+	}
+
+	commandName := commandOutputNameRegex.ReplaceAllString(filepath.Base(command), "_")
+	pattern := fmt.Sprintf("%s-%s-*.log.tmp", startedAt.UTC().Format(commandOutputTimestampFormat), commandName)
+
+	tmpFile, err := os.CreateTemp(opts.CommandOutputDir, pattern)
+	if err != nil {
+		return errors.WithStackTrace(err)
+	}
+
+	content := fmt.Sprintf("command: %s\nworking dir: %s\n\n--- stdout ---\n%s\n--- stderr ---\n%s", strings.Join(append([]string{command}, args...), " "), workingDir, output.Stdout, output.Stderr)
+
+	if _, err := tmpFile.WriteString(content); err != nil {
+		tmpFile.Close()           //nolint:errcheck
+		os.Remove(tmpFile.Name()) //nolint:errcheck
+
+		return errors.WithStackTrace(err)
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		os.Remove(tmpFile.Name()) //nolint:errcheck
+
+		return errors.WithStackTrace(err)
+	}
+
+	if err := os.Rename(tmpFile.Name(), strings.TrimSuffix(tmpFile.Name(), ".tmp")); err != nil {
+		os.Remove(tmpFile.Name()) //nolint:errcheck
+
+		return errors.WithStackTrace(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package shell
+
+import (
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// recordCommandSummary adds the record of a finished command to the session summary if --terragrunt-command-summary-file is set.
+func recordCommandSummary(opts *options.TerragruntOptions, startedAt time.Time, workingDir, command string, args []string, output *util.CmdOutput, cmdErr error) {
+	if opts.CommandSummaryFile == "" || opts.CommandSummary == nil {
+		return
+	}
+
+	if args == nil {
+		args = []string{}
+	}
+
+	if output == nil {
+		output = &util.CmdOutput{}
+	}
+
+	opts.CommandSummary.Add(options.CommandSummaryRecord{
+		Command:     command,
+		Args:        args,
+		WorkingDir:  workingDir,
+		ExitCode:    commandExitCode(cmdErr),
+		DurationMs:  time.Since(startedAt).Milliseconds(),
+		StdoutBytes: len(output.Stdout),
+		StderrBytes: len(output.Stderr),
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/gruntwork-io/terragrunt/internal/cache"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
 	"github.com/gruntwork-io/terragrunt/util"
 
 	"github.com/gruntwork-io/terragrunt/options"
@@ -12,8 +13,17 @@ import (
 const (
 	TerraformCommandContextKey ctxKey = iota
 	RunCmdCacheContextKey      ctxKey = iota
+	ModulePathContextKey       ctxKey = iota
+	RunIDContextKey            ctxKey = iota
+	ParallelismSlotContextKey  ctxKey = iota
+	ShellCommandContextKey     ctxKey = iota
 
 	runCmdCacheName = "runCmdCache"
+
+	// Names of the log fields populated by `ContextLogFields`.
+	ModulePathLogFieldName      = "modulePath"
+	RunIDLogFieldName           = "runID"
+	ParallelismSlotLogFieldName = "parallelismSlot"
 )
 
 type ctxKey byte
@@ -34,3 +44,56 @@ func TerraformCommandHookFromContext(ctx context.Context) RunShellCommandFunc {
 
 	return nil
 }
+
+// ShellCommandHookFunc runs the given command in place of `RunShellCommandWithOutput`.
+type ShellCommandHookFunc func(ctx context.Context, opts *options.TerragruntOptions, command string, args []string) (*util.CmdOutput, error)
+
+// ContextWithShellCommandHook returns a new context with the hook that replaces every shell command, e.g. with a
+// `FakeExecutor` in unit tests. Unlike the Terraform command hook, it is called for all commands.
+func ContextWithShellCommandHook(ctx context.Context, fn ShellCommandHookFunc) context.Context {
+	return context.WithValue(ctx, ShellCommandContextKey, fn)
+}
+
+// ShellCommandHookFromContext returns the hook set by `ContextWithShellCommandHook`, or nil if there is none.
+func ShellCommandHookFromContext(ctx context.Context) ShellCommandHookFunc {
+	if fn, ok := ctx.Value(ShellCommandContextKey).(ShellCommandHookFunc); ok {
+		return fn
+	}
+
+	return nil
+}
+
+// ContextWithModulePath returns a new context with the path of the module being run.
+func ContextWithModulePath(ctx context.Context, modulePath string) context.Context {
+	return context.WithValue(ctx, ModulePathContextKey, modulePath)
+}
+
+// ContextWithRunID returns a new context with the ID of the current run, shared by all modules of a `run-all` command.
+func ContextWithRunID(ctx context.Context, runID string) context.Context {
+	return context.WithValue(ctx, RunIDContextKey, runID)
+}
+
+// ContextWithParallelismSlot returns a new context with the parallelism slot occupied by the module being run.
+func ContextWithParallelismSlot(ctx context.Context, slot int) context.Context {
+	return context.WithValue(ctx, ParallelismSlotContextKey, slot)
+}
+
+// ContextLogFields returns the module path, run ID and parallelism slot stored in the context as log fields,
+// values that are not set in the context are omitted.
+func ContextLogFields(ctx context.Context) log.Fields {
+	fields := make(log.Fields)
+
+	if val, ok := ctx.Value(ModulePathContextKey).(string); ok {
+		fields[ModulePathLogFieldName] = val
+	}
+
+	if val, ok := ctx.Value(RunIDContextKey).(string); ok {
+		fields[RunIDLogFieldName] = val
+	}
+
+	if val, ok := ctx.Value(ParallelismSlotContextKey).(int); ok {
+		fields[ParallelismSlotLogFieldName] = val
+	}
+
+	return fields
+}
@@ -0,0 +1,65 @@
+package shell
+
+import (
+	"context"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// RunShellCommandWithOutputAndEnvCapture runs the command in the same way as `RunShellCommandWithOutput`, but if the
+// log level is trace, it first runs `env` with the same environment and in the same directory as the command, and logs
+// the env vars that reach the subprocess at trace level, to debug the env propagation. The values of the sensitive env
+// vars are redacted.
+func RunShellCommandWithOutputAndEnvCapture(
+	ctx context.Context,
+	opts *options.TerragruntOptions,
+	workingDir string,
+	suppressStdout bool,
+	suppressStderr bool,
+	allocatePseudoTty bool,
+	command string,
+	args ...string,
+) (*util.CmdOutput, error) {
+	if opts.LogLevel == log.TraceLevel && !skipInDryRun(opts, command, args) {
+		logSubprocessEnv(ctx, opts, workingDir, command)
+	}
+
+	return RunShellCommandWithOutput(ctx, opts, workingDir, suppressStdout, suppressStderr, allocatePseudoTty, command, args...)
+}
+
+// logSubprocessEnv logs at trace level the env vars of a subprocess run in the given directory. Failing to run `env` is
+// only logged, since it must not prevent the command from running.
+func logSubprocessEnv(ctx context.Context, opts *options.TerragruntOptions, workingDir, command string) {
+	commandDir := workingDir
+	if commandDir == "" {
+		commandDir = opts.WorkingDir
+	}
+
+	envCommand, envArgs := "env", []string{}
+	if runtime.GOOS == "windows" {
+		envCommand, envArgs = "cmd", []string{"/C", "set"}
+	}
+
+	cmd := exec.CommandContext(ctx, envCommand, envArgs...)
+	cmd.Env = toEnvVarsList(opts.InheritEnv, opts.Env, opts.EnvOverrides[commandDir])
+	cmd.Dir = subprocessDir(opts, commandDir)
+
+	out, err := cmd.Output()
+	if err != nil {
+		opts.Logger.Tracef("Failed to capture the env vars of %s: %v", command, err)
+		return
+	}
+
+	for _, envVar := range (util.CmdOutput{Stdout: string(out)}).Lines() {
+		if name, _, _ := strings.Cut(envVar, "="); isSensitiveEnvVarName(name) {
+			envVar = name + "=" + redactedEnvVarName
+		}
+
+		opts.Logger.Tracef("Env var of %s in %s: %s", command, commandDir, envVar)
+	}
+}
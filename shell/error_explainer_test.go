@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestExplainError(t *testing.T) {
@@ -58,3 +59,22 @@ func TestExplainError(t *testing.T) {
 	}
 
 }
+
+func TestExplainErrorWithHints(t *testing.T) {
+	t.Parallel()
+
+	processErr := util.ProcessExecutionError{
+		Err:    errors.New(""),
+		Stderr: "Error: No valid credential sources found",
+	}
+
+	// the same table is used for the hints and the explanations
+	hints, err := shell.MatchErrorHints(processErr.Stderr)
+	require.NoError(t, err)
+	require.Len(t, hints, 1)
+	assert.Contains(t, shell.ExplainError(multierror.Append(&multierror.Error{}, processErr)), hints[0])
+
+	// the errors that already carry their hints are not explained again
+	withHintsErr := multierror.Append(&multierror.Error{}, shell.ErrorWithHints{Err: processErr, Hints: hints})
+	assert.Empty(t, shell.ExplainError(withHintsErr))
+}
@@ -0,0 +1,77 @@
+package shell
+
+import (
+	_ "embed"
+	"regexp"
+	"sync"
+
+	"github.com/gruntwork-io/go-commons/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// errorHintsYAML is the list of the remediation hints of the well-known errors.
+//
+//go:embed error_hints.yaml
+var errorHintsYAML []byte
+
+// ErrorHint is the remediation hint of an error whose output matches one of the `Match` regular expressions.
+type ErrorHint struct {
+	Match []string `yaml:"match"`
+	Hint  string   `yaml:"hint"`
+
+	matchers []*regexp.Regexp
+}
+
+// errorHints parses `errorHintsYAML` and compiles its regular expressions once.
+var errorHints = sync.OnceValues(func() ([]ErrorHint, error) {
+	var hints []ErrorHint
+
+	if err := yaml.Unmarshal(errorHintsYAML, &hints); err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	for i := range hints {
+		for _, match := range hints[i].Match {
+			matcher, err := regexp.Compile(match)
+			if err != nil {
+				return nil, errors.WithStackTrace(err)
+			}
+
+			hints[i].matchers = append(hints[i].matchers, matcher)
+		}
+	}
+
+	return hints, nil
+})
+
+// MatchErrorHints returns the hints whose regular expressions match the given output of a command.
+func MatchErrorHints(output string) ([]string, error) {
+	hints, err := errorHints()
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []string
+
+	for _, hint := range hints {
+		for _, matcher := range hint.matchers {
+			if matcher.MatchString(output) {
+				matched = append(matched, hint.Hint)
+				break
+			}
+		}
+	}
+
+	return matched, nil
+}
+
+// withErrorHints returns the error of a command with the hints that match its stderr appended, or the error as is if
+// none matches.
+func withErrorHints(err error, stderr string) error {
+	hints, hintsErr := MatchErrorHints(stderr)
+	if hintsErr != nil || len(hints) == 0 {
+		return err
+	}
+
+	return errors.WithStackTrace(ErrorWithHints{Err: err, Hints: hints})
+}
@@ -0,0 +1,73 @@
+package shell
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// ErrNoOutputs is returned by RunTerraformOutputCommand when the module has no outputs.
+var ErrNoOutputs = errors.New("the module has no outputs")
+
+// CommandNotFoundError is returned when a command can't be found in the PATH.
+type CommandNotFoundError struct {
+	Name string
+	Path string
+}
+
+func (err CommandNotFoundError) Error() string {
+	return fmt.Sprintf("%s binary not found in PATH %q", err.Name, err.Path)
+}
+
+// FakeExitError is the exit error of the commands that fail in a `FakeExecutor`.
+type FakeExitError struct {
+	ExitCode int
+}
+
+func (err FakeExitError) Error() string {
+	return fmt.Sprintf("exit status %d", err.ExitCode)
+}
+
+// ExitStatus returns the exit code, which is read by `util.GetExitCode`.
+func (err FakeExitError) ExitStatus() (int, error) {
+	return err.ExitCode, nil
+}
+
+// UnregisteredFakeCommandError is returned by a `FakeExecutor` for the commands that were not registered.
+type UnregisteredFakeCommandError struct {
+	Command string
+	Args    []string
+}
+
+func (err UnregisteredFakeCommandError) Error() string {
+	return fmt.Sprintf("command %q with args %q is not registered in the fake executor", err.Command, err.Args)
+}
+
+// ErrorWithHints is the error of a command whose stderr matches remediation hints, see `--terragrunt-error-hints`.
+type ErrorWithHints struct {
+	Err   error
+	Hints []string
+}
+
+func (err ErrorWithHints) Error() string {
+	var sb strings.Builder
+
+	sb.WriteString(err.Err.Error())
+
+	for _, hint := range err.Hints {
+		sb.WriteString("\nHint: " + hint)
+	}
+
+	return sb.String()
+}
+
+func (err ErrorWithHints) Unwrap() error {
+	return err.Err
+}
+
+// ExitStatus returns the exit code of the command, which is read by `util.GetExitCode`.
+func (err ErrorWithHints) ExitStatus() (int, error) {
+	return util.GetExitCode(err.Err)
+}
@@ -0,0 +1,78 @@
+package shell
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+
+	"github.com/gruntwork-io/go-commons/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// FakeCommandFunc returns the output of a command registered in a `FakeExecutor`.
+type FakeCommandFunc func(args []string) (*util.CmdOutput, error)
+
+// FakeExecutor replaces the shell commands in unit tests. It is injected with `ContextWithShellCommandHook`, for
+// example:
+//
+//	fake := shell.NewFakeExecutor()
+//	fake.MustSucceed("git", "v1.2.3\n")
+//	ctx = shell.ContextWithShellCommandHook(ctx, fake.Run)
+//
+// The commands are matched by their name or by the base name of their path, so that `tofu` also matches
+// `/usr/local/bin/tofu`. Running a command that is not registered fails with `UnregisteredFakeCommandError`.
+type FakeExecutor struct {
+	commands map[string]FakeCommandFunc
+	mu       sync.RWMutex
+}
+
+// NewFakeExecutor returns a `FakeExecutor` without registered commands.
+func NewFakeExecutor() *FakeExecutor {
+	return &FakeExecutor{
+		commands: map[string]FakeCommandFunc{},
+	}
+}
+
+// Register sets the function that runs the given command, replacing the previously registered one.
+func (fake *FakeExecutor) Register(command string, fn FakeCommandFunc) {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+
+	fake.commands[command] = fn
+}
+
+// MustSucceed registers the command that succeeds with the given stdout, regardless of its args.
+func (fake *FakeExecutor) MustSucceed(command, stdout string) {
+	fake.Register(command, func(args []string) (*util.CmdOutput, error) {
+		return &util.CmdOutput{Stdout: stdout}, nil
+	})
+}
+
+// MustFail registers the command that fails with the given exit code and stderr, regardless of its args.
+func (fake *FakeExecutor) MustFail(command string, exitCode int, stderr string) {
+	fake.Register(command, func(args []string) (*util.CmdOutput, error) {
+		return &util.CmdOutput{Stderr: stderr}, errors.WithStackTrace(util.ProcessExecutionError{
+			Err:    FakeExitError{ExitCode: exitCode},
+			Stderr: stderr,
+		})
+	})
+}
+
+// Run implements `ShellCommandHookFunc`.
+func (fake *FakeExecutor) Run(ctx context.Context, opts *options.TerragruntOptions, command string, args []string) (*util.CmdOutput, error) {
+	fake.mu.RLock()
+
+	fn, ok := fake.commands[command]
+	if !ok {
+		fn, ok = fake.commands[filepath.Base(command)]
+	}
+
+	fake.mu.RUnlock()
+
+	if !ok {
+		return nil, errors.WithStackTrace(UnregisteredFakeCommandError{Command: command, Args: args})
+	}
+
+	return fn(args)
+}
@@ -0,0 +1,228 @@
+package shell_test
+
+import (
+	"context"
+	goerrors "errors"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/shell"
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeExecutor(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	fake := shell.NewFakeExecutor()
+	fake.MustSucceed("git", "v1.2.3\n")
+	fake.MustFail("tofu", 2, "Error: Invalid provider configuration")
+
+	ctx := shell.ContextWithShellCommandHook(context.Background(), fake.Run)
+
+	out, err := shell.RunShellCommandWithOutput(ctx, terragruntOptions, "", false, false, false, "git", "describe", "--tags")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.2.3\n", out.Stdout)
+
+	out, err = shell.RunShellCommandWithOutput(ctx, terragruntOptions, "", false, false, false, "/usr/local/bin/tofu", "plan")
+	require.Error(t, err)
+	assert.Equal(t, "Error: Invalid provider configuration", out.Stderr)
+
+	exitCode, err := util.GetExitCode(err)
+	require.NoError(t, err)
+	assert.Equal(t, 2, exitCode)
+
+	_, err = shell.RunShellCommandWithOutput(ctx, terragruntOptions, "", false, false, false, "terraform", "plan")
+
+	var unregisteredErr shell.UnregisteredFakeCommandError
+	require.True(t, goerrors.As(err, &unregisteredErr))
+	assert.Equal(t, "terraform", unregisteredErr.Command)
+}
+
+type recordingCommandHook struct {
+	commands []string
+	outputs  []string
+}
+
+func (hook *recordingCommandHook) Before(ctx context.Context, command string, args []string) {
+	hook.commands = append(hook.commands, command)
+}
+
+func (hook *recordingCommandHook) After(ctx context.Context, output *util.CmdOutput, err error) {
+	hook.outputs = append(hook.outputs, output.Stdout)
+}
+
+func TestCommandHooks(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	hook := &recordingCommandHook{}
+	terragruntOptions.CommandHooks = []options.CommandHook{hook}
+
+	fake := shell.NewFakeExecutor()
+	fake.MustSucceed("git", "v1.2.3\n")
+
+	ctx := shell.ContextWithShellCommandHook(context.Background(), fake.Run)
+
+	_, err = shell.RunShellCommandWithOutput(ctx, terragruntOptions, "", false, false, false, "git", "describe", "--tags")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"git"}, hook.commands)
+	assert.Equal(t, []string{"v1.2.3\n"}, hook.outputs)
+
+	// the commands with the output written to files go through the same hooks
+	outputDir := t.TempDir()
+
+	err = shell.RunShellCommandWithOutputToFile(ctx, terragruntOptions, "", filepath.Join(outputDir, "stdout.log"), filepath.Join(outputDir, "stderr.log"), "git", "describe", "--tags")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"git", "git"}, hook.commands)
+}
+
+func TestRunTerraformOutputCommand(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	fake := shell.NewFakeExecutor()
+	fake.MustSucceed(terragruntOptions.TerraformPath, `{"vpc_id": {"sensitive": false, "type": "string", "value": "vpc-123"}}`)
+
+	ctx := shell.ContextWithShellCommandHook(context.Background(), fake.Run)
+
+	outputs, err := shell.RunTerraformOutputCommand(ctx, terragruntOptions, "")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"sensitive": false, "type": "string", "value": "vpc-123"}`, string(outputs["vpc_id"]))
+
+	// the lines printed around the JSON are ignored
+	fake.MustSucceed(terragruntOptions.TerraformPath, "Refreshing state...\n{\n  \"ids\": {\n    \"value\": [\n      1,\n      true\n    ]\n  }\n}\n")
+
+	outputs, err = shell.RunTerraformOutputCommand(ctx, terragruntOptions, "")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"value": [1, true]}`, string(outputs["ids"]))
+
+	for _, stdout := range []string{"{}\n", ""} {
+		fake.MustSucceed(terragruntOptions.TerraformPath, stdout)
+
+		_, err = shell.RunTerraformOutputCommand(ctx, terragruntOptions, "")
+		require.ErrorIs(t, err, shell.ErrNoOutputs)
+	}
+}
+
+func TestRunTerraformOutputCommandWorkingDir(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	terragruntOptions.WorkingDir = t.TempDir()
+
+	var workingDirs []string
+
+	fake := shell.NewFakeExecutor()
+	fake.Register(terragruntOptions.TerraformPath, func(args []string) (*util.CmdOutput, error) {
+		return &util.CmdOutput{Stdout: `{"vpc_id": {"value": "vpc-123"}}`}, nil
+	})
+
+	ctx := shell.ContextWithShellCommandHook(context.Background(), func(ctx context.Context, opts *options.TerragruntOptions, command string, args []string) (*util.CmdOutput, error) {
+		workingDirs = append(workingDirs, opts.WorkingDir)
+
+		return fake.Run(ctx, opts, command, args)
+	})
+
+	_, err = shell.RunTerraformOutputCommand(ctx, terragruntOptions, "")
+	require.NoError(t, err)
+
+	// the command runs in the working directory of the caller
+	assert.Equal(t, []string{terragruntOptions.WorkingDir}, workingDirs)
+	assert.False(t, terragruntOptions.ForwardTFStdout)
+}
+
+func TestGitRepoTagsSSHFallback(t *testing.T) {
+	t.Parallel()
+
+	const sshRepo = "ssh://git@github.com/gruntwork-io/terragrunt.git"
+
+	newContext := func(repos *[]string) context.Context {
+		fake := shell.NewFakeExecutor()
+		fake.Register("git", func(args []string) (*util.CmdOutput, error) {
+			repo := args[len(args)-1]
+			*repos = append(*repos, repo)
+
+			if repo == sshRepo {
+				const stderr = "git@github.com: Permission denied (publickey).\nfatal: Could not read from remote repository."
+
+				return &util.CmdOutput{Stderr: stderr}, util.ProcessExecutionError{Err: shell.FakeExitError{ExitCode: 128}, Stderr: stderr}
+			}
+
+			return &util.CmdOutput{Stdout: "0123456789abcdef refs/tags/v0.1.0\n"}, nil
+		})
+
+		return shell.ContextWithShellCommandHook(context.Background(), fake.Run)
+	}
+
+	gitRepo, err := url.Parse(sshRepo)
+	require.NoError(t, err)
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	terragruntOptions.GitAllowShallow = true
+
+	var repos []string
+
+	tags, resolvedRepo, err := shell.GitRepoTags(newContext(&repos), terragruntOptions, gitRepo)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v0.1.0"}, shell.GitRepoTagNames(tags))
+	assert.Equal(t, "ssh://github.com/gruntwork-io/terragrunt.git", resolvedRepo.String())
+	assert.Equal(t, []string{sshRepo, "https://github.com/gruntwork-io/terragrunt.git"}, repos)
+
+	// the fallback is disabled
+	terragruntOptions.GitNoSSHFallback = true
+	repos = nil
+
+	_, _, err = shell.GitRepoTags(newContext(&repos), terragruntOptions, gitRepo)
+	require.Error(t, err)
+	assert.Equal(t, []string{sshRepo}, repos)
+
+	// not an SSH URL
+	assert.Nil(t, shell.ConvertSSHToHTTPS(&url.URL{Scheme: "https", Host: "github.com", Path: "/gruntwork-io/terragrunt.git"}))
+}
+
+func TestErrorHints(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	fake := shell.NewFakeExecutor()
+	fake.MustFail("tofu", 1, "Error: Error acquiring the state lock\n\nLock Info:\n  ID: 0123")
+
+	ctx := shell.ContextWithShellCommandHook(context.Background(), fake.Run)
+
+	_, err = shell.RunShellCommandWithOutput(ctx, terragruntOptions, "", false, false, false, "tofu", "apply")
+
+	var hintsErr shell.ErrorWithHints
+	require.True(t, goerrors.As(err, &hintsErr))
+	require.Len(t, hintsErr.Hints, 1)
+	assert.Contains(t, err.Error(), "Hint: Another run holds the state lock.")
+
+	exitCode, err := util.GetExitCode(err)
+	require.NoError(t, err)
+	assert.Equal(t, 1, exitCode)
+
+	// the hints are disabled
+	terragruntOptions.ErrorHints = false
+
+	_, err = shell.RunShellCommandWithOutput(ctx, terragruntOptions, "", false, false, false, "tofu", "apply")
+	require.Error(t, err)
+	assert.False(t, goerrors.As(err, &hintsErr))
+}
@@ -0,0 +1,52 @@
+package shell
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/gruntwork-io/go-commons/errors"
+)
+
+const gitCommandName = "git"
+
+// gitSemaphores limit the number of `git` subprocesses running at the same time across all the modules of a `run-all`
+// execution, so that dozens of parallel `git ls-remote` calls do not exhaust the connections to the remote.
+// There is one semaphore per `GitMaxConcurrency` value, which is the same for all the modules of a single run.
+var (
+	gitSemaphores   = map[int]chan struct{}{}
+	gitSemaphoresMu sync.Mutex
+)
+
+// acquireGitSlot blocks until a git subprocess can be started or the context is done. The returned function releases the slot.
+// A `maxConcurrency` lower than 1 disables the limit.
+func acquireGitSlot(ctx context.Context, maxConcurrency int) (func(), error) {
+	if maxConcurrency < 1 {
+		return func() {}, nil
+	}
+
+	gitSemaphoresMu.Lock()
+
+	semaphore, ok := gitSemaphores[maxConcurrency]
+	if !ok {
+		semaphore = make(chan struct{}, maxConcurrency)
+		gitSemaphores[maxConcurrency] = semaphore
+	}
+
+	gitSemaphoresMu.Unlock()
+
+	select {
+	case semaphore <- struct{}{}:
+		return func() { <-semaphore }, nil
+	case <-ctx.Done():
+		return nil, errors.WithStackTrace(ctx.Err())
+	}
+}
+
+// isGitCommand returns true if the command runs the git binary.
+func isGitCommand(command string) bool {
+	name := strings.TrimSuffix(filepath.Base(command), ".exe")
+
+	return name == gitCommandName
+}
@@ -0,0 +1,67 @@
+package shell
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/gruntwork-io/terragrunt/internal/cache"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/telemetry"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// gitShallowFileName is the file git creates in the `.git` dir of shallow clones, e.g. `git clone --depth=1` in CI.
+const gitShallowFileName = "shallow"
+
+// GitUnshallowIfNeeded fetches the full history of the git repository of the given path with `git fetch --unshallow`
+// if it is a shallow clone, so that older tags are not missed. Nothing is done if the path is not in a git repository.
+// The result is cached per repository for the lifetime of the context.
+func GitUnshallowIfNeeded(ctx context.Context, opts *options.TerragruntOptions, path string) error {
+	gitDir := findGitDir(path)
+	if gitDir == "" || !util.FileExists(filepath.Join(gitDir, gitShallowFileName)) {
+		return nil
+	}
+
+	runCache := cache.ContextCache[string](ctx, RunCmdCacheContextKey)
+	cacheKey := "unshallow-" + gitDir
+
+	_, cacheHit := runCache.Get(ctx, cacheKey)
+
+	return telemetry.Telemetry(ctx, opts, "git_unshallow", map[string]interface{}{
+		"path":      path,
+		"cache_hit": cacheHit,
+	}, func(childCtx context.Context) error {
+		if cacheHit {
+			return nil
+		}
+
+		opts.Logger.Infof("%s is a shallow clone, fetching its full history", filepath.Dir(gitDir))
+
+		if _, err := RunShellCommandAndCapture(childCtx, opts, path, gitCommandName, "fetch", "--unshallow", "--tags"); err != nil {
+			return err
+		}
+
+		runCache.Put(childCtx, cacheKey, gitDir)
+
+		return nil
+	})
+}
+
+// findGitDir returns the `.git` dir of the repository of the given path, or an empty string if there is none. Worktrees
+// and submodules, whose `.git` is a file, are ignored.
+func findGitDir(path string) string {
+	for dir := path; ; {
+		gitDir := filepath.Join(dir, ".git")
+		if info, err := os.Stat(gitDir); err == nil && info.IsDir() {
+			return gitDir
+		}
+
+		parentDir := filepath.Dir(dir)
+		if parentDir == dir {
+			return ""
+		}
+
+		dir = parentDir
+	}
+}
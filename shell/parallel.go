@@ -0,0 +1,78 @@
+package shell
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/hashicorp/go-multierror"
+)
+
+// ShellCommandSpec describes a command run by `RunShellCommandsInParallel`.
+type ShellCommandSpec struct {
+	// Opts, if set, are used to run the command instead of the options passed to `RunShellCommandsInParallel`,
+	// e.g. to set env vars specific to the command.
+	Opts           *options.TerragruntOptions
+	WorkingDir     string
+	Command        string
+	Args           []string
+	SuppressStdout bool
+}
+
+// RunShellCommandsInParallel runs the given commands using a pool of `concurrency` workers and returns their outputs
+// in the same order as the commands. All the commands are run even if some of them fail, the returned error lists all
+// the failures. A concurrency less than 1 runs all the commands at the same time.
+func RunShellCommandsInParallel(ctx context.Context, opts *options.TerragruntOptions, concurrency int, commands []ShellCommandSpec) ([]util.CmdOutput, error) {
+	if concurrency < 1 || concurrency > len(commands) {
+		concurrency = len(commands)
+	}
+
+	var (
+		outputs = make([]util.CmdOutput, len(commands))
+		errs    = make([]error, len(commands))
+		indexes = make(chan int)
+		wg      sync.WaitGroup
+	)
+
+	for range concurrency {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				spec := commands[i]
+
+				cmdOpts := opts
+				if spec.Opts != nil {
+					cmdOpts = spec.Opts
+				}
+
+				output, err := RunShellCommandWithOutput(ctx, cmdOpts, spec.WorkingDir, spec.SuppressStdout, false, false, spec.Command, spec.Args...)
+				if output != nil {
+					outputs[i] = *output
+				}
+
+				errs[i] = err
+			}
+		}()
+	}
+
+	for i := range commands {
+		indexes <- i
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	var errorsOccurred *multierror.Error
+
+	for _, err := range errs {
+		if err != nil {
+			errorsOccurred = multierror.Append(errorsOccurred, err)
+		}
+	}
+
+	return outputs, errorsOccurred.ErrorOrNil()
+}
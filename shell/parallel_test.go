@@ -0,0 +1,39 @@
+package shell_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/shell"
+	"github.com/hashicorp/go-multierror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunShellCommandsInParallel(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err, "Unexpected error creating NewTerragruntOptionsForTest: %v", err)
+
+	commands := []shell.ShellCommandSpec{
+		{Command: "sh", Args: []string{"-c", "sleep 0.1; echo first"}, SuppressStdout: true},
+		{Command: "sh", Args: []string{"-c", "exit 1"}, SuppressStdout: true},
+		{Command: "sh", Args: []string{"-c", "echo third"}, SuppressStdout: true},
+		{Command: "sh", Args: []string{"-c", "exit 2"}, SuppressStdout: true},
+	}
+
+	outputs, err := shell.RunShellCommandsInParallel(context.Background(), terragruntOptions, 2, commands)
+	require.Error(t, err)
+
+	var multiErr *multierror.Error
+	require.True(t, errors.As(err, &multiErr))
+	assert.Len(t, multiErr.Errors, 2)
+
+	require.Len(t, outputs, len(commands))
+	assert.Equal(t, "first", strings.TrimSpace(outputs[0].Stdout))
+	assert.Equal(t, "third", strings.TrimSpace(outputs[2].Stdout))
+}
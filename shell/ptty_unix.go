@@ -17,6 +17,11 @@ import (
 	"github.com/gruntwork-io/terragrunt/options"
 )
 
+const (
+	defaultPTYRows = 24
+	defaultPTYCols = 80
+)
+
 // runCommandWithPTTY will allocate a pseudo-tty to run the subcommand in. This is only necessary when running
 // interactive commands, so that terminal features like readline work through the subcommand when stdin, stdout, and
 // stderr is being shared.
@@ -24,7 +29,7 @@ import (
 func runCommandWithPTTY(terragruntOptions *options.TerragruntOptions, cmd *exec.Cmd, cmdStdout io.Writer, _ io.Writer) (err error) {
 	// NOTE: in order to ensure we can return errors that occur in cleanup, we use a variable binding for the return
 	// value so that it can be updated.
-	pseudoTerminal, startErr := pty.Start(cmd)
+	pseudoTerminal, startErr := startPTY(terragruntOptions, cmd)
 	defer func() {
 		if closeErr := pseudoTerminal.Close(); closeErr != nil {
 			terragruntOptions.Logger.Errorf("Error closing pty: %s", closeErr)
@@ -40,19 +45,21 @@ func runCommandWithPTTY(terragruntOptions *options.TerragruntOptions, cmd *exec.
 		return errors.WithStackTrace(startErr)
 	}
 
-	// Every time the current terminal size changes, we need to make sure the PTY also updates the size.
-	ch := make(chan os.Signal, 1)
-	signal.Notify(ch, syscall.SIGWINCH)
+	// Unless the size is configured, every time the current terminal size changes, we need to make sure the PTY also
+	// updates the size.
+	if terragruntOptions.PTYRows == 0 && terragruntOptions.PTYCols == 0 {
+		ch := make(chan os.Signal, 1)
+		signal.Notify(ch, syscall.SIGWINCH)
 
-	go func() {
-		for range ch {
-			if inheritSizeErr := pty.InheritSize(os.Stdin, pseudoTerminal); inheritSizeErr != nil {
-				// We don't propagate this error upstream because it does not affect normal operation of the command
-				terragruntOptions.Logger.Errorf("error resizing pty: %s", inheritSizeErr)
+		go func() {
+			for range ch {
+				if inheritSizeErr := pty.InheritSize(os.Stdin, pseudoTerminal); inheritSizeErr != nil {
+					// We don't propagate this error upstream because it does not affect normal operation of the command
+					terragruntOptions.Logger.Errorf("error resizing pty: %s", inheritSizeErr)
+				}
 			}
-		}
-	}()
-	ch <- syscall.SIGWINCH // Make sure the pty matches current size
+		}()
+	}
 
 	// Set stdin in raw mode so that we preserve readline properties
 	oldState, setRawErr := term.MakeRaw(int(os.Stdin.Fd()))
@@ -88,6 +95,31 @@ func runCommandWithPTTY(terragruntOptions *options.TerragruntOptions, cmd *exec.
 	return nil
 }
 
+// startPTY starts the command in a new pseudo-terminal, which is resized with `pty.Setsize` before the command starts.
+func startPTY(terragruntOptions *options.TerragruntOptions, cmd *exec.Cmd) (*os.File, error) {
+	return pty.StartWithSize(cmd, ptySize(terragruntOptions))
+}
+
+// ptySize returns the configured PTY dimensions, falling back to the size of the current terminal, or to 24x80 if
+// stdin is not a terminal.
+func ptySize(terragruntOptions *options.TerragruntOptions) *pty.Winsize {
+	size := &pty.Winsize{Rows: defaultPTYRows, Cols: defaultPTYCols}
+
+	if cols, rows, err := term.GetSize(int(os.Stdin.Fd())); err == nil && cols > 0 && rows > 0 {
+		size.Rows, size.Cols = uint16(rows), uint16(cols) //nolint:gosec
+	}
+
+	if terragruntOptions.PTYRows > 0 {
+		size.Rows = terragruntOptions.PTYRows
+	}
+
+	if terragruntOptions.PTYCols > 0 {
+		size.Cols = terragruntOptions.PTYCols
+	}
+
+	return size
+}
+
 func PrepareConsole(terragruntOptions *options.TerragruntOptions) {
 	// No operation function to match windows execution
 }
@@ -0,0 +1,39 @@
+//go:build !windows
+// +build !windows
+
+package shell
+
+import (
+	"bytes"
+	"io"
+	"os/exec"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartPTYSize(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	terragruntOptions.PTYRows = 40
+	terragruntOptions.PTYCols = 132
+
+	cmd := exec.Command("stty", "size")
+
+	pseudoTerminal, err := startPTY(terragruntOptions, cmd)
+	require.NoError(t, err)
+
+	defer pseudoTerminal.Close()
+
+	// Reading from the pty fails with EIO once the command exits and closes its side, so the error is ignored.
+	var output bytes.Buffer
+	_, _ = io.Copy(&output, pseudoTerminal)
+
+	require.NoError(t, cmd.Wait())
+	assert.Equal(t, "40 132", string(bytes.TrimSpace(output.Bytes())))
+}
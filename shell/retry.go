@@ -0,0 +1,60 @@
+package shell
+
+import (
+	"context"
+	"time"
+
+	"github.com/gruntwork-io/go-commons/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// maxRetryBackoff caps the delay returned by RetryBackoff.
+const maxRetryBackoff = time.Minute
+
+// RunTerraformCommandWithRetry runs the given Terraform command up to `maxAttempts` times, as long as its stderr matches
+// any of the `retryableErrors` regular expressions. If `retryableErrors` is empty, only the commands that fail to
+// acquire the state lock are retried. The delay between the attempts doubles each time, starting from
+// `RetrySleepInterval`, and is jittered so that several modules waiting for the same lock don't retry all at once.
+func RunTerraformCommandWithRetry(ctx context.Context, opts *options.TerragruntOptions, maxAttempts int, retryableErrors []string, args ...string) error {
+	if len(retryableErrors) == 0 {
+		retryableErrors = options.DefaultStateLockRetryableErrors
+	}
+
+	for attempt := 1; ; attempt++ {
+		out, err := RunTerraformCommandWithOutput(ctx, opts, args...)
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= maxAttempts || out == nil || !util.MatchesAny(retryableErrors, out.Stderr) {
+			return err
+		}
+
+		backoff := RetryBackoff(opts.RetrySleepInterval, attempt)
+
+		opts.Logger.Infof("Encountered an error eligible for retrying (attempt %d/%d). Sleeping %v before retrying.", attempt, maxAttempts, backoff)
+
+		select {
+		case <-time.After(backoff):
+			// try again
+		case <-ctx.Done():
+			return errors.WithStackTrace(ctx.Err())
+		}
+	}
+}
+
+// RetryBackoff returns a random delay between half and the whole of `interval` doubled for each previous attempt.
+func RetryBackoff(interval time.Duration, attempt int) time.Duration {
+	backoff := interval
+
+	for i := 1; i < attempt && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+
+	return util.GetRandomTime(backoff/2, backoff) //nolint:mnd
+}
@@ -11,7 +11,11 @@ import (
 	"os/exec"
 	"os/signal"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/gruntwork-io/terragrunt/internal/cache"
@@ -26,8 +30,10 @@ import (
 	"github.com/gruntwork-io/terragrunt/telemetry"
 
 	"github.com/hashicorp/go-version"
+	"golang.org/x/term"
 
 	"github.com/gruntwork-io/go-commons/collections"
+	"github.com/gruntwork-io/go-commons/env"
 	"github.com/gruntwork-io/go-commons/errors"
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/util"
@@ -42,13 +48,33 @@ import (
 // if it receives the signal directly from the shell, to avoid sending the second interrupt signal to `tofu`/`terraform`.
 const SignalForwardingDelay = time.Second * 30
 
+// DefaultSignalForwardingDelays are the per-signal forwarding delays used unless `SignalForwardingDelays` is set in the
+// options. SIGTERM usually means that the container is about to be stopped, so it is forwarded much sooner than SIGINT.
+var DefaultSignalForwardingDelays = map[os.Signal]time.Duration{
+	syscall.SIGINT:  SignalForwardingDelay,
+	syscall.SIGTERM: time.Second * 5,
+}
+
 const (
 	gitPrefix = "git::"
 	refsTags  = "refs/tags/"
+	refsHeads = "refs/heads/"
+
+	gitLsRemoteTagsFlag  = "--tags"
+	gitLsRemoteHeadsFlag = "--heads"
+
+	// peeledTagSuffix ends the refs of the commits that annotated tags point to in the output of `git ls-remote`.
+	peeledTagSuffix = "^{}"
 
 	tagSplitPart = 2
 
+	gitStatusPathOffset = 3
+
 	logMsgSeparator = "\n"
+
+	// outputToFileStderrExcerptSize is the number of leading stderr bytes of a failed `RunShellCommandWithOutputToFile`
+	// command kept in the returned error.
+	outputToFileStderrExcerptSize = 4096
 )
 
 const (
@@ -59,6 +85,46 @@ const (
 	tfLogMsgPrefix = "TF_LOG: "
 )
 
+// sensitiveEnvVarSuffixes are the suffixes of the env var names that are redacted in the debug log.
+var sensitiveEnvVarSuffixes = []string{"_TOKEN", "_SECRET", "_PASSWORD"}
+
+// gitSSHAuthErrors are the regular expressions that match the stderr of the git commands that fail to authenticate over
+// SSH, after which `GitRepoTags` retries with the HTTPS URL of the repository.
+var gitSSHAuthErrors = []string{
+	`Permission denied \(publickey`,
+	`Host key verification failed`,
+	`Could not read from remote repository`,
+}
+
+// redactedEnvVarName replaces the names of sensitive env vars in the debug log.
+const redactedEnvVarName = "<redacted>"
+
+// commandLocks serializes the execution of commands that share the same `LockKey`.
+var commandLocks = util.NewKeyLocks()
+
+// stdinHasDataCache holds the result of `stdinHasData`.
+var stdinHasDataCache struct {
+	once    sync.Once
+	hasData bool
+	err     error
+}
+
+// terraformCommandsWithNoColor are the terraform commands that accept the `-no-color` flag.
+var terraformCommandsWithNoColor = []string{
+	terraform.CommandNameInit,
+	terraform.CommandNameValidate,
+	terraform.CommandNamePlan,
+	terraform.CommandNameApply,
+	terraform.CommandNameDestroy,
+	terraform.CommandNameRefresh,
+	terraform.CommandNameImport,
+	terraform.CommandNameOutput,
+	terraform.CommandNameShow,
+	terraform.CommandNameGet,
+	terraform.CommandNameTaint,
+	terraform.CommandNameUntaint,
+}
+
 // Commands that implement a REPL need a pseudo TTY when run as a subprocess in order for the readline properties to be
 // preserved. This is a list of terraform commands that have this property, which is used to determine if terragrunt
 // should allocate a ptty when running that terraform command.
@@ -68,53 +134,227 @@ var terraformCommandsThatNeedPty = []string{
 
 // RunTerraformCommand runs the given Terraform command.
 func RunTerraformCommand(ctx context.Context, terragruntOptions *options.TerragruntOptions, args ...string) error {
-	needPTY, err := isTerraformCommandThatNeedsPty(args)
-	if err != nil {
-		return err
+	_, err := RunTerraformCommandWithOutput(ctx, terragruntOptions, args...)
+
+	return err
+}
+
+// withTerraformInitArgs appends the `TerraformInitArgs` that are not already present to the args of the `init` command.
+func withTerraformInitArgs(opts *options.TerragruntOptions, args []string) []string {
+	if len(opts.TerraformInitArgs) == 0 || util.FirstArg(args) != terraform.CommandNameInit {
+		return args
 	}
 
-	_, err = RunShellCommandWithOutput(ctx, terragruntOptions, "", false, needPTY, terragruntOptions.TerraformPath, args...)
+	initArgs := util.CloneStringList(args)
 
-	return err
+	for _, arg := range opts.TerraformInitArgs {
+		if !util.ListContainsElement(initArgs, arg) {
+			initArgs = append(initArgs, arg)
+		}
+	}
+
+	return initArgs
+}
+
+// logSensitivePatterns compiles the patterns of the secrets redacted from the logged output, if `LogStripSensitive` is
+// set.
+func logSensitivePatterns(opts *options.TerragruntOptions) ([]*regexp.Regexp, error) {
+	if !opts.LogStripSensitive {
+		return nil, nil
+	}
+
+	patterns := make([]*regexp.Regexp, 0, len(opts.LogSensitivePatterns))
+
+	for _, pattern := range opts.LogSensitivePatterns {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.WithStackTrace(err)
+		}
+
+		patterns = append(patterns, compiled)
+	}
+
+	return patterns, nil
+}
+
+// tfLogParseFunc returns the function that parses the TF_LOG records of the stderr of terraform. The records keep their
+// own level, unless `TFLogLevel` is set.
+func tfLogParseFunc(opts *options.TerragruntOptions) writer.WriterParseFunc {
+	if opts.TFLogLevel == nil {
+		return terraform.ParseLogFunc(tfLogMsgPrefix, false)
+	}
+
+	return terraform.ParseLogFuncWithLevel(tfLogMsgPrefix, false, *opts.TFLogLevel)
+}
+
+// withNoColorArg inserts `-no-color` right after the command name if `DisableLogColors` is set and the command
+// supports it. It is inserted before the other args, since the flags after the positional args, such as the plan file
+// of `apply`, are not parsed.
+func withNoColorArg(opts *options.TerragruntOptions, args []string) []string {
+	if !opts.DisableLogColors || !util.ListContainsElement(terraformCommandsWithNoColor, util.FirstArg(args)) || util.ListContainsElement(args, terraform.FlagNameNoColor) {
+		return args
+	}
+
+	noColorArgs := make([]string, 0, len(args)+1)
+	noColorArgs = append(noColorArgs, args[0], terraform.FlagNameNoColor)
+
+	return append(noColorArgs, args[1:]...)
+}
+
+// withAutoTFLog returns a copy of the options with `TF_LOG` set to `DEBUG` or `TRACE` if `AutoTFLog` is set, the log
+// level is debug or trace, and `TF_LOG` is not already set. Otherwise, the options are returned as is. Only `Env` is
+// copied, so the copy keeps the `WorkingDir` of the given options, e.g. the `.terragrunt-cache` folder of a remote source.
+func withAutoTFLog(opts *options.TerragruntOptions) *options.TerragruntOptions {
+	var tfLog string
+
+	switch opts.LogLevel {
+	case log.DebugLevel:
+		tfLog = "DEBUG"
+	case log.TraceLevel:
+		tfLog = "TRACE"
+	default:
+		return opts
+	}
+
+	if !opts.AutoTFLog {
+		return opts
+	}
+
+	if _, ok := opts.Env[terraform.EnvNameTFLog]; ok {
+		return opts
+	}
+
+	if _, ok := os.LookupEnv(terraform.EnvNameTFLog); ok && opts.InheritEnv {
+		return opts
+	}
+
+	tfLogOpts := *opts
+	tfLogOpts.Env = util.CloneStringMap(opts.Env)
+	tfLogOpts.Env[terraform.EnvNameTFLog] = tfLog
+
+	return &tfLogOpts
 }
 
 // RunShellCommand runs the given shell command.
 func RunShellCommand(ctx context.Context, terragruntOptions *options.TerragruntOptions, command string, args ...string) error {
-	_, err := RunShellCommandWithOutput(ctx, terragruntOptions, "", false, false, command, args...)
+	_, err := RunShellCommandWithOutput(ctx, terragruntOptions, "", false, false, false, command, args...)
 	return err
 }
 
 // RunTerraformCommandWithOutput runs the given Terraform command, writing its stdout/stderr to the terminal AND returning stdout/stderr to this
 // method's caller
 func RunTerraformCommandWithOutput(ctx context.Context, terragruntOptions *options.TerragruntOptions, args ...string) (*util.CmdOutput, error) {
-	needPTY, err := isTerraformCommandThatNeedsPty(args)
+	if err := checkTerraformBinary(ctx, terragruntOptions, args); err != nil {
+		return nil, err
+	}
+
+	args = withNoColorArg(terragruntOptions, withTerraformInitArgs(terragruntOptions, args))
+
+	terragruntOptions = withAutoTFLog(terragruntOptions)
+
+	needPTY, err := isTerraformCommandThatNeedsPty(terragruntOptions, args)
 	if err != nil {
 		return nil, err
 	}
 
-	return RunShellCommandWithOutput(ctx, terragruntOptions, "", false, needPTY, terragruntOptions.TerraformPath, args...)
+	return RunShellCommandWithOutputAndEnvCapture(ctx, terragruntOptions, "", false, terragruntOptions.SuppressStderr, needPTY, terragruntOptions.TerraformPath, args...)
 }
 
 // RunShellCommandWithOutput runs the specified shell command with the specified arguments.
 //
 // Connect the command's stdin, stdout, and stderr to
 // the currently running app. The command can be executed in a custom working directory by using the parameter
-// `workingDir`. Terragrunt working directory will be assumed if empty string.
+// `workingDir`. Terragrunt working directory will be assumed if empty string. The stdout and stderr suppressed by
+// `suppressStdout` and `suppressStderr` are not displayed, but they are still captured in the returned output.
+// The `CommandHooks` of the options are called before and after the command. If `ErrorHints` is set, the remediation
+// hints that match the stderr of a failed command are appended to its error.
 func RunShellCommandWithOutput(
 	ctx context.Context,
 	opts *options.TerragruntOptions,
 	workingDir string,
 	suppressStdout bool,
+	suppressStderr bool,
 	allocatePseudoTty bool,
 	command string,
 	args ...string,
 ) (*util.CmdOutput, error) {
+	return runShellCommandWithHooks(ctx, opts, workingDir, suppressStdout, suppressStderr, allocatePseudoTty, nil, command, args...)
+}
+
+// outputFiles are the files the stdout and stderr of a command are written to, instead of being displayed and
+// captured in memory.
+type outputFiles struct {
+	outFile string
+	errFile string
+}
+
+// runShellCommandWithHooks runs the command, surrounded by the `CommandHooks` of the options, and appends the error
+// hints to its error. If `files` is not nil, the output of the command is written to these files.
+func runShellCommandWithHooks(
+	ctx context.Context,
+	opts *options.TerragruntOptions,
+	workingDir string,
+	suppressStdout bool,
+	suppressStderr bool,
+	allocatePseudoTty bool,
+	files *outputFiles,
+	command string,
+	args ...string,
+) (*util.CmdOutput, error) {
+	for _, hook := range opts.CommandHooks {
+		hook.Before(ctx, command, args)
+	}
+
+	output, err := runShellCommandWithOutput(ctx, opts, workingDir, suppressStdout, suppressStderr, allocatePseudoTty, files, command, args...)
+	if err != nil && opts.ErrorHints && output != nil {
+		err = withErrorHints(err, output.Stderr)
+	}
+
+	for _, hook := range opts.CommandHooks {
+		hook.After(ctx, output, err)
+	}
+
+	return output, err
+}
+
+func runShellCommandWithOutput(
+	ctx context.Context,
+	opts *options.TerragruntOptions,
+	workingDir string,
+	suppressStdout bool,
+	suppressStderr bool,
+	allocatePseudoTty bool,
+	files *outputFiles,
+	command string,
+	args ...string,
+) (*util.CmdOutput, error) {
+	// Do not launch a new process if the context has already been cancelled, e.g. during an orderly shutdown of `run-all`.
+	if err := ctx.Err(); err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
 	if command == opts.TerraformPath {
 		if fn := TerraformCommandHookFromContext(ctx); fn != nil {
 			return fn(ctx, opts, args)
 		}
 	}
 
+	if fn := ShellCommandHookFromContext(ctx); fn != nil {
+		return fn(ctx, opts, command, args)
+	}
+
+	if mock := commandMock(opts, command); mock != nil {
+		return mock(ctx, command, args)
+	}
+
+	if isGitCommand(command) {
+		release, err := acquireGitSlot(ctx, opts.GitMaxConcurrency)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+
 	var (
 		output     *util.CmdOutput = nil
 		commandDir                 = workingDir
@@ -124,98 +364,162 @@ func RunShellCommandWithOutput(
 		commandDir = opts.WorkingDir
 	}
 
+	cmdLogger := opts.Logger.WithFields(ContextLogFields(ctx))
+	startedAt := time.Now()
+
 	err := telemetry.Telemetry(ctx, opts, "run_"+command, map[string]interface{}{
 		"command": command,
 		"args":    fmt.Sprintf("%v", args),
 		"dir":     commandDir,
 	}, func(childCtx context.Context) error {
-		opts.Logger.Debugf("Running command: %s %s", command, strings.Join(args, " "))
+		if skipInDryRun(opts, command, args) {
+			var redirect string
+			if files != nil {
+				redirect = fmt.Sprintf(" > %s 2> %s", files.outFile, files.errFile)
+			}
+
+			cmdLogger.Infof("Dry run: %s%s in %s", dryRunCommand(toEnvVarsList(opts.InheritEnv, opts.Env, opts.EnvOverrides[commandDir]), command, args), redirect, commandDir)
+
+			output = &util.CmdOutput{}
+
+			// the engine is still started, to check that it is reachable, but it doesn't run the command
+			if command == opts.TerraformPath && opts.Engine != nil && engine.IsEngineEnabled(ctx, opts) {
+				if _, err := engine.Run(childCtx, engineExecutionOptions(opts, subprocessDir(opts, commandDir), io.Discard, io.Discard, command, args)); err != nil {
+					return errors.WithStackTrace(err)
+				}
+			}
+
+			return nil
+		}
+
+		if files != nil {
+			cmdLogger.Debugf("Running command: %s %s, writing the output to %s and %s", command, strings.Join(args, " "), files.outFile, files.errFile)
+		} else {
+			cmdLogger.Debugf("Running command: %s %s", command, strings.Join(args, " "))
+		}
 
 		cmd := exec.Command(command, args...)
 
 		// TODO: consider adding prefix from opts logger to stdout and stderr
-		cmd.Env = toEnvVarsList(opts.Env)
-		cmd.Dir = commandDir
+		cmd.Env = toEnvVarsList(opts.InheritEnv, opts.Env, opts.EnvOverrides[commandDir])
+		cmd.Dir = subprocessDir(opts, commandDir)
+
+		if err := appendCommandAuditLog(opts, commandDir, command, args, cmd.Env); err != nil {
+			return err
+		}
+
+		if envVarNames := injectedEnvVarNames(cmd.Env); len(envVarNames) > 0 {
+			cmdLogger.Debugf("Command env vars: %s", strings.Join(envVarNames, ", "))
+		}
 
 		var (
-			outWriter = opts.Writer
-			errWriter = opts.ErrWriter
+			cmdStdout, cmdStderr io.Writer
+			// capturedOutput returns the stdout and stderr of the command kept in the output and in the error
+			capturedOutput func() (string, string)
 		)
 
-		// redirect output through logger with json wrapping
-		if opts.JSONLogFormat && opts.TerraformLogsToJSON {
-			logger := opts.Logger.WithField("workingDir", opts.WorkingDir).WithField("executedCommandArgs", args)
-			outWriter = logger.WithOptions(log.WithOutput(errWriter)).Writer()
-			errWriter = logger.WithOptions(log.WithOutput(errWriter)).WriterLevel(log.ErrorLevel)
-		} else if command == opts.TerraformPath {
-			if opts.ForwardTFStdout || shouldForceForwardTFStdout(args) {
-				// We only display the output receipt notification when we show it to the user, and do nothing when we hide it, for example when `outWriter` is io.Discard.
-				if _, ok := outWriter.(*os.File); ok {
-					outWriter = util.WriterNotifier(outWriter, func(p []byte) {
-						opts.Logger.Infof("Retrieved output from %s", opts.TerraformPath)
-					})
+		if files != nil {
+			stdoutFile, err := createOutputFile(files.outFile)
+			if err != nil {
+				return err
+			}
+			defer stdoutFile.Close() //nolint:errcheck
+
+			stderrFile, err := createOutputFile(files.errFile)
+			if err != nil {
+				return err
+			}
+			defer stderrFile.Close() //nolint:errcheck
+
+			cmdStdout, cmdStderr = stdoutFile, stderrFile
+
+			capturedOutput = func() (string, string) {
+				stderrExcerpt, err := readFileHead(files.errFile, outputToFileStderrExcerptSize)
+				if err != nil {
+					cmdLogger.Warnf("Failed to read the stderr of %s from %s: %v", command, files.errFile, err)
 				}
-			} else {
-				logger := opts.Logger.WithField(format.TFBinaryKeyName, filepath.Base(opts.TerraformPath))
-
-				outWriter = writer.New(
-					writer.WithLogger(logger.WithOptions(log.WithOutput(errWriter))),
-					writer.WithDefaultLevel(log.StdoutLevel),
-					writer.WithMsgSeparator(logMsgSeparator),
-				)
-
-				errWriter = writer.New(
-					writer.WithLogger(logger.WithOptions(log.WithOutput(errWriter))),
-					writer.WithDefaultLevel(log.StderrLevel),
-					writer.WithMsgSeparator(logMsgSeparator),
-					writer.WithParseFunc(terraform.ParseLogFunc(tfLogMsgPrefix, false)),
-				)
+
+				return "", stderrExcerpt
 			}
-		}
+		} else {
+			outWriter, errWriter, closeWriters, err := commandOutputWriters(opts, cmdLogger, command, args)
+			if err != nil {
+				return err
+			}
+			defer closeWriters()
 
-		var (
-			stdoutBuf bytes.Buffer
-			stderrBuf bytes.Buffer
+			var (
+				stderrBuf = util.NewTeeBuffer(errWriter)
+				stdoutBuf = util.NewTeeBuffer(outWriter)
+			)
 
-			cmdStderr = io.MultiWriter(errWriter, &stderrBuf)
-			cmdStdout = io.MultiWriter(outWriter, &stdoutBuf)
-		)
+			if suppressStdout {
+				cmdLogger.Debugf("Command output will be suppressed.")
+
+				stdoutBuf = util.NewTeeBuffer()
+			}
+
+			if suppressStderr {
+				cmdLogger.Debugf("Command stderr will be suppressed.")
+
+				stderrBuf = util.NewTeeBuffer()
+			}
 
-		if suppressStdout {
-			opts.Logger.Debugf("Command output will be suppressed.")
+			cmdStdout, cmdStderr = stdoutBuf, stderrBuf
 
-			cmdStdout = io.MultiWriter(&stdoutBuf)
+			capturedOutput = func() (string, string) {
+				return stdoutBuf.String(), stderrBuf.String()
+			}
 		}
 
-		if command == opts.TerraformPath && opts.Engine != nil && !engine.IsEngineEnabled() {
-			opts.Logger.Debugf("Engine is not enabled, running command directly in %s", commandDir)
+		if command == opts.TerraformPath && opts.Engine != nil && !engine.IsEngineEnabled(ctx, opts) {
+			cmdLogger.Debugf("Engine is not enabled, running command directly in %s", commandDir)
 		}
 
-		useEngine := opts.Engine != nil && engine.IsEngineEnabled()
+		useEngine := opts.Engine != nil && engine.IsEngineEnabled(ctx, opts)
 
 		// If the engine is enabled and the command is IaC executable, use the engine to run the command.
 		if useEngine && command == opts.TerraformPath {
-			opts.Logger.Debugf("Using engine to run command: %s %s", command, strings.Join(args, " "))
-
-			cmdOutput, err := engine.Run(ctx, &engine.ExecutionOptions{
-				TerragruntOptions: opts,
-				CmdStdout:         cmdStdout,
-				CmdStderr:         cmdStderr,
-				WorkingDir:        cmd.Dir,
-				SuppressStdout:    suppressStdout,
-				AllocatePseudoTty: allocatePseudoTty,
-				Command:           command,
-				Args:              args,
-			})
+			cmdLogger.Debugf("Using engine to run command: %s %s", command, strings.Join(args, " "))
+
+			// The output of the command is the feedback of a long run, the spinner is only shown if it is suppressed.
+			var progressCallback engine.ProgressCallback
+			if suppressStdout && !opts.NonInteractive && term.IsTerminal(int(os.Stderr.Fd())) {
+				progressCallback = engine.SpinnerProgressCallback(opts.ErrWriter)
+			}
+
+			execOptions := engineExecutionOptions(opts, cmd.Dir, cmdStdout, cmdStderr, command, args)
+			execOptions.SuppressStdout = suppressStdout
+			execOptions.AllocatePseudoTty = allocatePseudoTty
+			execOptions.ProgressCallback = progressCallback
+
+			result, err := engine.Run(ctx, execOptions)
 			if err != nil {
 				return errors.WithStackTrace(err)
 			}
 
-			output = cmdOutput
+			output = &result.CmdOutput
+
+			completionLogger := cmdLogger
+			if opts.JSONLogFormat {
+				completionLogger = cmdLogger.WithField("duration_ms", output.Duration.Milliseconds())
+
+				if result.Usage != nil {
+					completionLogger = completionLogger.WithFields(log.Fields{
+						"engine_max_rss_bytes": result.Usage.MaxRSSBytes,
+						"engine_user_cpu_ms":   result.Usage.UserCPUTime.Milliseconds(),
+						"engine_system_cpu_ms": result.Usage.SystemCPUTime.Milliseconds(),
+					})
+				}
+			}
+
+			completionLogger.Debugf("Command %s finished in %s", command, output.Duration)
 
 			return err
 		}
 
+		cmdStartedAt := time.Now()
+
 		// If we need to allocate a ptty for the command, route through the ptty routine. Otherwise, directly call the
 		// command.
 		if allocatePseudoTty {
@@ -224,6 +528,17 @@ func RunShellCommandWithOutput(
 			}
 		} else {
 			cmd.Stdin = os.Stdin
+
+			if opts.ConsoleInput != "" && command == opts.TerraformPath && len(args) > 0 && util.ListContainsElement(terraformCommandsThatNeedPty, args[0]) {
+				consoleInput, err := os.Open(opts.ConsoleInput)
+				if err != nil {
+					return errors.WithStackTrace(err)
+				}
+				defer consoleInput.Close() //nolint:errcheck
+
+				cmd.Stdin = consoleInput
+			}
+
 			cmd.Stdout = cmdStdout
 			cmd.Stderr = cmdStderr
 
@@ -235,29 +550,48 @@ func RunShellCommandWithOutput(
 
 		// Make sure to forward signals to the subcommand.
 		cmdChannel := make(chan error) // used for closing the signals forwarder goroutine
-		signalChannel := NewSignalsForwarder(InterruptSignals, cmd, opts.Logger, cmdChannel)
+		signalForwardingDelays := opts.SignalForwardingDelays
+		if signalForwardingDelays == nil {
+			signalForwardingDelays = DefaultSignalForwardingDelays
+		}
+
+		signalChannel := newSignalsForwarder(opts.SignalsToForward, signalForwardingDelays, opts.SignalEscalationDelay, cmd, cmdLogger, cmdChannel)
 
 		defer func(signalChannel *SignalsForwarder) {
 			err := signalChannel.Close()
 			if err != nil {
-				opts.Logger.Warnf("Error closing signal channel: %v", err)
+				cmdLogger.Warnf("Error closing signal channel: %v", err)
 			}
 		}(&signalChannel)
 
+		stopTermination := terminateOnCancel(ctx, opts, cmd, cmdLogger)
+
 		err := cmd.Wait()
 		cmdChannel <- err
 
+		stopTermination()
+
+		stdout, stderr := capturedOutput()
+
 		output = &util.CmdOutput{
-			Stdout: stdoutBuf.String(),
-			Stderr: stderrBuf.String(),
+			Stdout:   stdout,
+			Stderr:   stderr,
+			Duration: time.Since(cmdStartedAt),
+		}
+
+		completionLogger := cmdLogger
+		if opts.JSONLogFormat {
+			completionLogger = cmdLogger.WithField("duration_ms", output.Duration.Milliseconds())
 		}
 
+		completionLogger.Debugf("Command %s finished in %s", command, output.Duration)
+
 		if err != nil {
-			opts.Logger.Warnf("Failed to execute %s in %s\n%s\n%s\n%v", command+" "+strings.Join(args, " "), cmd.Dir, stdoutBuf.String(), stderrBuf.String(), err)
+			cmdLogger.Warnf("Failed to execute %s in %s\n%s\n%s\n%v", command+" "+strings.Join(args, " "), cmd.Dir, stdout, stderr, err)
 			err = util.ProcessExecutionError{
 				Err:        err,
-				Stdout:     stdoutBuf.String(),
-				Stderr:     stderrBuf.String(),
+				Stdout:     stdout,
+				Stderr:     stderr,
 				WorkingDir: cmd.Dir,
 			}
 		}
@@ -265,12 +599,440 @@ func RunShellCommandWithOutput(
 		return errors.WithStackTrace(err)
 	})
 
+	if !skipInDryRun(opts, command, args) {
+		if auditErr := writeAuditTrail(opts, startedAt, commandDir, command, args, output, err); auditErr != nil {
+			cmdLogger.Warnf("Failed to write the audit trail entry of %s: %v", command, auditErr)
+		}
+
+		if outputErr := writeCommandOutput(opts, startedAt, commandDir, command, args, output); outputErr != nil {
+			cmdLogger.Warnf("Failed to write the output of %s: %v", command, outputErr)
+		}
+
+		recordCommandSummary(opts, startedAt, commandDir, command, args, output, err)
+	}
+
 	return output, err
 }
 
-func toEnvVarsList(envVarsAsMap map[string]string) []string {
-	envVarsAsList := []string{}
+// engineExecutionOptions returns the options to run the command with the engine in the given directory, writing its
+// output to the given writers.
+func engineExecutionOptions(opts *options.TerragruntOptions, workingDir string, stdout, stderr io.Writer, command string, args []string) *engine.ExecutionOptions {
+	return &engine.ExecutionOptions{
+		TerragruntOptions: opts,
+		CmdStdout:         stdout,
+		CmdStderr:         stderr,
+		WorkingDir:        workingDir,
+		Command:           command,
+		Args:              args,
+		PluginGRPCOptions: &engine.PluginGRPCOptions{
+			MaxRecvMsgSizeBytes: opts.EngineGRPCMaxMessageSize,
+			MaxSendMsgSizeBytes: opts.EngineGRPCMaxMessageSize,
+		},
+		Timeout: opts.EngineTimeout,
+		Meta:    opts.EngineMeta,
+	}
+}
+
+// commandOutputWriters returns the writers that display the stdout and stderr of the command. The output of
+// OpenTofu/Terraform is integrated into the Terragrunt log, unless it is forwarded as is. The returned function logs
+// the output held back by the writers and closes the TF_LOG file, if any, and must be called once the command has
+// finished.
+func commandOutputWriters(opts *options.TerragruntOptions, cmdLogger log.Logger, command string, args []string) (io.Writer, io.Writer, func(), error) {
+	var (
+		outWriter = opts.Writer
+		errWriter = opts.ErrWriter
+		noop      = func() {}
+	)
+
+	// redirect output through logger with json wrapping
+	if opts.JSONLogFormat && opts.TerraformLogsToJSON {
+		logger := opts.Logger.WithField(format.WorkingDirKeyName, opts.WorkingDir).WithField("executedCommandArgs", args)
+		outWriter = logger.WithOptions(log.WithOutput(errWriter)).Writer()
+		errWriter = logger.WithOptions(log.WithOutput(errWriter)).WriterLevel(log.ErrorLevel)
+
+		return outWriter, errWriter, noop, nil
+	}
+
+	if command != opts.TerraformPath {
+		return outWriter, errWriter, noop, nil
+	}
+
+	if opts.ForwardTFStdout || shouldForceForwardTFStdout(opts, args) {
+		// We only display the output receipt notification when we show it to the user, and do nothing when we hide it, for example when `outWriter` is io.Discard.
+		if _, ok := util.UnwrapWriter(outWriter).(*os.File); ok {
+			outWriter = util.WriterNotifier(outWriter, func(p []byte) {
+				cmdLogger.Infof("Retrieved output from %s", opts.TerraformPath)
+			})
+		}
+
+		return outWriter, errWriter, noop, nil
+	}
+
+	logger := opts.Logger.WithField(format.TFBinaryKeyName, filepath.Base(opts.TerraformPath))
+
+	var timestampLayout string
+	if opts.OutputPrefixTimestamp {
+		timestampLayout = opts.OutputTimestampFormat
+	}
+
+	sensitivePatterns, err := logSensitivePatterns(opts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	outLogWriter := writer.New(
+		writer.WithLogger(logger.WithOptions(log.WithOutput(errWriter))),
+		writer.WithDefaultLevel(log.StdoutLevel),
+		writer.WithMsgSeparator(logMsgSeparator),
+		writer.WithRemoveANSI(opts.DisableLogColors),
+		writer.WithTimestampPrefix(timestampLayout),
+		writer.WithSensitivePatterns(sensitivePatterns),
+	)
+
+	errLogWriter := writer.New(
+		writer.WithLogger(logger.WithOptions(log.WithOutput(errWriter))),
+		writer.WithDefaultLevel(log.StderrLevel),
+		writer.WithMsgSeparator(logMsgSeparator),
+		writer.WithRemoveANSI(opts.DisableLogColors),
+		writer.WithTimestampPrefix(timestampLayout),
+		writer.WithSensitivePatterns(sensitivePatterns),
+		writer.WithParseFunc(tfLogParseFunc(opts)),
+	)
+
+	// the lines held back to redact the secrets are logged once the command has finished
+	flushLogWriters := func() {
+		outLogWriter.Flush() //nolint:errcheck
+		errLogWriter.Flush() //nolint:errcheck
+	}
+
+	// duplicate TF_LOG output to the file, if specified
+	if opts.TFLogFile == "" {
+		return outLogWriter, errLogWriter, flushLogWriters, nil
+	}
+
+	const ownerWriteGlobalReadPerms = 0644
+
+	tfLogFile, err := os.OpenFile(opts.TFLogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, ownerWriteGlobalReadPerms)
+	if err != nil {
+		return nil, nil, nil, errors.WithStackTrace(err)
+	}
+
+	tfLogWriter := &tfLogFileWriter{writer: tfLogFile}
+
+	closeTFLogFile := func() {
+		flushLogWriters()
+		tfLogWriter.Flush() //nolint:errcheck
+		tfLogFile.Close()   //nolint:errcheck
+	}
+
+	return outLogWriter, io.MultiWriter(errLogWriter, tfLogWriter), closeTFLogFile, nil
+}
+
+// tfLogFileWriter writes only TF_LOG output lines, prefixed with `tfLogMsgPrefix`, to the underlying writer. A line
+// split across several writes is only parsed once its newline is written, or when the writer is flushed.
+type tfLogFileWriter struct {
+	writer io.Writer
+	// partial is the last line written, until its newline arrives.
+	partial []byte
+}
+
+// Write implements `io.Writer` interface. The other lines of the output are dropped, but still reported as written.
+func (tfLogWriter *tfLogFileWriter) Write(p []byte) (int, error) {
+	tfLogWriter.partial = append(tfLogWriter.partial, p...)
+
+	for {
+		i := bytes.IndexByte(tfLogWriter.partial, '\n')
+		if i < 0 {
+			break
+		}
+
+		line := string(tfLogWriter.partial[:i])
+		tfLogWriter.partial = tfLogWriter.partial[i+1:]
+
+		if err := tfLogWriter.writeLine(line); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+// Flush writes the last line, if it is not terminated by a newline.
+func (tfLogWriter *tfLogFileWriter) Flush() error {
+	if len(tfLogWriter.partial) == 0 {
+		return nil
+	}
+
+	line := string(tfLogWriter.partial)
+	tfLogWriter.partial = nil
+
+	return tfLogWriter.writeLine(line)
+}
+
+func (tfLogWriter *tfLogFileWriter) writeLine(line string) error {
+	if _, _, _, err := terraform.ParseLog(line); err != nil {
+		return nil
+	}
+
+	if _, err := fmt.Fprint(tfLogWriter.writer, tfLogMsgPrefix+line+logMsgSeparator); err != nil {
+		return errors.WithStackTrace(err)
+	}
+
+	return nil
+}
+
+// RunShellCommandWithOutputAndMutex runs the specified shell command in the same way as `RunShellCommandWithOutput`,
+// but if `opts.LockKey` is set, the command is serialized with all other commands sharing the same lock key.
+func RunShellCommandWithOutputAndMutex(
+	ctx context.Context,
+	opts *options.TerragruntOptions,
+	workingDir string,
+	suppressStdout bool,
+	suppressStderr bool,
+	allocatePseudoTty bool,
+	command string,
+	args ...string,
+) (*util.CmdOutput, error) {
+	if opts.LockKey != "" {
+		opts.Logger.Debugf("Acquiring lock %q to run command: %s %s", opts.LockKey, command, strings.Join(args, " "))
+
+		commandLocks.Lock(opts.LockKey)
+		defer commandLocks.Unlock(opts.LockKey)
+	}
+
+	return RunShellCommandWithOutput(ctx, opts, workingDir, suppressStdout, suppressStderr, allocatePseudoTty, command, args...)
+}
+
+// RunShellCommandWithEnv runs the specified shell command in the same way as `RunShellCommandWithOutput`, with the
+// `extraEnv` env vars added to `opts.Env`, e.g. to set `TF_WORKSPACE` for a single command. Like the rest of `opts.Env`,
+// the extra env vars are overridden by the `opts.EnvOverrides` of the command dir. The given `opts` are not modified.
+func RunShellCommandWithEnv(
+	ctx context.Context,
+	opts *options.TerragruntOptions,
+	extraEnv map[string]string,
+	workingDir string,
+	suppressStdout bool,
+	allocatePseudoTty bool,
+	command string,
+	args ...string,
+) (*util.CmdOutput, error) {
+	envOpts := *opts
+	envOpts.Env = util.CloneStringMap(opts.Env)
+
+	for key, value := range extraEnv {
+		envOpts.Env[key] = value
+	}
+
+	return RunShellCommandWithOutput(ctx, &envOpts, workingDir, suppressStdout, false, allocatePseudoTty, command, args...)
+}
+
+// RunShellCommandAndCapture runs the specified shell command in the same way as `RunShellCommandWithOutput`, but the
+// stdout and stderr of the command are only captured and returned, they are never written to `opts.Writer` or `opts.ErrWriter`.
+func RunShellCommandAndCapture(
+	ctx context.Context,
+	opts *options.TerragruntOptions,
+	workingDir string,
+	command string,
+	args ...string,
+) (*util.CmdOutput, error) {
+	captureOpts, err := opts.Clone(opts.TerragruntConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	captureOpts.WorkingDir = opts.WorkingDir
+	captureOpts.Writer = io.Discard
+	captureOpts.ErrWriter = io.Discard
+
+	return RunShellCommandWithOutput(ctx, captureOpts, workingDir, true, false, false, command, args...)
+}
+
+// RunShellCommandWithOutputToFile runs the specified shell command with its stdout and stderr written directly to
+// the `outFile` and `errFile` files instead of being buffered in memory, which is useful for commands with a large
+// output, such as `show -json` of a large state. Apart from the output, the command is run as with
+// `RunShellCommandWithOutput`. If the command fails, the returned `util.ProcessExecutionError` only holds the first
+// `outputToFileStderrExcerptSize` bytes of the stderr.
+func RunShellCommandWithOutputToFile(
+	ctx context.Context,
+	opts *options.TerragruntOptions,
+	workingDir string,
+	outFile string,
+	errFile string,
+	command string,
+	args ...string,
+) error {
+	_, err := runShellCommandWithHooks(ctx, opts, workingDir, false, false, false, &outputFiles{outFile: outFile, errFile: errFile}, command, args...)
+
+	return err
+}
+
+// commandMock returns the mock of `CommandMocks` that replaces the given command, matched by its name or by the base
+// name of its path, or nil if there is none.
+func commandMock(opts *options.TerragruntOptions, command string) options.CommandMockFunc {
+	if mock, ok := opts.CommandMocks[command]; ok {
+		return mock
+	}
+
+	return opts.CommandMocks[filepath.Base(command)]
+}
+
+// skipInDryRun returns true if the command is not run because `DryRun` is set. Only the OpenTofu/Terraform commands
+// that change the state or the infrastructure, such as `apply`, and the commands that are neither OpenTofu/Terraform
+// nor git, such as hooks, are skipped. The read-only commands, such as `--version`, `output -json` or
+// `git ls-remote`, still run, since Terragrunt parses their output.
+func skipInDryRun(opts *options.TerragruntOptions, command string, args []string) bool {
+	if !opts.DryRun {
+		return false
+	}
+
+	if command == opts.TerraformPath {
+		return terraform.IsMutatingCommand(args)
+	}
+
+	return !isGitCommand(command)
+}
+
+// subprocessDir returns the directory the subprocess runs in. If `WorkingDirAbs` is set, the symlinks in the directory
+// are resolved, falling back to the given directory if that fails, e.g. because it doesn't exist yet.
+func subprocessDir(opts *options.TerragruntOptions, dir string) string {
+	if !opts.WorkingDirAbs {
+		return dir
+	}
+
+	resolvedDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		opts.Logger.Debugf("Failed to resolve symlinks in %s: %v", dir, err)
+		return dir
+	}
+
+	return resolvedDir
+}
+
+// createOutputFile creates, or truncates, the file with the given path and its parent directories.
+func createOutputFile(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	return file, nil
+}
+
+// readFileHead returns up to `size` first bytes of the file with the given path.
+func readFileHead(path string, size int64) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", errors.WithStackTrace(err)
+	}
+	defer file.Close() //nolint:errcheck
+
+	content, err := io.ReadAll(io.LimitReader(file, size))
+	if err != nil {
+		return "", errors.WithStackTrace(err)
+	}
+
+	return string(content), nil
+}
+
+// dryRunCommand returns the given command in the form it would be typed in a shell, prefixed with the environment
+// variables that differ from the current process environment.
+func dryRunCommand(envVars []string, command string, args []string) string {
+	var envOverrides []string
+
+	for _, envVar := range envVars {
+		key, value, _ := strings.Cut(envVar, "=")
+
+		if osValue, ok := os.LookupEnv(key); ok && osValue == value {
+			continue
+		}
+
+		envOverrides = append(envOverrides, key+"="+quoteShellArg(value))
+	}
+
+	sort.Strings(envOverrides)
+
+	parts := make([]string, 0, len(envOverrides)+len(args)+1)
+	parts = append(parts, envOverrides...)
+	parts = append(parts, quoteShellArg(command))
+
+	for _, arg := range args {
+		parts = append(parts, quoteShellArg(arg))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// injectedEnvVarNames returns the sorted names of the given env vars that are not inherited unchanged from the current
+// process. The values are never returned, and the names of sensitive env vars, such as `*_TOKEN`, are replaced with
+// `redactedEnvVarName`.
+func injectedEnvVarNames(envVars []string) []string {
+	var names []string
+
+	for _, envVar := range envVars {
+		key, value, _ := strings.Cut(envVar, "=")
+
+		if osValue, ok := os.LookupEnv(key); ok && osValue == value {
+			continue
+		}
+
+		if isSensitiveEnvVarName(key) {
+			key = redactedEnvVarName
+		}
+
+		names = append(names, key)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// isSensitiveEnvVarName returns true if the name of the env var ends with one of `sensitiveEnvVarSuffixes`.
+func isSensitiveEnvVarName(name string) bool {
+	name = strings.ToUpper(name)
+
+	for _, suffix := range sensitiveEnvVarSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// quoteShellArg wraps the given argument in single quotes if it contains characters that are special to the shell.
+func quoteShellArg(arg string) string {
+	if arg != "" && !strings.ContainsAny(arg, " \t\n\"'`$\\|&;<>()*?[]{}!#~") {
+		return arg
+	}
+
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// toEnvVarsList converts the given env vars to the `key=value` list, the values from `envOverrides` take precedence.
+// If `inheritEnv` is true, the list starts from the environment of the current process, which the given env vars override.
+func toEnvVarsList(inheritEnv bool, envVarsAsMap map[string]string, envOverrides map[string]string) []string {
+	envVars := map[string]string{}
+
+	if inheritEnv {
+		envVars = env.Parse(os.Environ())
+	}
+
 	for key, value := range envVarsAsMap {
+		envVars[key] = value
+	}
+
+	for key, value := range envOverrides {
+		envVars[key] = value
+	}
+
+	envVarsAsList := make([]string, 0, len(envVars))
+
+	for key, value := range envVars {
 		envVarsAsList = append(envVarsAsList, fmt.Sprintf("%s=%s", key, value))
 	}
 
@@ -278,43 +1040,98 @@ func toEnvVarsList(envVarsAsMap map[string]string) []string {
 }
 
 // isTerraformCommandThatNeedsPty returns true if the sub command of terraform we are running requires a pty.
-func isTerraformCommandThatNeedsPty(args []string) (bool, error) {
+func isTerraformCommandThatNeedsPty(opts *options.TerragruntOptions, args []string) (bool, error) {
 	if len(args) == 0 || !util.ListContainsElement(terraformCommandsThatNeedPty, args[0]) {
 		return false, nil
 	}
 
-	fi, err := os.Stdin.Stat()
+	// the input is read from the file set by --terragrunt-console-input, so the command is not interactive.
+	if opts.ConsoleInput != "" {
+		return false, nil
+	}
+
+	hasData, err := stdinHasData()
 	if err != nil {
-		return false, errors.WithStackTrace(err)
+		return false, err
 	}
 
 	// if there is data in the stdin, then the terraform console is used in non-interactive mode, for example `echo "1 + 5" | terragrunt console`.
-	if fi.Size() > 0 {
-		return false, nil
-	}
+	return !hasData, nil
+}
 
-	return true, nil
+// stdinHasData returns true if there is data in the stdin. The result is cached for the lifetime of the process,
+// since `os.Stdin.Stat()` can be slow on some virtual filesystem mounts.
+func stdinHasData() (bool, error) {
+	stdinHasDataCache.once.Do(func() {
+		fi, err := os.Stdin.Stat()
+		if err != nil {
+			stdinHasDataCache.err = errors.WithStackTrace(err)
+			return
+		}
+
+		stdinHasDataCache.hasData = fi.Size() > 0
+	})
+
+	return stdinHasDataCache.hasData, stdinHasDataCache.err
 }
 
 type SignalsForwarder chan os.Signal
 
 // NewSignalsForwarder Forwards signals to a command, waiting for the command to finish.
 func NewSignalsForwarder(signals []os.Signal, c *exec.Cmd, logger log.Logger, cmdChannel chan error) SignalsForwarder {
+	return NewSignalsForwarderWithDelays(signals, nil, c, logger, cmdChannel)
+}
+
+// NewSignalsForwarderWithDelays is like NewSignalsForwarder, but waits for the delay of each signal given in `delays`
+// before forwarding it. Signals without a delay are forwarded after `SignalForwardingDelay`.
+func NewSignalsForwarderWithDelays(signals []os.Signal, delays map[os.Signal]time.Duration, c *exec.Cmd, logger log.Logger, cmdChannel chan error) SignalsForwarder {
+	return newSignalsForwarder(signals, delays, 0, c, logger, cmdChannel)
+}
+
+// NewSignalsForwarderWithEscalation is like NewSignalsForwarder, but kills the command if it is still running
+// `escalateAfter` after the first signal was forwarded, for processes that ignore SIGTERM.
+func NewSignalsForwarderWithEscalation(signals []os.Signal, c *exec.Cmd, logger log.Logger, cmdChannel chan error, escalateAfter time.Duration) SignalsForwarder {
+	return newSignalsForwarder(signals, nil, escalateAfter, c, logger, cmdChannel)
+}
+
+// newSignalsForwarder forwards the signals to the command after their delay and, unless `escalateAfter` is zero, kills
+// the command if it is still running `escalateAfter` after the first signal was forwarded.
+func newSignalsForwarder(signals []os.Signal, delays map[os.Signal]time.Duration, escalateAfter time.Duration, c *exec.Cmd, logger log.Logger, cmdChannel chan error) SignalsForwarder {
 	signalChannel := make(chan os.Signal, 1)
 	signal.Notify(signalChannel, signals...)
 
 	go func() {
+		// nil until a signal is forwarded, so that the select below blocks on it
+		var escalate <-chan time.Time
+
 		for {
 			select {
+			case <-escalate:
+				logger.Warnf("%s did not exit %v after the signal was forwarded, killing it.", c.Path, escalateAfter)
+
+				if err := c.Process.Kill(); err != nil {
+					logger.Errorf("Error killing command: %v", err)
+				}
+
+				escalate = nil
 			case s := <-signalChannel:
+				delay, ok := delays[s]
+				if !ok {
+					delay = SignalForwardingDelay
+				}
+
 				select {
-				case <-time.After(SignalForwardingDelay):
+				case <-time.After(delay):
 					logger.Debugf("Forward signal %v to terraform.", s)
 
 					err := c.Process.Signal(s)
 					if err != nil {
 						logger.Errorf("Error forwarding signal: %v", err)
 					}
+
+					if escalateAfter > 0 && escalate == nil {
+						escalate = time.After(escalateAfter)
+					}
 				case <-cmdChannel:
 					return
 				}
@@ -340,74 +1157,277 @@ func GitTopLevelDir(ctx context.Context, terragruntOptions *options.TerragruntOp
 	runCache := cache.ContextCache[string](ctx, RunCmdCacheContextKey)
 	cacheKey := "top-level-dir-" + path
 
-	if gitTopLevelDir, found := runCache.Get(ctx, cacheKey); found {
-		return gitTopLevelDir, nil
-	}
+	gitTopLevelDir, cacheHit := runCache.Get(ctx, cacheKey)
+
+	err := telemetry.Telemetry(ctx, terragruntOptions, "git_top_level_dir", map[string]interface{}{
+		"path":      path,
+		"cache_hit": cacheHit,
+	}, func(childCtx context.Context) error {
+		if cacheHit {
+			return nil
+		}
+
+		cmd, err := RunShellCommandAndCapture(childCtx, terragruntOptions, path, "git", "rev-parse", "--show-toplevel")
+		if err != nil {
+			return err
+		}
 
-	stdout := bytes.Buffer{}
-	stderr := bytes.Buffer{}
+		if lines := cmd.Lines(); len(lines) > 0 {
+			gitTopLevelDir = lines[0]
+		}
+		terragruntOptions.Logger.Debugf("git show-toplevel result: \n%v\n%v\n%v\n", cmd.Stdout, cmd.Stderr, gitTopLevelDir)
+		runCache.Put(childCtx, cacheKey, gitTopLevelDir)
 
-	opts, err := options.NewTerragruntOptionsWithConfigPath(path)
+		return nil
+	})
 	if err != nil {
 		return "", err
 	}
 
-	opts.Env = terragruntOptions.Env
-	opts.Writer = &stdout
-	opts.ErrWriter = &stderr
+	return gitTopLevelDir, nil
+}
+
+// GitModifiedPaths returns the paths with uncommitted changes in the git working tree of the passed directory, as
+// reported by `git status --porcelain`. The result is cached per path for the lifetime of the context.
+func GitModifiedPaths(ctx context.Context, opts *options.TerragruntOptions, path string) ([]string, error) {
+	runCache := cache.ContextCache[string](ctx, RunCmdCacheContextKey)
+	cacheKey := "git-status-" + path
+
+	status, cacheHit := runCache.Get(ctx, cacheKey)
+
+	err := telemetry.Telemetry(ctx, opts, "git_status", map[string]interface{}{
+		"path":      path,
+		"cache_hit": cacheHit,
+	}, func(childCtx context.Context) error {
+		if cacheHit {
+			return nil
+		}
+
+		output, err := RunShellCommandAndCapture(childCtx, opts, path, "git", "status", "--porcelain")
+		if err != nil {
+			return err
+		}
+
+		status = output.Stdout
+		runCache.Put(childCtx, cacheKey, status)
 
-	cmd, err := RunShellCommandWithOutput(ctx, opts, path, true, false, "git", "rev-parse", "--show-toplevel")
+		return nil
+	})
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	var paths []string
+
+	for _, line := range strings.Split(status, "\n") {
+		// each line is the two-letter status code, a space and the path
+		if len(line) > gitStatusPathOffset {
+			paths = append(paths, line[gitStatusPathOffset:])
+		}
 	}
 
-	cmdOutput := strings.TrimSpace(cmd.Stdout)
-	terragruntOptions.Logger.Debugf("git show-toplevel result: \n%v\n%v\n%v\n", stdout.String(), stderr.String(), cmdOutput)
-	runCache.Put(ctx, cacheKey, cmdOutput)
+	return paths, nil
+}
+
+// GitDirtyCheck returns true if the git working tree of the passed directory has uncommitted changes.
+func GitDirtyCheck(ctx context.Context, opts *options.TerragruntOptions, path string) (bool, error) {
+	paths, err := GitModifiedPaths(ctx, opts, path)
+	if err != nil {
+		return false, err
+	}
+
+	return len(paths) > 0, nil
+}
+
+// GitTag is a tag of a git repository, with the SHA of the commit it points to.
+type GitTag struct {
+	Name      string
+	CommitSHA string
+}
 
-	return cmdOutput, nil
+// GitRepoTagNames returns the names of the given tags.
+func GitRepoTagNames(tags []GitTag) []string {
+	names := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+
+	return names
 }
 
-// GitRepoTags - fetch git repository tags from passed url
-func GitRepoTags(ctx context.Context, opts *options.TerragruntOptions, gitRepo *url.URL) ([]string, error) {
+// GitRepoTags - fetch git repository tags from passed url. The returned `resolvedRepo` is the url without the `git::`
+// prefix and without credentials, so that it can be logged or used to construct the source url of a tag. The tags are
+// cached per repository for the lifetime of the context.
+func GitRepoTags(ctx context.Context, opts *options.TerragruntOptions, gitRepo *url.URL) (tags []GitTag, resolvedRepo *url.URL, err error) {
 	repoPath := gitRepo.String()
 	// remove git:: part if present
 	repoPath = strings.TrimPrefix(repoPath, gitPrefix)
 
-	stdout := bytes.Buffer{}
-	stderr := bytes.Buffer{}
+	resolvedRepo = scrubGitRepoURL(repoPath, gitRepo)
+
+	// the tags of the remote are listed anyway, so failing to fetch the full history, e.g. offline or without write
+	// access to the working dir, is not fatal
+	if !opts.GitAllowShallow {
+		if err := GitUnshallowIfNeeded(ctx, opts, opts.WorkingDir); err != nil {
+			opts.Logger.Warnf("Failed to fetch the full history of the shallow clone of %s: %v", opts.WorkingDir, err)
+		}
+	}
+
+	lsRemoteOutput, err := gitLsRemote(ctx, opts, repoPath, resolvedRepo, gitLsRemoteTagsFlag, "git_repo_tags")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tags = parseGitLsRemoteTags(util.CmdOutput{Stdout: lsRemoteOutput}.Lines())
+
+	return tags, resolvedRepo, nil
+}
+
+// GitRepoBranches - fetch the names of the branches of the git repository from passed url. Like the tags, the branches
+// are cached per repository for the lifetime of the context.
+func GitRepoBranches(ctx context.Context, opts *options.TerragruntOptions, gitRepo *url.URL) ([]string, error) {
+	repoPath := strings.TrimPrefix(gitRepo.String(), gitPrefix)
 
-	gitOpts, err := options.NewTerragruntOptionsWithConfigPath(opts.WorkingDir)
+	lsRemoteOutput, err := gitLsRemote(ctx, opts, repoPath, scrubGitRepoURL(repoPath, gitRepo), gitLsRemoteHeadsFlag, "git_repo_branches")
 	if err != nil {
 		return nil, err
 	}
 
-	gitOpts.Env = opts.Env
-	gitOpts.Writer = &stdout
-	gitOpts.ErrWriter = &stderr
+	var branches []string
+
+	for _, line := range (util.CmdOutput{Stdout: lsRemoteOutput}).Lines() {
+		fields := strings.Fields(line)
+		if len(fields) < tagSplitPart {
+			continue
+		}
+
+		branches = append(branches, strings.TrimPrefix(fields[1], refsHeads))
+	}
+
+	return branches, nil
+}
+
+// gitLsRemote runs `git ls-remote` with the given flag, `--tags` or `--heads`, on the given repository, retrying over
+// HTTPS if the SSH authentication fails, and returns its output. The output is cached for the lifetime of the context,
+// and the run is reported in the telemetry span with the given name.
+func gitLsRemote(ctx context.Context, opts *options.TerragruntOptions, repoPath string, resolvedRepo *url.URL, refsFlag, spanName string) (string, error) {
+	runCache := cache.ContextCache[string](ctx, RunCmdCacheContextKey)
+	cacheKey := "ls-remote-" + strings.Join(gitLsRemoteArgs(opts, refsFlag, repoPath), " ")
+
+	lsRemoteOutput, cacheHit := runCache.Get(ctx, cacheKey)
 
-	output, err := RunShellCommandWithOutput(ctx, opts, opts.WorkingDir, true, false, "git", "ls-remote", "--tags", repoPath)
+	err := telemetry.Telemetry(ctx, opts, spanName, map[string]interface{}{
+		"repo":      resolvedRepo.String(),
+		"cache_hit": cacheHit,
+	}, func(childCtx context.Context) error {
+		if cacheHit {
+			return nil
+		}
+
+		output, err := RunShellCommandAndCapture(childCtx, opts, opts.WorkingDir, "git", gitLsRemoteArgs(opts, refsFlag, repoPath)...)
+
+		if err != nil && !opts.GitNoSSHFallback && output != nil && util.MatchesAny(gitSSHAuthErrors, output.Stderr) {
+			if httpsRepo := ConvertSSHToHTTPS(resolvedRepo); httpsRepo != nil {
+				opts.Logger.Warnf("Failed to authenticate to %s over SSH, retrying with %s", resolvedRepo, httpsRepo)
+
+				output, err = RunShellCommandAndCapture(childCtx, opts, opts.WorkingDir, "git", gitLsRemoteArgs(opts, refsFlag, httpsRepo.String())...)
+			}
+		}
+
+		if err != nil {
+			return errors.WithStackTrace(err)
+		}
+
+		lsRemoteOutput = output.Stdout
+		runCache.Put(childCtx, cacheKey, lsRemoteOutput)
+
+		return nil
+	})
 	if err != nil {
-		return nil, errors.WithStackTrace(err)
+		return "", err
+	}
+
+	return lsRemoteOutput, nil
+}
+
+// gitLsRemoteArgs returns the args of the `git ls-remote` command that lists the refs of the given repository selected
+// by the given flag, `--tags` or `--heads`.
+func gitLsRemoteArgs(opts *options.TerragruntOptions, refsFlag, repoPath string) []string {
+	args := []string{"ls-remote", refsFlag, repoPath}
+
+	if opts.GitCredentialHelper != "" {
+		args = append([]string{"-c", "credential.helper=" + opts.GitCredentialHelper}, args...)
+	}
+
+	return args
+}
+
+// ConvertSSHToHTTPS returns the HTTPS URL of the given SSH URL of a git repository, without the user and the port,
+// e.g. `https://github.com/org/repo.git` for `ssh://git@github.com/org/repo.git`. Nil is returned if the URL is not an
+// SSH URL.
+func ConvertSSHToHTTPS(u *url.URL) *url.URL {
+	if u == nil || (u.Scheme != "ssh" && u.Scheme != "git+ssh") || u.Hostname() == "" {
+		return nil
 	}
 
-	var tags []string
+	return &url.URL{
+		Scheme:   "https",
+		Host:     u.Hostname(),
+		Path:     u.Path,
+		RawQuery: u.RawQuery,
+	}
+}
 
-	tagLines := strings.Split(output.Stdout, "\n")
+// parseGitLsRemoteTags parses the `<sha> refs/tags/<name>` lines of `git ls-remote --tags`. Annotated tags are listed
+// twice, the `refs/tags/<name>^{}` line has the SHA of the commit, while the other one has the SHA of the tag object.
+func parseGitLsRemoteTags(lines []string) []GitTag {
+	var (
+		tags    []GitTag
+		indexes = make(map[string]int)
+	)
 
-	for _, line := range tagLines {
+	for _, line := range lines {
 		fields := strings.Fields(line)
-		if len(fields) >= tagSplitPart {
-			tags = append(tags, fields[1])
+		if len(fields) < tagSplitPart {
+			continue
 		}
+
+		name, peeled := strings.CutSuffix(strings.TrimPrefix(fields[1], refsTags), peeledTagSuffix)
+
+		if i, ok := indexes[name]; ok {
+			if peeled {
+				tags[i].CommitSHA = fields[0]
+			}
+
+			continue
+		}
+
+		indexes[name] = len(tags)
+		tags = append(tags, GitTag{Name: name, CommitSHA: fields[0]})
+	}
+
+	return tags
+}
+
+// scrubGitRepoURL parses the repo path, without the `git::` prefix, and removes the credentials from it. If the path
+// cannot be parsed, e.g. an scp-like `git@github.com:org/repo.git` path, a copy of `gitRepo` is used instead.
+func scrubGitRepoURL(repoPath string, gitRepo *url.URL) *url.URL {
+	resolvedRepo, err := url.Parse(repoPath)
+	if err != nil {
+		repoCopy := *gitRepo
+		resolvedRepo = &repoCopy
 	}
 
-	return tags, nil
+	resolvedRepo.User = nil
+
+	return resolvedRepo
 }
 
-// GitLastReleaseTag - fetch git repository last release tag
-func GitLastReleaseTag(ctx context.Context, opts *options.TerragruntOptions, gitRepo *url.URL) (string, error) {
-	tags, err := GitRepoTags(ctx, opts, gitRepo)
+// GitLastReleaseTag - fetch git repository last release tag. If `prefix` is not empty, only the tags that start with
+// the prefix, such as `module-name/v1.2.3` for the `module-name/` prefix, are considered.
+func GitLastReleaseTag(ctx context.Context, opts *options.TerragruntOptions, gitRepo *url.URL, prefix string) (string, error) {
+	tags, _, err := GitRepoTags(ctx, opts, gitRepo)
 	if err != nil {
 		return "", err
 	}
@@ -416,12 +1436,17 @@ func GitLastReleaseTag(ctx context.Context, opts *options.TerragruntOptions, git
 		return "", nil
 	}
 
-	return LastReleaseTag(tags), nil
+	if opts.SourceNoPrerelease {
+		return LastStableReleaseTag(GitRepoTagNames(tags), prefix), nil
+	}
+
+	return LastReleaseTag(GitRepoTagNames(tags), prefix), nil
 }
 
-// LastReleaseTag - return last release tag from passed tags slice.
-func LastReleaseTag(tags []string) string {
-	semverTags := extractSemVerTags(tags)
+// LastReleaseTag - return last release tag from passed tags slice. If `prefix` is not empty, only the tags that start
+// with the prefix are compared by the version that follows the prefix.
+func LastReleaseTag(tags []string, prefix string) string {
+	semverTags := ExtractSemVerTags(tagsWithPrefix(tags, prefix))
 	if len(semverTags) == 0 {
 		return ""
 	}
@@ -433,11 +1458,46 @@ func LastReleaseTag(tags []string) string {
 		}
 	}
 
-	return lastVersion.Original()
+	return prefix + lastVersion.Original()
 }
 
-// extractSemVerTags - extract semver tags from passed tags slice.
-func extractSemVerTags(tags []string) []*version.Version {
+// LastStableReleaseTag - return last release tag from passed tags slice, ignoring pre-release tags like `v1.2.0-rc.1`.
+func LastStableReleaseTag(tags []string, prefix string) string {
+	var stableTags []string
+
+	for _, tag := range tagsWithPrefix(tags, prefix) {
+		if v, err := version.NewVersion(strings.TrimPrefix(tag, refsTags)); err == nil && v.Prerelease() == "" {
+			stableTags = append(stableTags, tag)
+		}
+	}
+
+	if tag := LastReleaseTag(stableTags, ""); tag != "" {
+		return prefix + tag
+	}
+
+	return ""
+}
+
+// tagsWithPrefix returns the tags that start with the given prefix, with the `refs/tags/` part and the prefix removed.
+// All the tags are returned as is if the prefix is empty.
+func tagsWithPrefix(tags []string, prefix string) []string {
+	if prefix == "" {
+		return tags
+	}
+
+	var filtered []string
+
+	for _, tag := range tags {
+		if tag = strings.TrimPrefix(tag, refsTags); strings.HasPrefix(tag, prefix) {
+			filtered = append(filtered, strings.TrimPrefix(tag, prefix))
+		}
+	}
+
+	return filtered
+}
+
+// ExtractSemVerTags - extract semver tags from passed tags slice, the `refs/tags/` prefix is ignored and non-semver tags are skipped.
+func ExtractSemVerTags(tags []string) []*version.Version {
 	var semverTags []*version.Version
 
 	for _, tag := range tags {
@@ -451,27 +1511,14 @@ func extractSemVerTags(tags []string) []*version.Version {
 	return semverTags
 }
 
-// shouldForceForwardTFStdout returns true if at least one of the conditions is met, args contains the `-json` flag or the `output` or `state` command.
-func shouldForceForwardTFStdout(args cli.Args) bool {
-	tfCommands := []string{
-		terraform.CommandNameOutput,
-		terraform.CommandNameState,
-		terraform.CommandNameVersion,
-		terraform.CommandNameConsole,
-	}
-
-	tfFlags := []string{
-		terraform.FlagNameJSON,
-		terraform.FlagNameVersion,
-		terraform.FlagNameHelpLong,
-		terraform.FlagNameHelpShort,
-	}
-
-	for _, flag := range tfFlags {
+// shouldForceForwardTFStdout returns true if at least one of the conditions is met, args contains one of the
+// `ForceForwardStdoutFlags` flags, such as `-json`, or the command is one of `ForceForwardStdoutCommands`, such as `output` or `state`.
+func shouldForceForwardTFStdout(opts *options.TerragruntOptions, args cli.Args) bool {
+	for _, flag := range opts.ForceForwardStdoutFlags {
 		if args.Normalize(cli.SingleDashFlag).Contains(flag) {
 			return true
 		}
 	}
 
-	return collections.ListContainsElement(tfCommands, args.CommandName())
+	return collections.ListContainsElement(opts.ForceForwardStdoutCommands, args.CommandName())
 }
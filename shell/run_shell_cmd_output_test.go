@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -97,7 +98,7 @@ func testCommandOutput(t *testing.T, withOptions func(*options.TerragruntOptions
 
 	withOptions(terragruntOptions)
 
-	out, err := shell.RunShellCommandWithOutput(context.Background(), terragruntOptions, "", !allocateStdout, false, "../testdata/test_outputs.sh", "same")
+	out, err := shell.RunShellCommandWithOutput(context.Background(), terragruntOptions, "", !allocateStdout, false, false, "../testdata/test_outputs.sh", "same")
 
 	assert.NotNil(t, out, "Should get output")
 	require.NoError(t, err, "Should have no error")
@@ -150,3 +151,58 @@ func (s *BufferWithLocking) String() string {
 	defer s.mutex.Unlock()
 	return s.buffer.String()
 }
+
+func TestCommandOutputTFLogFile(t *testing.T) {
+	t.Parallel()
+
+	terraformPath := "../testdata/test_tf_log.sh"
+	tfLogFile := filepath.Join(t.TempDir(), "tf.log")
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err, "Unexpected error creating NewTerragruntOptionsForTest: %v", err)
+
+	var allOutputBuffer BufferWithLocking
+	terragruntOptions.Writer = &allOutputBuffer
+	terragruntOptions.ErrWriter = &allOutputBuffer
+	terragruntOptions.TerraformPath = terraformPath
+	terragruntOptions.TFLogFile = tfLogFile
+
+	_, err = shell.RunShellCommandWithOutput(context.Background(), terragruntOptions, "", false, false, false, terraformPath)
+	require.NoError(t, err)
+
+	assert.Contains(t, allOutputBuffer.String(), "TF_LOG: using github.com/zclconf/go-cty v1.14.3")
+	assert.Contains(t, allOutputBuffer.String(), "stderr1")
+
+	tfLog, err := os.ReadFile(tfLogFile)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"TF_LOG: 2024-09-08T13:44:31.229+0300 [DEBUG] using github.com/zclconf/go-cty v1.14.3",
+		"TF_LOG: 2024-09-08T13:44:31.230+0300 [INFO]  Go runtime version: go1.22.1",
+	}, strings.Split(strings.TrimSpace(string(tfLog)), "\n"))
+}
+
+func TestCommandOutputForceForwardStdoutCommands(t *testing.T) {
+	t.Parallel()
+
+	prefix := "PREFIX"
+	terraformPath := "../testdata/test_outputs.sh"
+
+	logFormatter := format.NewFormatter()
+	logFormatter.DisableLogFormatting = true
+
+	testCommandOutput(t, func(terragruntOptions *options.TerragruntOptions) {
+		terragruntOptions.TerraformPath = terraformPath
+		terragruntOptions.ForceForwardStdoutCommands = []string{"same"}
+		terragruntOptions.Logger.SetOptions(log.WithFormatter(logFormatter))
+		terragruntOptions.Logger = terragruntOptions.Logger.WithField(format.PrefixKeyName, prefix)
+	}, func(allOutput string, out *util.CmdOutput) {
+		// both stdout and stderr are forwarded as is, without the log prefix
+		for _, line := range FullOutput {
+			assert.Contains(t, allOutput, line)
+			assert.NotContains(t, allOutput, "msg="+line)
+		}
+
+		assert.Equal(t, Stdout, strings.Split(strings.TrimSpace(out.Stdout), "\n"))
+	}, true)
+}
@@ -3,11 +3,24 @@ package shell_test
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/gruntwork-io/terragrunt/internal/cache"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/pkg/log/format"
 	"github.com/gruntwork-io/terragrunt/shell"
+	"github.com/gruntwork-io/terragrunt/telemetry"
+	"github.com/gruntwork-io/terragrunt/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -70,11 +83,139 @@ func TestLastReleaseTag(t *testing.T) {
 		"refs/tags/v20.1.2",
 		"refs/tags/v0.5.1",
 	}
-	lastTag := shell.LastReleaseTag(tags)
+	lastTag := shell.LastReleaseTag(tags, "")
 	assert.NotEmpty(t, lastTag)
 	assert.Equal(t, "v20.1.2", lastTag)
 }
 
+func TestExtractSemVerTags(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		tags     []string
+		expected []string
+	}{
+		{
+			tags:     []string{"refs/tags/v0.1.0", "refs/tags/v1.2.0-rc.1", "refs/tags/1.0.0"},
+			expected: []string{"v0.1.0", "v1.2.0-rc.1", "1.0.0"},
+		},
+		{
+			tags:     []string{"v0.1.0", "refs/tags/v0.2.0"},
+			expected: []string{"v0.1.0", "v0.2.0"},
+		},
+		{
+			tags:     []string{"latest", "refs/tags/release-candidate", "refs/heads/main", "v1.0.0"},
+			expected: []string{"v1.0.0"},
+		},
+		{
+			tags:     []string{"latest", "stable"},
+			expected: nil,
+		},
+	}
+
+	for i, tt := range tc {
+		tt := tt
+
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			var actual []string
+			for _, ver := range shell.ExtractSemVerTags(tt.tags) {
+				actual = append(actual, ver.Original())
+			}
+
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestLastStableReleaseTag(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		tags     []string
+		expected string
+	}{
+		{
+			tags:     []string{"refs/tags/v1.1.0", "refs/tags/v1.2.0-rc.1", "refs/tags/v1.0.0"},
+			expected: "v1.1.0",
+		},
+		{
+			tags:     []string{"v1.1.0", "v1.2.0", "v1.3.0-beta", "v1.3.0-alpha.2"},
+			expected: "v1.2.0",
+		},
+		{
+			tags:     []string{"refs/tags/v2.0.0-rc.1", "refs/tags/v2.0.0-rc.2"},
+			expected: "",
+		},
+		{
+			tags:     []string{"refs/tags/latest", "refs/tags/v0.1.0", "refs/tags/v0.2.0-rc.1"},
+			expected: "v0.1.0",
+		},
+		{
+			tags:     []string{"refs/tags/v0.9.0", "refs/tags/v0.10.0", "refs/tags/v1.0.0-rc.1"},
+			expected: "v0.10.0",
+		},
+		{
+			tags:     nil,
+			expected: "",
+		},
+	}
+
+	for i, tt := range tc {
+		tt := tt
+
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, shell.LastStableReleaseTag(tt.tags, ""))
+		})
+	}
+}
+
+func TestLastReleaseTagWithPrefix(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		tags         []string
+		prefix       string
+		noPrerelease bool
+		expected     string
+	}{
+		{
+			tags:     []string{"refs/tags/module-a/v1.2.3", "refs/tags/module-b/v2.0.0", "refs/tags/module-a/v1.10.0", "refs/tags/v3.0.0"},
+			prefix:   "module-a/",
+			expected: "module-a/v1.10.0",
+		},
+		{
+			tags:         []string{"module-a/v1.2.3", "module-a/v1.3.0-rc.1", "module-b/v2.0.0"},
+			prefix:       "module-a/",
+			noPrerelease: true,
+			expected:     "module-a/v1.2.3",
+		},
+		{
+			tags:     []string{"refs/tags/module-b/v2.0.0", "refs/tags/v3.0.0"},
+			prefix:   "module-a/",
+			expected: "",
+		},
+	}
+
+	for i, tt := range tc {
+		tt := tt
+
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			if tt.noPrerelease {
+				assert.Equal(t, tt.expected, shell.LastStableReleaseTag(tt.tags, tt.prefix))
+				return
+			}
+
+			assert.Equal(t, tt.expected, shell.LastReleaseTag(tt.tags, tt.prefix))
+		})
+	}
+}
+
 func TestGitLevelTopDirCaching(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -92,3 +233,738 @@ func TestGitLevelTopDirCaching(t *testing.T) {
 	assert.Equal(t, path1, path2)
 	assert.Len(t, c.Cache, 1)
 }
+
+func TestGitTopLevelDirTelemetry(t *testing.T) {
+	// Telemetry is configured globally, so this test must not run in parallel with others.
+	ctx := context.Background()
+
+	var traces bytes.Buffer
+
+	err := telemetry.InitTelemetry(ctx, &telemetry.TelemetryOptions{
+		Vars: map[string]string{
+			"TERRAGRUNT_TELEMETRY_TRACE_EXPORTER": "console",
+		},
+		AppName:   "terragrunt",
+		Writer:    &traces,
+		ErrWriter: io.Discard,
+	})
+	require.NoError(t, err)
+
+	ctx = shell.ContextWithTerraformCommandHook(ctx, nil)
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	_, err = shell.GitTopLevelDir(ctx, terragruntOptions, ".")
+	require.NoError(t, err)
+	_, err = shell.GitTopLevelDir(ctx, terragruntOptions, ".")
+	require.NoError(t, err)
+
+	require.NoError(t, telemetry.ShutdownTelemetry(ctx))
+
+	output := traces.String()
+	assert.Equal(t, 2, strings.Count(output, `"Name":"git_top_level_dir"`))
+	assert.Contains(t, output, `"Key":"cache_hit","Value":{"Type":"BOOL","Value":true}`)
+	assert.Contains(t, output, `"Key":"cache_hit","Value":{"Type":"BOOL","Value":false}`)
+}
+
+func TestGitRepoTagsTelemetry(t *testing.T) {
+	// Telemetry is configured globally, so this test must not run in parallel with others.
+	ctx := context.Background()
+
+	var traces bytes.Buffer
+
+	err := telemetry.InitTelemetry(ctx, &telemetry.TelemetryOptions{
+		Vars: map[string]string{
+			"TERRAGRUNT_TELEMETRY_TRACE_EXPORTER": "console",
+		},
+		AppName:   "terragrunt",
+		Writer:    &traces,
+		ErrWriter: io.Discard,
+	})
+	require.NoError(t, err)
+
+	var calls int
+
+	fake := shell.NewFakeExecutor()
+	fake.Register("git", func(args []string) (*util.CmdOutput, error) {
+		calls++
+		return &util.CmdOutput{Stdout: "0123456789abcdef refs/tags/v0.1.0\n"}, nil
+	})
+
+	ctx = shell.ContextWithTerraformCommandHook(shell.ContextWithShellCommandHook(ctx, fake.Run), nil)
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	terragruntOptions.GitAllowShallow = true
+
+	gitRepo := &url.URL{Scheme: "https", Host: "github.com", Path: "/gruntwork-io/terragrunt.git"}
+
+	for i := 0; i < 2; i++ {
+		tags, _, err := shell.GitRepoTags(ctx, terragruntOptions, gitRepo)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"v0.1.0"}, shell.GitRepoTagNames(tags))
+	}
+
+	require.NoError(t, telemetry.ShutdownTelemetry(ctx))
+
+	// the tags of the second call come from the cache
+	assert.Equal(t, 1, calls)
+
+	output := traces.String()
+	assert.Equal(t, 2, strings.Count(output, `"Name":"git_repo_tags"`))
+	assert.Contains(t, output, `"Key":"cache_hit","Value":{"Type":"BOOL","Value":true}`)
+	assert.Contains(t, output, `"Key":"cache_hit","Value":{"Type":"BOOL","Value":false}`)
+}
+
+func TestGitDirtyCheck(t *testing.T) {
+	t.Parallel()
+
+	repoDir := t.TempDir()
+	require.NoError(t, exec.Command("git", "init", repoDir).Run())
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	ctx := shell.ContextWithTerraformCommandHook(context.Background(), nil)
+
+	dirty, err := shell.GitDirtyCheck(ctx, terragruntOptions, repoDir)
+	require.NoError(t, err)
+	assert.False(t, dirty)
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "main.tf"), []byte{}, 0644))
+
+	// the result is cached for the lifetime of the context
+	dirty, err = shell.GitDirtyCheck(ctx, terragruntOptions, repoDir)
+	require.NoError(t, err)
+	assert.False(t, dirty)
+
+	ctx = shell.ContextWithTerraformCommandHook(context.Background(), nil)
+
+	dirty, err = shell.GitDirtyCheck(ctx, terragruntOptions, repoDir)
+	require.NoError(t, err)
+	assert.True(t, dirty)
+
+	paths, err := shell.GitModifiedPaths(ctx, terragruntOptions, repoDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"main.tf"}, paths)
+}
+
+func TestGitUnshallowIfNeeded(t *testing.T) {
+	t.Parallel()
+
+	git := func(dir string, args ...string) {
+		args = append([]string{"-c", "user.name=terragrunt", "-c", "user.email=terragrunt@example.com"}, args...)
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	repoDir := t.TempDir()
+	git(repoDir, "init")
+	git(repoDir, "commit", "--allow-empty", "-m", "first")
+	git(repoDir, "commit", "--allow-empty", "-m", "second")
+
+	cloneDir := filepath.Join(t.TempDir(), "clone")
+	git(repoDir, "clone", "--depth=1", "file://"+filepath.ToSlash(repoDir), cloneDir)
+
+	shallowFile := filepath.Join(cloneDir, ".git", "shallow")
+	require.FileExists(t, shallowFile)
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	require.NoError(t, shell.GitUnshallowIfNeeded(context.Background(), terragruntOptions, cloneDir))
+	assert.NoFileExists(t, shallowFile)
+
+	// not a git repository
+	require.NoError(t, shell.GitUnshallowIfNeeded(context.Background(), terragruntOptions, t.TempDir()))
+}
+
+func TestGitRepoTagsUnshallowFailure(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(workingDir, ".git"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, ".git", "shallow"), []byte{}, 0644))
+
+	var unshallowed bool
+
+	fake := shell.NewFakeExecutor()
+	fake.Register("git", func(args []string) (*util.CmdOutput, error) {
+		if util.ListContainsElement(args, "--unshallow") {
+			unshallowed = true
+			return &util.CmdOutput{}, errors.New("fatal: unable to access the remote")
+		}
+
+		return &util.CmdOutput{Stdout: "0123456789abcdef refs/tags/v0.1.0\n"}, nil
+	})
+
+	ctx := shell.ContextWithTerraformCommandHook(shell.ContextWithShellCommandHook(context.Background(), fake.Run), nil)
+
+	logs := new(bytes.Buffer)
+
+	formatter := format.NewFormatter()
+	formatter.DisableColors = true
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	terragruntOptions.Logger = log.New(log.WithOutput(logs), log.WithLevel(log.InfoLevel), log.WithFormatter(formatter))
+
+	// the tags are listed anyway when the full history of the working dir can't be fetched
+	tags, _, err := shell.GitRepoTags(ctx, terragruntOptions, &url.URL{Scheme: "https", Host: "github.com", Path: "/gruntwork-io/terragrunt.git"})
+	require.NoError(t, err)
+	assert.True(t, unshallowed)
+	assert.Equal(t, []string{"v0.1.0"}, shell.GitRepoTagNames(tags))
+	assert.Contains(t, logs.String(), "Failed to fetch the full history of the shallow clone")
+}
+
+func TestGitRepoTags(t *testing.T) {
+	t.Parallel()
+
+	git := func(args ...string) string {
+		args = append([]string{"-c", "user.name=terragrunt", "-c", "user.email=terragrunt@example.com"}, args...)
+		out, err := exec.Command("git", args...).CombinedOutput()
+		require.NoError(t, err, string(out))
+
+		return strings.TrimSpace(string(out))
+	}
+
+	repoDir := t.TempDir()
+	git("init", repoDir)
+	git("-C", repoDir, "commit", "--allow-empty", "-m", "first")
+	git("-C", repoDir, "tag", "v0.1.0")
+	git("-C", repoDir, "commit", "--allow-empty", "-m", "second")
+	git("-C", repoDir, "tag", "-a", "v0.2.0", "-m", "release")
+
+	commitSHA := git("-C", repoDir, "rev-parse", "HEAD")
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	tags, _, err := shell.GitRepoTags(context.Background(), terragruntOptions, &url.URL{Scheme: "file", Path: filepath.ToSlash(repoDir)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v0.1.0", "v0.2.0"}, shell.GitRepoTagNames(tags))
+
+	// the SHA of the commit, not the one of the annotated tag object
+	assert.Equal(t, commitSHA, tags[1].CommitSHA)
+}
+
+func TestGitRepoTagsCredentialHelper(t *testing.T) {
+	t.Parallel()
+
+	repoDir := t.TempDir()
+	require.NoError(t, exec.Command("git", "init", "--bare", repoDir).Run())
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	terragruntOptions.CommandAuditLog = filepath.Join(t.TempDir(), "audit.log")
+	terragruntOptions.GitCredentialHelper = "cache --timeout=60"
+
+	tags, resolvedRepo, err := shell.GitRepoTags(context.Background(), terragruntOptions, &url.URL{Scheme: "file", Path: filepath.ToSlash(repoDir)})
+	require.NoError(t, err)
+	assert.Empty(t, tags)
+	assert.Equal(t, filepath.ToSlash(repoDir), resolvedRepo.Path)
+
+	content, err := os.ReadFile(terragruntOptions.CommandAuditLog)
+	require.NoError(t, err)
+
+	var record shell.CommandAuditRecord
+	require.NoError(t, json.Unmarshal(bytes.TrimSpace(content), &record))
+
+	assert.Equal(t, "git", record.Command)
+	require.Len(t, record.Args, 5)
+	assert.Equal(t, []string{"-c", "credential.helper=cache --timeout=60", "ls-remote", "--tags"}, record.Args[:4])
+}
+
+func TestRunShellCommandWithOutputDryRun(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err, "Unexpected error creating NewTerragruntOptionsForTest: %v", err)
+
+	logs := new(bytes.Buffer)
+
+	formatter := format.NewFormatter()
+	formatter.DisableColors = true
+	formatter.DisableLogFormatting = true
+
+	terragruntOptions.Logger = log.New(log.WithOutput(logs), log.WithLevel(log.InfoLevel), log.WithFormatter(formatter))
+	terragruntOptions.DryRun = true
+	terragruntOptions.Env = map[string]string{"TG_DRY_RUN_VAR": "some value"}
+
+	workingDir := t.TempDir()
+	markerFile := filepath.Join(workingDir, "marker")
+
+	out, err := shell.RunShellCommandWithOutput(context.Background(), terragruntOptions, workingDir, false, false, false, "touch", markerFile)
+	require.NoError(t, err)
+
+	assert.Empty(t, out.Stdout)
+	assert.Empty(t, out.Stderr)
+	assert.NoFileExists(t, markerFile)
+	assert.Contains(t, logs.String(), "Dry run: TG_DRY_RUN_VAR='some value' touch "+markerFile+" in "+workingDir)
+}
+
+func TestRunShellCommandWithOutputDryRunReadOnlyCommands(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		args           []string
+		expectedStdout string
+	}{
+		{[]string{"output", "-json"}, "output -json\n"},
+		{[]string{"--version"}, "--version\n"},
+		{[]string{"state", "list"}, "state list\n"},
+		{[]string{"apply", "-auto-approve"}, ""},
+		{[]string{"state", "rm", "null_resource.foo"}, ""},
+	}
+
+	// the "terraform" command prints its args, so the commands that are run can be told apart. `echo` itself can't be
+	// used, since GNU echo prints its own version for `--version`.
+	terraformPath := filepath.Join(t.TempDir(), "terraform")
+	require.NoError(t, os.WriteFile(terraformPath, []byte("#!/bin/sh\necho \"$@\"\n"), 0755))
+
+	for _, tt := range tc {
+		tt := tt
+
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			t.Parallel()
+
+			terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+			require.NoError(t, err)
+
+			terragruntOptions.DryRun = true
+			terragruntOptions.TerraformPath = terraformPath
+
+			out, err := shell.RunShellCommandWithOutput(context.Background(), terragruntOptions, "", true, false, false, terragruntOptions.TerraformPath, tt.args...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStdout, out.Stdout)
+		})
+	}
+}
+
+func TestRunShellCommandWithOutputEnvOverrides(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err, "Unexpected error creating NewTerragruntOptionsForTest: %v", err)
+
+	moduleDir := t.TempDir()
+	otherDir := t.TempDir()
+
+	terragruntOptions.Env = map[string]string{"TG_GLOBAL_VAR": "global", "TG_MODULE_VAR": "global"}
+	terragruntOptions.SetEnvOverrides(moduleDir, map[string]string{"TG_MODULE_VAR": "module"})
+
+	out, err := shell.RunShellCommandWithOutput(context.Background(), terragruntOptions, moduleDir, true, false, false, "sh", "-c", "echo $TG_GLOBAL_VAR $TG_MODULE_VAR")
+	require.NoError(t, err)
+	assert.Equal(t, "global module", strings.TrimSpace(out.Stdout))
+
+	out, err = shell.RunShellCommandWithOutput(context.Background(), terragruntOptions, otherDir, true, false, false, "sh", "-c", "echo $TG_GLOBAL_VAR $TG_MODULE_VAR")
+	require.NoError(t, err)
+	assert.Equal(t, "global global", strings.TrimSpace(out.Stdout))
+}
+
+func TestRunShellCommandWithOutputInheritEnv(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		inheritEnv bool
+		expectPath bool
+	}{
+		{true, true},
+		{false, false},
+	}
+
+	for _, tt := range tc {
+		tt := tt
+
+		t.Run(strconv.FormatBool(tt.inheritEnv), func(t *testing.T) {
+			t.Parallel()
+
+			terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+			require.NoError(t, err)
+
+			terragruntOptions.Env = map[string]string{"TG_INHERIT_ENV_VAR": "value"}
+			terragruntOptions.InheritEnv = tt.inheritEnv
+
+			out, err := shell.RunShellCommandWithOutput(context.Background(), terragruntOptions, "", true, false, false, "env")
+			require.NoError(t, err)
+
+			assert.Contains(t, out.Stdout, "TG_INHERIT_ENV_VAR=value")
+
+			if tt.expectPath {
+				assert.Contains(t, out.Stdout, "PATH=")
+			} else {
+				assert.NotContains(t, out.Stdout, "PATH=")
+			}
+		})
+	}
+}
+
+func TestRunShellCommandWithOutputContextLogFields(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err, "Unexpected error creating NewTerragruntOptionsForTest: %v", err)
+
+	logs := new(bytes.Buffer)
+
+	formatter := format.NewFormatter()
+	formatter.DisableColors = true
+	formatter.DisableLogFormatting = true
+
+	terragruntOptions.Logger = log.New(log.WithOutput(logs), log.WithLevel(log.DebugLevel), log.WithFormatter(formatter))
+
+	ctx := context.Background()
+	ctx = shell.ContextWithModulePath(ctx, "/live/app")
+	ctx = shell.ContextWithRunID(ctx, "abc123")
+	ctx = shell.ContextWithParallelismSlot(ctx, 2)
+
+	assert.Equal(t, log.Fields{
+		shell.ModulePathLogFieldName:      "/live/app",
+		shell.RunIDLogFieldName:           "abc123",
+		shell.ParallelismSlotLogFieldName: 2,
+	}, shell.ContextLogFields(ctx))
+
+	_, err = shell.RunShellCommandWithOutput(ctx, terragruntOptions, "", true, false, false, "echo", "hello")
+	require.NoError(t, err)
+
+	var runningCmdLog string
+
+	for _, line := range strings.Split(logs.String(), "\n") {
+		if strings.Contains(line, "Running command: echo hello") {
+			runningCmdLog = line
+		}
+	}
+
+	assert.Contains(t, runningCmdLog, "modulePath=/live/app")
+	assert.Contains(t, runningCmdLog, "runID=abc123")
+	assert.Contains(t, runningCmdLog, "parallelismSlot=2")
+}
+
+func TestRunShellCommandWithOutputAndEnvCapture(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	logs := new(bytes.Buffer)
+
+	formatter := format.NewFormatter()
+	formatter.DisableColors = true
+	formatter.DisableLogFormatting = true
+
+	terragruntOptions.Logger = log.New(log.WithOutput(logs), log.WithLevel(log.TraceLevel), log.WithFormatter(formatter))
+	terragruntOptions.Env = map[string]string{"TG_TEST_CAPTURED": "value", "GITHUB_TOKEN": "secret"}
+	terragruntOptions.InheritEnv = false
+
+	// the env vars are only captured at trace level
+	terragruntOptions.LogLevel = log.DebugLevel
+
+	_, err = shell.RunShellCommandWithOutputAndEnvCapture(context.Background(), terragruntOptions, "", true, false, false, "echo", "hello")
+	require.NoError(t, err)
+	// the names of the env vars are logged at debug level, but not their values
+	assert.NotContains(t, logs.String(), "TG_TEST_CAPTURED=value")
+	assert.NotContains(t, logs.String(), "Env var of echo")
+
+	terragruntOptions.LogLevel = log.TraceLevel
+
+	_, err = shell.RunShellCommandWithOutputAndEnvCapture(context.Background(), terragruntOptions, "", true, false, false, "echo", "hello")
+	require.NoError(t, err)
+	assert.Contains(t, logs.String(), "Env var of echo")
+	assert.Contains(t, logs.String(), "TG_TEST_CAPTURED=value")
+	assert.Contains(t, logs.String(), "GITHUB_TOKEN=<redacted>")
+	assert.NotContains(t, logs.String(), "secret")
+}
+
+func TestRunShellCommandAndCapture(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err, "Unexpected error creating NewTerragruntOptionsForTest: %v", err)
+
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+
+	terragruntOptions.Writer = stdout
+	terragruntOptions.ErrWriter = stderr
+
+	out, err := shell.RunShellCommandAndCapture(context.Background(), terragruntOptions, "", "sh", "-c", "echo to-stdout; echo to-stderr >&2")
+	require.NoError(t, err)
+
+	assert.Equal(t, "to-stdout", strings.TrimSpace(out.Stdout))
+	assert.Equal(t, "to-stderr", strings.TrimSpace(out.Stderr))
+	assert.Empty(t, stdout.String())
+	assert.Empty(t, stderr.String())
+}
+
+func TestRunShellCommandWithOutputCancelledContext(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	out, err := shell.RunShellCommandWithOutput(ctx, terragruntOptions, "", false, false, false, "echo", "should not run")
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, out)
+}
+
+func TestRunShellCommandWithOutputCommandAuditLog(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	workingDir := t.TempDir()
+	terragruntOptions.CommandAuditLog = filepath.Join(t.TempDir(), "audit.log")
+	terragruntOptions.Env = map[string]string{"TG_AUDIT_SECRET": "do-not-log"}
+	terragruntOptions.InheritEnv = false
+
+	const commands = 10
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < commands; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			_, err := shell.RunShellCommandWithOutput(context.Background(), terragruntOptions, workingDir, true, false, false, "echo", strconv.Itoa(i))
+			assert.NoError(t, err)
+		}(i)
+	}
+
+	wg.Wait()
+
+	content, err := os.ReadFile(terragruntOptions.CommandAuditLog)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "do-not-log")
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, commands)
+
+	for _, line := range lines {
+		var record shell.CommandAuditRecord
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+
+		assert.False(t, record.Timestamp.IsZero())
+		assert.Equal(t, workingDir, record.WorkingDir)
+		assert.Equal(t, "echo", record.Command)
+		assert.Len(t, record.Args, 1)
+		assert.Equal(t, []string{"TG_AUDIT_SECRET"}, record.EnvKeys)
+	}
+}
+
+func TestRunTerraformCommandWithOutputConsoleInput(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err, "Unexpected error creating NewTerragruntOptionsForTest: %v", err)
+
+	tmpDir := t.TempDir()
+
+	// a stand-in for terraform that echoes whatever it reads from stdin
+	terraformPath := filepath.Join(tmpDir, "terraform")
+	require.NoError(t, os.WriteFile(terraformPath, []byte("#!/bin/sh\ncat\n"), 0755))
+
+	consoleInput := filepath.Join(tmpDir, "console-input")
+	require.NoError(t, os.WriteFile(consoleInput, []byte("1 + 1\n"), 0644))
+
+	terragruntOptions.TerraformPath = terraformPath
+	terragruntOptions.WorkingDir = tmpDir
+	terragruntOptions.ConsoleInput = consoleInput
+
+	out, err := shell.RunTerraformCommandWithOutput(context.Background(), terragruntOptions, "console")
+	require.NoError(t, err)
+	assert.Equal(t, "1 + 1\n", out.Stdout)
+}
+
+func TestRunShellCommandWithOutputAuditTrail(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err, "Unexpected error creating NewTerragruntOptionsForTest: %v", err)
+
+	auditTrailFile := filepath.Join(t.TempDir(), "audit-trail.ndjson")
+
+	auditTrailWriter, err := shell.NewFileAuditTrailWriter(auditTrailFile)
+	require.NoError(t, err)
+
+	terragruntOptions.AuditTrailWriter = auditTrailWriter
+
+	_, err = shell.RunShellCommandWithOutput(context.Background(), terragruntOptions, "", true, false, false, "echo", "hello")
+	require.NoError(t, err)
+
+	_, err = shell.RunShellCommandWithOutput(context.Background(), terragruntOptions, "", true, false, false, "sh", "-c", "exit 3")
+	require.Error(t, err)
+
+	require.NoError(t, auditTrailWriter.Close())
+
+	content, err := os.ReadFile(auditTrailFile)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 2)
+
+	var entries [2]shell.AuditTrailEntry
+	for i, line := range lines {
+		require.NoError(t, json.Unmarshal([]byte(line), &entries[i]))
+	}
+
+	assert.Equal(t, "echo", entries[0].Command)
+	assert.Equal(t, []string{"hello"}, entries[0].Args)
+	assert.Equal(t, 0, entries[0].ExitCode)
+	assert.Len(t, entries[0].StdoutSha256, 64)
+
+	assert.Equal(t, "sh", entries[1].Command)
+	assert.Equal(t, 3, entries[1].ExitCode)
+}
+
+func TestRunShellCommandWithOutputSuppressStderr(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err, "Unexpected error creating NewTerragruntOptionsForTest: %v", err)
+
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	terragruntOptions.Writer = stdout
+	terragruntOptions.ErrWriter = stderr
+
+	out, err := shell.RunShellCommandWithOutput(context.Background(), terragruntOptions, "", false, true, false, "sh", "-c", "echo out; echo warning >&2")
+	require.NoError(t, err)
+
+	assert.Equal(t, "out\n", stdout.String())
+	assert.Empty(t, stderr.String())
+	assert.Equal(t, "warning\n", out.Stderr)
+}
+
+func TestRunShellCommandWithOutputDuration(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err, "Unexpected error creating NewTerragruntOptionsForTest: %v", err)
+
+	out, err := shell.RunShellCommandWithOutput(context.Background(), terragruntOptions, "", true, false, false, "echo", "hello")
+	require.NoError(t, err)
+	assert.Positive(t, out.Duration)
+}
+
+func TestRunShellCommandWithOutputCommandOutputDir(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	terragruntOptions.CommandOutputDir = filepath.Join(t.TempDir(), "output")
+
+	const commands = 10
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < commands; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			_, err := shell.RunShellCommandWithOutput(context.Background(), terragruntOptions, "", true, true, false, "sh", "-c", "echo out-"+strconv.Itoa(i)+"; echo err-"+strconv.Itoa(i)+" >&2")
+			assert.NoError(t, err)
+		}(i)
+	}
+
+	wg.Wait()
+
+	entries, err := os.ReadDir(terragruntOptions.CommandOutputDir)
+	require.NoError(t, err)
+	require.Len(t, entries, commands)
+
+	for _, entry := range entries {
+		assert.Regexp(t, `^\d{8}T\d{6}\.\d{3}Z-sh-\d+\.log$`, entry.Name())
+
+		content, err := os.ReadFile(filepath.Join(terragruntOptions.CommandOutputDir, entry.Name()))
+		require.NoError(t, err)
+		assert.Regexp(t, `--- stdout ---\nout-\d+\n\n--- stderr ---\nerr-\d+\n`, string(content))
+	}
+}
+
+func TestRunShellCommandWithEnv(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	terragruntOptions.Env = map[string]string{"TG_BASE_VAR": "base"}
+
+	out, err := shell.RunShellCommandWithEnv(context.Background(), terragruntOptions, map[string]string{"TF_WORKSPACE": "staging"}, "", true, false, "env")
+	require.NoError(t, err)
+
+	assert.Contains(t, out.Stdout, "TG_BASE_VAR=base")
+	assert.Contains(t, out.Stdout, "TF_WORKSPACE=staging")
+	assert.Equal(t, map[string]string{"TG_BASE_VAR": "base"}, terragruntOptions.Env)
+}
+
+func TestRunShellCommandWithEnvOverrides(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	moduleDir := t.TempDir()
+
+	terragruntOptions.Env = map[string]string{"TG_BASE_VAR": "base"}
+	terragruntOptions.SetEnvOverrides(moduleDir, map[string]string{"TF_WORKSPACE": "module"})
+
+	extraEnv := map[string]string{"TF_WORKSPACE": "staging", "TG_BASE_VAR": "extra"}
+
+	// the extra env vars override `Env`, the env overrides of the command dir override both of them
+	out, err := shell.RunShellCommandWithEnv(context.Background(), terragruntOptions, extraEnv, moduleDir, true, false, "sh", "-c", "echo $TG_BASE_VAR $TF_WORKSPACE")
+	require.NoError(t, err)
+	assert.Equal(t, "extra module", strings.TrimSpace(out.Stdout))
+
+	out, err = shell.RunShellCommandWithEnv(context.Background(), terragruntOptions, extraEnv, t.TempDir(), true, false, "sh", "-c", "echo $TG_BASE_VAR $TF_WORKSPACE")
+	require.NoError(t, err)
+	assert.Equal(t, "extra staging", strings.TrimSpace(out.Stdout))
+
+	assert.Equal(t, map[string]string{"TG_BASE_VAR": "base"}, terragruntOptions.Env)
+}
+
+func TestRunShellCommandWithOutputCommandSummary(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	terragruntOptions.CommandSummaryFile = filepath.Join(t.TempDir(), "summary.json")
+
+	_, err = shell.RunShellCommandWithOutput(context.Background(), terragruntOptions, "", true, false, false, "echo", "hello")
+	require.NoError(t, err)
+
+	_, err = shell.RunShellCommandWithOutput(context.Background(), terragruntOptions, "", true, true, false, "sh", "-c", "echo oops >&2; exit 3")
+	require.Error(t, err)
+
+	require.NoError(t, terragruntOptions.CommandSummary.WriteFile(terragruntOptions.CommandSummaryFile))
+
+	content, err := os.ReadFile(terragruntOptions.CommandSummaryFile)
+	require.NoError(t, err)
+
+	var records []options.CommandSummaryRecord
+	require.NoError(t, json.Unmarshal(content, &records))
+	require.Len(t, records, 2)
+
+	assert.Equal(t, "echo", records[0].Command)
+	assert.Equal(t, []string{"hello"}, records[0].Args)
+	assert.Equal(t, terragruntOptions.WorkingDir, records[0].WorkingDir)
+	assert.Equal(t, 0, records[0].ExitCode)
+	assert.Equal(t, len("hello\n"), records[0].StdoutBytes)
+
+	assert.Equal(t, "sh", records[1].Command)
+	assert.Equal(t, 3, records[1].ExitCode)
+	assert.Equal(t, len("oops\n"), records[1].StderrBytes)
+}
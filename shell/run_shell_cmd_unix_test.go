@@ -9,7 +9,9 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strconv"
+	"sync"
 	"syscall"
 	"testing"
 	"time"
@@ -81,6 +83,37 @@ func TestNewSignalsForwarderWaitUnix(t *testing.T) {
 
 }
 
+func TestNewSignalsForwarderWithDelaysUnix(t *testing.T) {
+	// The signal is sent to the test process itself, so this test must not run in parallel with others.
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	cmd := exec.Command("sleep", "30")
+	require.NoError(t, cmd.Start())
+
+	cmdChannel := make(chan error)
+	runChannel := make(chan error)
+
+	delays := map[os.Signal]time.Duration{syscall.SIGTERM: 100 * time.Millisecond}
+
+	signalChannel := shell.NewSignalsForwarderWithDelays([]os.Signal{syscall.SIGTERM}, delays, cmd, terragruntOptions.Logger, cmdChannel)
+	defer signalChannel.Close()
+
+	go func() {
+		runChannel <- cmd.Wait()
+	}()
+
+	start := time.Now()
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGTERM))
+
+	err = <-runChannel
+	cmdChannel <- err
+	require.Error(t, err)
+
+	// much sooner than the default `SignalForwardingDelay`
+	assert.Less(t, time.Since(start), shell.SignalForwardingDelay/2)
+}
+
 // There isn't a proper way to catch interrupts in Windows batch scripts, so this test exists only for Unix
 func TestNewSignalsForwarderMultipleUnix(t *testing.T) {
 	t.Parallel()
@@ -137,7 +170,7 @@ func TestRunShellCommandWithOutputInterrupt(t *testing.T) {
 	expectedWait := 5
 
 	go func() {
-		_, err := shell.RunShellCommandWithOutput(context.Background(), terragruntOptions, "", false, false, "../testdata/test_sigint_wait.sh", strconv.Itoa(expectedWait))
+		_, err := shell.RunShellCommandWithOutput(context.Background(), terragruntOptions, "", false, false, false, "../testdata/test_sigint_wait.sh", strconv.Itoa(expectedWait))
 		errCh <- err
 	}()
 
@@ -148,3 +181,276 @@ func TestRunShellCommandWithOutputInterrupt(t *testing.T) {
 	expectedErr := fmt.Sprintf("[.] exit status %d", expectedWait)
 	assert.EqualError(t, <-errCh, expectedErr)
 }
+
+func TestRunShellCommandWithOutputAndMutex(t *testing.T) {
+	t.Parallel()
+
+	const goroutines = 10
+
+	// each command fails if the directory has already been created by another command running at the same time
+	lockDir := filepath.Join(t.TempDir(), "lock")
+	script := fmt.Sprintf("mkdir %[1]s && sleep 0.05 && rmdir %[1]s", lockDir)
+
+	var (
+		wg   sync.WaitGroup
+		errs = make(chan error, goroutines)
+	)
+
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			terragruntOptions.LockKey = "state-bucket"
+
+			_, err = shell.RunShellCommandWithOutputAndMutex(context.Background(), terragruntOptions, "", true, false, false, "sh", "-c", script)
+			errs <- err
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+}
+
+func TestRunShellCommandWithOutputTerminationHandler(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	handlerCalled := make(chan int, 1)
+
+	terragruntOptions.TerminationHandler = func(cmd *exec.Cmd) error {
+		// the process must still be alive when the handler is called
+		assert.NoError(t, cmd.Process.Signal(syscall.Signal(0)))
+
+		handlerCalled <- cmd.Process.Pid
+
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(time.Second, cancel)
+
+	startedAt := time.Now()
+
+	_, err = shell.RunShellCommandWithOutput(ctx, terragruntOptions, "", true, false, false, "sleep", "30")
+	require.Error(t, err)
+	assert.Less(t, time.Since(startedAt), 10*time.Second)
+
+	select {
+	case pid := <-handlerCalled:
+		assert.NotZero(t, pid)
+	default:
+		t.Fatal("termination handler was not called")
+	}
+}
+
+func TestRunShellCommandWithOutputGitMaxConcurrency(t *testing.T) {
+	t.Parallel()
+
+	const goroutines = 10
+
+	// a fake git that fails if another git is running at the same time
+	tmpDir := t.TempDir()
+	lockDir := filepath.Join(tmpDir, "lock")
+	gitPath := filepath.Join(tmpDir, "git")
+	script := fmt.Sprintf("#!/bin/sh\nmkdir %[1]s && sleep 0.05 && rmdir %[1]s\n", lockDir)
+	require.NoError(t, os.WriteFile(gitPath, []byte(script), 0755))
+
+	var (
+		wg   sync.WaitGroup
+		errs = make(chan error, goroutines)
+	)
+
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			terragruntOptions.GitMaxConcurrency = 1
+
+			_, err = shell.RunShellCommandWithOutput(context.Background(), terragruntOptions, "", true, false, false, gitPath, "ls-remote")
+			errs <- err
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+}
+
+func TestWhichCommand(t *testing.T) {
+	t.Parallel()
+
+	binDir := t.TempDir()
+	binPath := filepath.Join(binDir, "my-tofu")
+	require.NoError(t, os.WriteFile(binPath, []byte("#!/bin/sh\n"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "not-executable"), []byte(""), 0644))
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	terragruntOptions.Env = map[string]string{"PATH": "/does/not/exist:" + binDir}
+
+	path, err := shell.WhichCommand(context.Background(), terragruntOptions, "my-tofu")
+	require.NoError(t, err)
+	assert.Equal(t, binPath, path)
+
+	path, err = shell.WhichCommand(context.Background(), terragruntOptions, binPath)
+	require.NoError(t, err)
+	assert.Equal(t, binPath, path)
+
+	for _, name := range []string{"not-executable", "sh"} {
+		_, err = shell.WhichCommand(context.Background(), terragruntOptions, name)
+
+		var notFoundErr shell.CommandNotFoundError
+		require.True(t, goerrors.As(err, &notFoundErr), name)
+		assert.Equal(t, name, notFoundErr.Name)
+	}
+
+	// without PATH in the env, the PATH of the process is used
+	terragruntOptions.Env = map[string]string{}
+
+	path, err = shell.WhichCommand(context.Background(), terragruntOptions, "sh")
+	require.NoError(t, err)
+	assert.NotEmpty(t, path)
+}
+
+func TestRunTerraformCommandWithOutputMissingBinary(t *testing.T) {
+	t.Parallel()
+
+	newOptions := func() *options.TerragruntOptions {
+		terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+		require.NoError(t, err)
+
+		terragruntOptions.TerraformPath = "my-tofu"
+		terragruntOptions.Env = map[string]string{"PATH": t.TempDir()}
+
+		return terragruntOptions
+	}
+
+	_, err := shell.RunTerraformCommandWithOutput(context.Background(), newOptions(), "plan")
+
+	var notFoundErr shell.CommandNotFoundError
+	require.True(t, goerrors.As(err, &notFoundErr))
+	assert.Equal(t, "my-tofu", notFoundErr.Name)
+
+	// the binary is not needed when the command is mocked
+	terragruntOptions := newOptions()
+	terragruntOptions.CommandMocks = map[string]options.CommandMockFunc{
+		"my-tofu": func(ctx context.Context, command string, args []string) (*util.CmdOutput, error) {
+			return &util.CmdOutput{Stdout: "mocked\n"}, nil
+		},
+	}
+
+	out, err := shell.RunTerraformCommandWithOutput(context.Background(), terragruntOptions, "plan")
+	require.NoError(t, err)
+	assert.Equal(t, "mocked\n", out.Stdout)
+
+	fake := shell.NewFakeExecutor()
+	fake.MustSucceed("my-tofu", "faked\n")
+
+	ctx := shell.ContextWithShellCommandHook(context.Background(), fake.Run)
+
+	out, err = shell.RunTerraformCommandWithOutput(ctx, newOptions(), "plan")
+	require.NoError(t, err)
+	assert.Equal(t, "faked\n", out.Stdout)
+}
+
+func TestRunTerraformCommandWithRetry(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	attemptsFile := filepath.Join(tmpDir, "attempts")
+	scriptPath := filepath.Join(tmpDir, "tofu")
+
+	// fails to acquire the state lock until it runs for the third time, then fails with another error on the fifth one
+	script := fmt.Sprintf(`#!/bin/sh
+echo attempt >> %[1]s
+attempts=$(wc -l < %[1]s)
+if [ "$attempts" -lt 3 ] || [ "$attempts" -eq 4 ]; then
+  echo "Error: Error acquiring the state lock" >&2
+  exit 1
+fi
+if [ "$attempts" -eq 5 ]; then
+  echo "Error: Invalid provider configuration" >&2
+  exit 1
+fi
+`, attemptsFile)
+	require.NoError(t, os.WriteFile(scriptPath, []byte(script), 0755))
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	terragruntOptions.TerraformPath = scriptPath
+	terragruntOptions.RetrySleepInterval = 0
+
+	countAttempts := func() int {
+		content, err := os.ReadFile(attemptsFile)
+		require.NoError(t, err)
+
+		return len(util.CmdOutput{Stdout: string(content)}.Lines())
+	}
+
+	require.NoError(t, shell.RunTerraformCommandWithRetry(context.Background(), terragruntOptions, 3, nil, "apply"))
+	assert.Equal(t, 3, countAttempts())
+
+	// the state lock error of the fourth run is retried, the provider error of the fifth run is not
+	require.Error(t, shell.RunTerraformCommandWithRetry(context.Background(), terragruntOptions, 3, nil, "apply"))
+	assert.Equal(t, 5, countAttempts())
+}
+
+func TestRunShellCommandWithOutputToFile(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	outputDir := t.TempDir()
+	outFile := filepath.Join(outputDir, "out", "stdout.log")
+	errFile := filepath.Join(outputDir, "err", "stderr.log")
+
+	err = shell.RunShellCommandWithOutputToFile(context.Background(), terragruntOptions, "", outFile, errFile, "sh", "-c", "echo out; echo err >&2")
+	require.NoError(t, err)
+
+	stdout, err := os.ReadFile(outFile)
+	require.NoError(t, err)
+	assert.Equal(t, "out\n", string(stdout))
+
+	stderr, err := os.ReadFile(errFile)
+	require.NoError(t, err)
+	assert.Equal(t, "err\n", string(stderr))
+
+	// only the first 4KB of the stderr are kept in the error
+	err = shell.RunShellCommandWithOutputToFile(context.Background(), terragruntOptions, "", outFile, errFile, "sh", "-c", "head -c 10000 /dev/zero | tr '\\0' x >&2; exit 1")
+
+	var execErr util.ProcessExecutionError
+	require.True(t, goerrors.As(err, &execErr))
+	assert.Len(t, execErr.Stderr, 4096)
+
+	stderr, err = os.ReadFile(errFile)
+	require.NoError(t, err)
+	assert.Len(t, stderr, 10000)
+}
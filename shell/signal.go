@@ -4,8 +4,29 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+
+	"github.com/gruntwork-io/terragrunt/options"
 )
 
+// InterruptSignals are the default signals forwarded to the running commands.
+//
+// Deprecated: use `TerragruntOptions.SignalsToForward`, which can be changed per run.
+var InterruptSignals = options.DefaultSignalsToForward
+
+// CatchSignals calls the given callback func `notifyFn` for each of the given signals received from the OS, instead of
+// letting them terminate the process. Unlike `signal.Ignore`, the signals are not ignored by the commands started by
+// the process.
+func CatchSignals(notifyFn func(os.Signal), sigs ...os.Signal) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, sigs...)
+
+	go func() {
+		for sig := range sigCh {
+			notifyFn(sig)
+		}
+	}()
+}
+
 // RegisterSignalHandler registers a handler of interrupt signal from the OS.
 // When signal is receiving, it calls the given callback func `notifyFn`.
 func RegisterSignalHandler(notifyFn func(os.Signal), sigs ...os.Signal) {
@@ -4,8 +4,11 @@
 package shell
 
 import (
-	"os"
+	"os/exec"
 	"syscall"
 )
 
-var InterruptSignals []os.Signal = []os.Signal{syscall.SIGTERM, syscall.SIGINT}
+// terminateCommand asks the command to exit by sending SIGTERM.
+func terminateCommand(cmd *exec.Cmd) error {
+	return cmd.Process.Signal(syscall.SIGTERM)
+}
@@ -0,0 +1,58 @@
+//go:build !windows
+// +build !windows
+
+package shell
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignalsForwarderEscalation(t *testing.T) {
+	// The signal is sent to the test process itself, so this test must not run in parallel with others.
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	// the script ignores SIGTERM, so only the escalation to SIGKILL can stop it
+	cmd := exec.Command("sh", "-c", "trap '' TERM; while true; do sleep 0.1; done")
+	require.NoError(t, cmd.Start())
+
+	cmdChannel := make(chan error)
+	runChannel := make(chan error)
+
+	delays := map[os.Signal]time.Duration{syscall.SIGTERM: 100 * time.Millisecond}
+
+	signalChannel := newSignalsForwarder([]os.Signal{syscall.SIGTERM}, delays, 500*time.Millisecond, cmd, terragruntOptions.Logger, cmdChannel)
+	defer signalChannel.Close()
+
+	go func() {
+		runChannel <- cmd.Wait()
+	}()
+
+	start := time.Now()
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGTERM))
+
+	select {
+	case err = <-runChannel:
+	case <-time.After(10 * time.Second):
+		require.NoError(t, cmd.Process.Kill())
+		t.Fatal("The command was not killed")
+	}
+
+	cmdChannel <- err
+
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr)
+
+	status, ok := exitErr.Sys().(syscall.WaitStatus)
+	require.True(t, ok)
+	assert.Equal(t, syscall.SIGKILL, status.Signal())
+	assert.GreaterOrEqual(t, time.Since(start), 600*time.Millisecond)
+}
@@ -4,7 +4,10 @@
 package shell
 
 import (
-	"os"
+	"os/exec"
 )
 
-var InterruptSignals []os.Signal = []os.Signal{}
+// terminateCommand kills the command, since Windows processes cannot be sent SIGTERM.
+func terminateCommand(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
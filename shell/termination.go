@@ -0,0 +1,64 @@
+package shell
+
+import (
+	"context"
+	goErrors "errors"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// terminateOnCancel terminates the running command if the context is cancelled before the command finishes, calling
+// `opts.TerminationHandler` first. Nothing is done if no handler is set. The returned function must be called once the
+// command has finished, it waits for a handler that is already running.
+func terminateOnCancel(ctx context.Context, opts *options.TerragruntOptions, cmd *exec.Cmd, logger log.Logger) func() {
+	if opts.TerminationHandler == nil {
+		return func() {}
+	}
+
+	finished := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		select {
+		case <-finished:
+			return
+		case <-ctx.Done():
+		}
+
+		runTerminationHandler(opts, cmd, logger)
+
+		logger.Debugf("Context cancelled, terminating %s", cmd.Path)
+
+		if err := terminateCommand(cmd); err != nil && !goErrors.Is(err, os.ErrProcessDone) {
+			logger.Warnf("Error terminating %s: %v", cmd.Path, err)
+		}
+	}()
+
+	return func() {
+		close(finished)
+		<-stopped
+	}
+}
+
+func runTerminationHandler(opts *options.TerragruntOptions, cmd *exec.Cmd, logger log.Logger) {
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- opts.TerminationHandler(cmd)
+	}()
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			logger.Warnf("Termination handler failed: %v", err)
+		}
+	case <-time.After(opts.TerminationHandlerTimeout):
+		logger.Warnf("Termination handler did not finish within %v", opts.TerminationHandlerTimeout)
+	}
+}
@@ -0,0 +1,63 @@
+package shell
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/gruntwork-io/go-commons/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/terraform"
+)
+
+// RunTerraformOutputCommand runs `terraform output -json` in the given working directory and returns the outputs by
+// name. The stdout is forwarded as is rather than through the logger, so that it can be parsed. `ErrNoOutputs` is
+// returned if the module has no outputs.
+func RunTerraformOutputCommand(ctx context.Context, opts *options.TerragruntOptions, workingDir string) (map[string]json.RawMessage, error) {
+	// a shallow copy keeps the working directory of the caller, which `Clone` resets
+	outputOpts := *opts
+	outputOpts.ForwardTFStdout = true
+
+	out, err := RunShellCommandWithOutput(ctx, &outputOpts, workingDir, false, outputOpts.SuppressStderr, false, outputOpts.TerraformPath, terraform.CommandNameOutput, "-json")
+	if err != nil {
+		return nil, err
+	}
+
+	// modules without outputs print `{}`, older versions print nothing
+	stdout := strings.TrimSpace(out.FilterStdout(isNotJSONLine).Stdout)
+	if stdout == "" {
+		return nil, errors.WithStackTrace(ErrNoOutputs)
+	}
+
+	var outputs map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(stdout), &outputs); err != nil {
+		return nil, errors.WithStackTrace(err)
+	}
+
+	if len(outputs) == 0 {
+		return nil, errors.WithStackTrace(ErrNoOutputs)
+	}
+
+	return outputs, nil
+}
+
+// isNotJSONLine returns true if the given line of the indented JSON printed by `terraform output -json` is rather
+// printed by Terraform around the JSON, such as progress messages or warnings.
+func isNotJSONLine(line string) bool {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return false
+	}
+
+	if strings.ContainsAny(line[:1], `{}[]"-0123456789`) {
+		return false
+	}
+
+	for _, literal := range []string{"true", "false", "null"} {
+		if strings.HasPrefix(line, literal) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,51 @@
+// Package shelltesting provides helpers to replace the shell commands run by Terragrunt in tests.
+package shelltesting
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gruntwork-io/go-commons/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/shell"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// RegisterMockCommand replaces the given command, for the duration of the test, with a mock that returns the given
+// stdout and stderr and exits with the given code. Unlike a `shell.FakeExecutor`, which replaces all the commands run
+// with its context, only the given command is replaced, in the `CommandMocks` of the options, and the other commands
+// are run as usual. The command is matched by its name or by the base name of its path. The options cloned from
+// `opts` share its mocks.
+func RegisterMockCommand(t *testing.T, opts *options.TerragruntOptions, command string, stdout, stderr string, exitCode int) {
+	t.Helper()
+
+	if opts.CommandMocks == nil {
+		opts.CommandMocks = map[string]options.CommandMockFunc{}
+	}
+
+	previous, hasPrevious := opts.CommandMocks[command]
+
+	opts.CommandMocks[command] = func(ctx context.Context, command string, args []string) (*util.CmdOutput, error) {
+		output := &util.CmdOutput{Stdout: stdout, Stderr: stderr}
+
+		if exitCode == 0 {
+			return output, nil
+		}
+
+		return output, errors.WithStackTrace(util.ProcessExecutionError{
+			Err:    shell.FakeExitError{ExitCode: exitCode},
+			Stdout: stdout,
+			Stderr: stderr,
+		})
+	}
+
+	// the mock previously registered for the command, if any, is restored
+	t.Cleanup(func() {
+		if hasPrevious {
+			opts.CommandMocks[command] = previous
+			return
+		}
+
+		delete(opts.CommandMocks, command)
+	})
+}
@@ -0,0 +1,58 @@
+package shelltesting_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/shell"
+	shelltesting "github.com/gruntwork-io/terragrunt/shell/testing"
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterMockCommand(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	const mockedCommand = "terragrunt-mocked-command"
+
+	t.Run("mocked", func(t *testing.T) { //nolint:paralleltest
+		shelltesting.RegisterMockCommand(t, terragruntOptions, mockedCommand, "No changes.\n", "", 0)
+		shelltesting.RegisterMockCommand(t, terragruntOptions, "terraform", "", "Error: Invalid provider configuration", 2)
+
+		out, err := shell.RunShellCommandWithOutput(ctx, terragruntOptions, "", true, true, false, "/usr/local/bin/"+mockedCommand, "plan")
+		require.NoError(t, err)
+		assert.Equal(t, "No changes.\n", out.Stdout)
+
+		out, err = shell.RunShellCommandWithOutput(ctx, terragruntOptions, "", true, true, false, "terraform", "plan")
+		require.Error(t, err)
+		assert.Equal(t, "Error: Invalid provider configuration", out.Stderr)
+
+		exitCode, err := util.GetExitCode(err)
+		require.NoError(t, err)
+		assert.Equal(t, 2, exitCode)
+
+		// the options cloned for the modules share the mocks
+		clonedOptions, err := terragruntOptions.Clone(terragruntOptions.TerragruntConfigPath)
+		require.NoError(t, err)
+
+		out, err = shell.RunShellCommandWithOutput(ctx, clonedOptions, "", true, true, false, mockedCommand, "plan")
+		require.NoError(t, err)
+		assert.Equal(t, "No changes.\n", out.Stdout)
+
+		// the other commands are run as usual
+		out, err = shell.RunShellCommandWithOutput(ctx, terragruntOptions, "", true, true, false, "git", "--version")
+		require.NoError(t, err)
+		assert.Contains(t, out.Stdout, "git version")
+	})
+
+	// the mock is removed once the test is done, and the command doesn't exist
+	_, err = shell.RunShellCommandWithOutput(ctx, terragruntOptions, "", true, true, false, mockedCommand, "plan")
+	require.Error(t, err)
+}
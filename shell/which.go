@@ -0,0 +1,68 @@
+package shell
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/gruntwork-io/go-commons/errors"
+	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+const pathEnvName = "PATH"
+
+// WhichCommand returns the full path of the given command, looked up in the `PATH` of `opts.Env`, or in the `PATH`
+// of the process if it is not set there. A name that contains a path separator is checked as is. It allows callers
+// to fail early with a clear error instead of relying on the error of `cmd.Start()`.
+func WhichCommand(ctx context.Context, opts *options.TerragruntOptions, name string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", errors.WithStackTrace(err)
+	}
+
+	if strings.ContainsRune(name, '/') || strings.ContainsRune(name, filepath.Separator) {
+		path, err := exec.LookPath(name)
+		if err != nil {
+			return "", errors.WithStackTrace(CommandNotFoundError{Name: name, Path: filepath.Dir(name)})
+		}
+
+		return path, nil
+	}
+
+	pathEnv, ok := opts.Env[pathEnvName]
+	if !ok {
+		pathEnv = os.Getenv(pathEnvName)
+	}
+
+	for _, dir := range filepath.SplitList(pathEnv) {
+		// relative entries are skipped for the same security reasons as `exec.ErrDot`
+		if !filepath.IsAbs(dir) {
+			continue
+		}
+
+		// `LookPath` checks a path that contains a separator as is, including the Windows executable extensions.
+		if path, err := exec.LookPath(filepath.Join(dir, name)); err == nil {
+			return path, nil
+		}
+	}
+
+	return "", errors.WithStackTrace(CommandNotFoundError{Name: name, Path: pathEnv})
+}
+
+// checkTerraformBinary fails early if the OpenTofu/Terraform binary can't be found. The check is skipped if the
+// command with the given args is not going to run the binary directly, e.g. it is mocked or run by an engine.
+func checkTerraformBinary(ctx context.Context, opts *options.TerragruntOptions, args []string) error {
+	if skipInDryRun(opts, opts.TerraformPath, args) ||
+		TerraformCommandHookFromContext(ctx) != nil ||
+		ShellCommandHookFromContext(ctx) != nil ||
+		commandMock(opts, opts.TerraformPath) != nil ||
+		(opts.Engine != nil && engine.IsEngineEnabled(ctx, opts)) {
+		return nil
+	}
+
+	_, err := WhichCommand(ctx, opts, opts.TerraformPath)
+
+	return err
+}
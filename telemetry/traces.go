@@ -50,6 +50,11 @@ func Trace(ctx context.Context, name string, attrs map[string]interface{}, fn fu
 	return nil
 }
 
+// SetSpanAttributes - add attributes to the span of the context, if any.
+func SetSpanAttributes(ctx context.Context, attrs map[string]interface{}) {
+	trace.SpanFromContext(ctx).SetAttributes(mapToAttributes(attrs)...)
+}
+
 // configureTraceCollection - configure the traces collection
 func configureTraceCollection(ctx context.Context, opts *TelemetryOptions) error {
 	exp, err := NewTraceExporter(ctx, opts)
@@ -73,3 +73,17 @@ func ParseLog(str string) (msg string, ptrTime *time.Time, ptrLevel *log.Level,
 
 	return msg, ptrTime, ptrLevel, nil
 }
+
+// ParseLogFuncWithLevel works like `ParseLogFunc`, but records that are successfully parsed as TF_LOG output
+// are logged with the given `level` instead of the level extracted from the record.
+func ParseLogFuncWithLevel(msgPrefix string, returnError bool, level log.Level) writer.WriterParseFunc {
+	parseFn := ParseLogFunc(msgPrefix, returnError)
+
+	return func(str string) (msg string, ptrTime *time.Time, ptrLevel *log.Level, err error) {
+		if msg, ptrTime, ptrLevel, err = parseFn(str); err != nil || ptrLevel == nil {
+			return msg, ptrTime, ptrLevel, err
+		}
+
+		return msg, ptrTime, &level, nil
+	}
+}
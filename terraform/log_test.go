@@ -0,0 +1,44 @@
+package terraform_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLogFuncWithLevel(t *testing.T) {
+	t.Parallel()
+
+	var (
+		warnLevel  = log.WarnLevel
+		traceLevel = log.TraceLevel
+	)
+
+	tc := []struct {
+		str           string
+		level         log.Level
+		expectedMsg   string
+		expectedLevel *log.Level
+	}{
+		{"2024-09-08T13:44:31.229+0300 [DEBUG] using github.com/zclconf/go-cty v1.14.3", log.WarnLevel, "TF_LOG: using github.com/zclconf/go-cty v1.14.3", &warnLevel},
+		{"2024-09-08T13:44:31.229+0300 [INFO]  Go runtime version: go1.22.1", log.TraceLevel, "TF_LOG: Go runtime version: go1.22.1", &traceLevel},
+		{"Error: Invalid provider configuration", log.DebugLevel, "Error: Invalid provider configuration", nil},
+	}
+
+	for i, tt := range tc {
+		tt := tt
+
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			msg, _, level, err := terraform.ParseLogFuncWithLevel("TF_LOG: ", false, tt.level)(tt.str)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedMsg, msg)
+			assert.Equal(t, tt.expectedLevel, level)
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 var (
 	forcedRegexp     = regexp.MustCompile(`^([A-Za-z0-9]+)::(.+)$`)
 	httpSchemeRegexp = regexp.MustCompile(`(?i)^https?://`)
+	commitSHARegexp  = regexp.MustCompile(`^[0-9a-fA-F]{7,40}$`)
 )
 
 const matchCount = 2
@@ -46,6 +47,31 @@ func (src *Source) String() string {
 	return fmt.Sprintf("Source{CanonicalSourceURL = %v, DownloadDir = %v, WorkingDir = %v, VersionFile = %v}", src.CanonicalSourceURL, src.DownloadDir, src.WorkingDir, src.VersionFile)
 }
 
+// IsGitSource returns true if the source is downloaded with Git.
+func (src Source) IsGitSource() bool {
+	if src.CanonicalSourceURL == nil {
+		return false
+	}
+
+	scheme := src.CanonicalSourceURL.Scheme
+
+	return strings.HasPrefix(scheme, "git::") || scheme == "git" || scheme == "git+ssh"
+}
+
+// GitRef returns the `ref` query parameter of the Git source, which is empty if the source tracks the default branch.
+func (src Source) GitRef() string {
+	if !src.IsGitSource() {
+		return ""
+	}
+
+	return src.CanonicalSourceURL.Query().Get("ref")
+}
+
+// IsCommitSHA returns true if the given Git ref is a commit SHA, full or abbreviated.
+func IsCommitSHA(ref string) bool {
+	return commitSHARegexp.MatchString(ref)
+}
+
 // EncodeSourceVersion encodes a version number for the given source. When calculating a version number, we take the query
 // string of the source URL, calculate its sha1, and base 64 encode it. For remote URLs (e.g. Git URLs), this is
 // based on the assumption that the scheme/host/path of the URL (e.g. git::github.com/foo/bar) identifies the module
@@ -113,3 +113,42 @@ func TestRegressionSupportForGitRemoteCodecommit(t *testing.T) {
 	require.Equal(t, "git::codecommit::ap-northeast-1://my_app_modules", actualRootRepo.String())
 	require.Equal(t, "my-app/modules/main-module", actualModulePath)
 }
+
+func TestSourceGitRef(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		source   string
+		expected string
+	}{
+		{"git::https://github.com/gruntwork-io/repo-name.git//modules/module-name", ""},
+		{"git::https://github.com/gruntwork-io/repo-name.git//modules/module-name?ref=main", "main"},
+		{"git::ssh://git@github.com/gruntwork-io/repo-name.git//modules/module-name?ref=feature/foo", "feature/foo"},
+		{"git::https://github.com/gruntwork-io/repo-name.git//modules/module-name?ref=v0.0.3", "v0.0.3"},
+		{"https://s3-eu-west-1.amazonaws.com/modules/vpc.zip?ref=v0.0.3", ""},
+		{"file:///tmp/modules/vpc", ""},
+	}
+
+	for i, testCase := range testCases {
+		// Save a local copy in scope so all the tests don't run the final item in the loop
+		testCase := testCase
+		t.Run(fmt.Sprintf("testCase-%d", i), func(t *testing.T) {
+			t.Parallel()
+
+			sourceURL, err := terraform.ToSourceURL(testCase.source, os.TempDir())
+			require.NoError(t, err)
+
+			source := terraform.Source{CanonicalSourceURL: sourceURL}
+			assert.Equal(t, testCase.expected, source.GitRef())
+		})
+	}
+}
+
+func TestIsCommitSHA(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, terraform.IsCommitSHA("2b7c4f9a1e3d5c6b8a9f0e1d2c3b4a5f6e7d8c9b"))
+	assert.True(t, terraform.IsCommitSHA("2b7c4f9"))
+	assert.False(t, terraform.IsCommitSHA("v0.0.3"))
+	assert.False(t, terraform.IsCommitSHA("main"))
+}
@@ -1,7 +1,10 @@
 package terraform
 
 import (
+	"strings"
+
 	"github.com/gruntwork-io/go-commons/errors"
+	"github.com/gruntwork-io/terragrunt/util"
 	"github.com/hashicorp/terraform-config-inspect/tfconfig"
 )
 
@@ -12,6 +15,7 @@ const (
 	CommandNamePlan           = "plan"
 	CommandNameApply          = "apply"
 	CommandNameDestroy        = "destroy"
+	CommandNameRefresh        = "refresh"
 	CommandNameValidate       = "validate"
 	CommandNameOutput         = "output"
 	CommandNameProviders      = "providers"
@@ -24,6 +28,7 @@ const (
 	CommandNameForceUnlock    = "force-unlock"
 	CommandNameShow           = "show"
 	CommandNameVersion        = "version"
+	CommandNameGraph          = "graph"
 
 	FlagNameHelpLong  = "-help"
 	FlagNameHelpShort = "-h"
@@ -37,6 +42,7 @@ const (
 	FlagNamePlatform = "-platform"
 
 	EnvNameTFCLIConfigFile                         = "TF_CLI_CONFIG_FILE"
+	EnvNameTFLog                                   = "TF_LOG"
 	EnvNameTFPluginCacheDir                        = "TF_PLUGIN_CACHE_DIR"
 	EnvNameTFPluginCacheMayBreakDependencyLockFile = "TF_PLUGIN_CACHE_MAY_BREAK_DEPENDENCY_LOCK_FILE"
 	EnvNameTFTokenFmt                              = "TF_TOKEN_%s"
@@ -44,10 +50,48 @@ const (
 
 	TerraformLockFile = ".terraform.lock.hcl"
 
-	TerraformPlanFile     = "tfplan.tfplan"
-	TerraformPlanJSONFile = "tfplan.json"
+	TerraformPlanFile          = "tfplan.tfplan"
+	TerraformPlanJSONFile      = "tfplan.json"
+	TerraformSavedPlanJSONFile = "plan.json"
 )
 
+// mutatingCommands are the commands that change the state or the infrastructure.
+var mutatingCommands = []string{
+	CommandNameApply,
+	CommandNameDestroy,
+	CommandNameImport,
+	CommandNameRefresh,
+	CommandNameTaint,
+	CommandNameUntaint,
+	CommandNameForceUnlock,
+}
+
+// mutatingStateCommands are the `state` subcommands that change the state.
+var mutatingStateCommands = []string{"mv", "rm", "push", "replace-provider"}
+
+// IsMutatingCommand returns true if the given args, starting with the command name, run a command that changes the
+// state or the infrastructure, such as `apply` or `state rm`. Read-only commands, such as `output` or `--version`,
+// return false.
+func IsMutatingCommand(args []string) bool {
+	var positional []string
+
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			positional = append(positional, arg)
+		}
+	}
+
+	if len(positional) == 0 {
+		return false
+	}
+
+	if positional[0] == CommandNameState {
+		return len(positional) > 1 && util.ListContainsElement(mutatingStateCommands, positional[1])
+	}
+
+	return util.ListContainsElement(mutatingCommands, positional[0])
+}
+
 // ModuleVariables will return all the variables defined in the downloaded terraform modules, taking into
 // account all the generated sources. This function will return the required and optional variables separately.
 func ModuleVariables(modulePath string) ([]string, []string, error) {
@@ -69,3 +113,13 @@ func ModuleVariables(modulePath string) ([]string, []string, error) {
 
 	return required, optional, nil
 }
+
+// ModuleRequiredVersions returns the `required_version` constraints declared in the `terraform` blocks of the module.
+func ModuleRequiredVersions(modulePath string) ([]string, error) {
+	module, diags := tfconfig.LoadModule(modulePath)
+	if diags.HasErrors() {
+		return nil, errors.WithStackTrace(diags)
+	}
+
+	return module.RequiredCore, nil
+}
@@ -0,0 +1,9 @@
+locals {
+  region="us-east-1"
+      name = "unformatted"
+}
+
+inputs={
+  region=local.region
+  name   =    local.name
+}
@@ -0,0 +1,2 @@
+# Copied over the module as _terragrunt_override.tf by --terragrunt-provider-override-file.
+provider "null" {}
@@ -0,0 +1,7 @@
+terraform {
+  # Keep a copy of the override file to check that it is present while init runs.
+  before_hook "copy_override_file" {
+    commands = ["init"]
+    execute  = ["cp", "_terragrunt_override.tf", "override-during-init.tf.txt"]
+  }
+}
@@ -0,0 +1,3 @@
+output "text" {
+  value = "Hello, World!"
+}
@@ -55,6 +55,17 @@ func TestEngineLocalApply(t *testing.T) {
 	assert.Contains(t, stdout, "Apply complete! Resources: 1 added, 0 changed, 0 destroyed.")
 }
 
+func TestEngineLocalDryRun(t *testing.T) {
+	rootPath := setupLocalEngine(t)
+
+	stdout, stderr, err := runTerragruntCommandWithOutput(t, fmt.Sprintf("terragrunt apply -auto-approve --terragrunt-non-interactive --terragrunt-forward-tf-stdout --terragrunt-dry-run --terragrunt-working-dir %s", rootPath))
+	require.NoError(t, err)
+
+	assert.Contains(t, stderr, "starting plugin:")
+	assert.Contains(t, stderr, "Dry run: engine")
+	assert.NotContains(t, stdout, "Apply complete!")
+}
+
 func TestEngineOpentofu(t *testing.T) {
 	t.Setenv(envVarExperimental, "1")
 
@@ -235,12 +246,11 @@ func setupLocalEngine(t *testing.T) string {
 	tmpEnvPath := copyEnvironment(t, testFixtureLocalEngine)
 	rootPath := util.JoinPath(tmpEnvPath, testFixtureLocalEngine)
 
-	// get pwd
-	pwd, err := os.Getwd()
+	localEngine, err := engine.NewLocalEngine(filepath.Join("..", LocalEngineBinaryPath))
 	require.NoError(t, err)
 
 	copyAndFillMapPlaceholders(t, util.JoinPath(testFixtureLocalEngine, "terragrunt.hcl"), util.JoinPath(rootPath, config.DefaultTerragruntConfigPath), map[string]string{
-		"__engine_source__": pwd + "/../" + LocalEngineBinaryPath,
+		"__engine_source__": localEngine.Source(),
 	})
 	return rootPath
 }
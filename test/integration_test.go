@@ -67,8 +67,10 @@ const (
 	testFixtureGraphDependencies              = "fixtures/graph-dependencies"
 	testFixtureHclfmtDiff                     = "fixtures/hclfmt-diff"
 	testFixtureHclvalidate                    = "fixtures/hclvalidate"
+	testFixtureHclvalidateUnformatted         = "fixtures/hclvalidate-unformatted"
 	testFixtureIamRolesMultipleModules        = "fixtures/read-config/iam_roles_multiple_modules"
 	testFixtureIncludeParent                  = "fixtures/include-parent"
+	testFixtureProviderOverrideFile           = "fixtures/provider-override-file"
 	testFixtureInfoError                      = "fixtures/terragrunt-info-error"
 	testFixtureInitCache                      = "fixtures/init-cache"
 	testFixtureInitError                      = "fixtures/init-error"
@@ -95,6 +97,7 @@ const (
 	testFixtureProviderCacheNetworkMirror     = "fixtures/provider-cache/network-mirror"
 	testFixtureReadConfig                     = "fixtures/read-config"
 	testFixtureRefSource                      = "fixtures/download/remote-ref"
+	testFixtureRequireVersionConstraint       = "fixtures/require-version-constraint"
 	testFixtureSkip                           = "fixtures/skip/"
 	testFixtureSkipDependencies               = "fixtures/skip-dependencies"
 	testFixtureSops                           = "fixtures/sops"
@@ -369,6 +372,78 @@ func TestHclvalidateInvalidConfigPath(t *testing.T) {
 	assert.ElementsMatch(t, expectedPaths, actualPaths)
 }
 
+func TestHclvalidateDoesNotFormatFiles(t *testing.T) {
+	t.Parallel()
+
+	cleanupTerraformFolder(t, testFixtureHclvalidateUnformatted)
+	tmpEnvPath := copyEnvironment(t, testFixtureHclvalidateUnformatted)
+	rootPath := util.JoinPath(tmpEnvPath, testFixtureHclvalidateUnformatted)
+	configPath := util.JoinPath(rootPath, config.DefaultTerragruntConfigPath)
+
+	expected, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+
+	_, _, err = runTerragruntCommandWithOutput(t, "terragrunt hclvalidate --terragrunt-working-dir "+rootPath)
+	require.NoError(t, err)
+
+	actual, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual))
+}
+
+func TestTerragruntProviderOverrideFile(t *testing.T) {
+	t.Parallel()
+
+	cleanupTerraformFolder(t, testFixtureProviderOverrideFile)
+	tmpEnvPath := copyEnvironment(t, testFixtureProviderOverrideFile)
+	rootPath := util.JoinPath(tmpEnvPath, testFixtureProviderOverrideFile)
+
+	expected, err := os.ReadFile(util.JoinPath(rootPath, "overrides", "mock-provider.tf"))
+	require.NoError(t, err)
+
+	runTerragrunt(t, "terragrunt init --terragrunt-non-interactive --terragrunt-provider-override-file overrides/mock-provider.tf --terragrunt-working-dir "+rootPath)
+
+	// the before hook copied the override file while init was running
+	actual, err := os.ReadFile(util.JoinPath(rootPath, "override-during-init.tf.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual))
+
+	assert.NoFileExists(t, util.JoinPath(rootPath, terraform.ProviderOverrideFile))
+}
+
+func TestTerragruntShellCommand(t *testing.T) {
+	t.Parallel()
+
+	cleanupTerraformFolder(t, testFixtureInputs)
+	tmpEnvPath := copyEnvironment(t, testFixtureInputs)
+	rootPath := util.JoinPath(tmpEnvPath, testFixtureInputs)
+
+	stdout := bytes.Buffer{}
+	stderr := bytes.Buffer{}
+
+	err := runTerragruntCommand(t, "terragrunt shell --terragrunt-non-interactive --terragrunt-working-dir "+rootPath+" -- printenv TF_VAR_string", &stdout, &stderr)
+	require.NoError(t, err)
+
+	assert.Equal(t, "string", strings.TrimSpace(stdout.String()))
+}
+
+func TestTerragruntRequireVersionConstraint(t *testing.T) {
+	t.Parallel()
+
+	cleanupTerraformFolder(t, testFixtureRequireVersionConstraint)
+	tmpEnvPath := copyEnvironment(t, testFixtureRequireVersionConstraint)
+	rootPath := util.JoinPath(tmpEnvPath, testFixtureRequireVersionConstraint)
+
+	stdout := bytes.Buffer{}
+	stderr := bytes.Buffer{}
+
+	err := runTerragruntCommand(t, "terragrunt plan --terragrunt-non-interactive --terragrunt-backend-require-version-constraint --terragrunt-working-dir "+rootPath, &stdout, &stderr)
+	require.ErrorIs(t, err, terraform.ErrMissingVersionConstraint)
+
+	// the check runs before auto-init
+	assert.NoDirExists(t, util.JoinPath(rootPath, ".terraform"))
+}
+
 func TestTerragruntProviderCacheMultiplePlatforms(t *testing.T) {
 	t.Parallel()
 
@@ -3224,8 +3299,9 @@ func TestErrorExplaining(t *testing.T) {
 	err := runTerragruntCommand(t, "terragrunt init -no-color --terragrunt-forward-tf-stdout --terragrunt-non-interactive --terragrunt-working-dir "+initTestCase, &stdout, &stderr)
 	require.Error(t, err)
 
-	explanation := shell.ExplainError(err)
-	assert.Contains(t, explanation, "Check your credentials and permissions")
+	// the hint is appended to the error, so it is not explained again
+	assert.Contains(t, err.Error(), "Check your credentials and permissions")
+	assert.Empty(t, shell.ExplainError(err))
 }
 
 func TestExplainingMissingCredentials(t *testing.T) {
@@ -3244,8 +3320,11 @@ func TestExplainingMissingCredentials(t *testing.T) {
 	stderr := bytes.Buffer{}
 
 	err := runTerragruntCommand(t, "terragrunt init -no-color --terragrunt-forward-tf-stdout --terragrunt-non-interactive --terragrunt-working-dir "+initTestCase, &stdout, &stderr)
-	explanation := shell.ExplainError(err)
-	assert.Contains(t, explanation, "Missing AWS credentials")
+	require.Error(t, err)
+
+	// the hint is appended to the error, so it is not explained again
+	assert.Contains(t, err.Error(), "Missing AWS credentials")
+	assert.Empty(t, shell.ExplainError(err))
 }
 
 func TestModulePathInPlanErrorMessage(t *testing.T) {
@@ -3708,6 +3787,38 @@ func TestPlanJsonPlanBinaryRunAll(t *testing.T) {
 
 }
 
+func TestPlanJsonSavedAlongsidePlanBinaryRunAll(t *testing.T) {
+	t.Parallel()
+
+	// create temporary directory for plan files
+	tmpDir := t.TempDir()
+	tmpEnvPath := copyEnvironment(t, testFixtureOutDir)
+	cleanupTerraformFolder(t, tmpEnvPath)
+	testPath := util.JoinPath(tmpEnvPath, testFixtureOutDir)
+
+	// run plan with output directory and saving JSON plans
+	_, _, err := runTerragruntCommandWithOutput(t, fmt.Sprintf("terragrunt run-all plan --terragrunt-non-interactive --terragrunt-log-level debug --terragrunt-working-dir %s --terragrunt-out-dir %s --terragrunt-save-plan-json", testPath, tmpDir))
+	require.NoError(t, err)
+
+	// verify that binary plan files were generated
+	planFiles, err := findFilesWithExtension(tmpDir, ".tfplan")
+	require.NoError(t, err)
+	assert.Len(t, planFiles, 2)
+
+	// verify that json plan files were generated next to the binary plan files
+	for _, planFile := range planFiles {
+		content, err := os.ReadFile(filepath.Join(filepath.Dir(planFile), "plan.json"))
+		require.NoError(t, err)
+
+		var plan map[string]interface{}
+		require.NoError(t, json.Unmarshal(content, &plan))
+
+		resourceChanges, ok := plan["resource_changes"].([]interface{})
+		require.True(t, ok, "plan.json should contain a resource_changes array")
+		assert.NotEmpty(t, resourceChanges)
+	}
+}
+
 func TestTerragruntRunAllPlanAndShow(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,4 @@
+#!/bin/sh
+>&2 echo '2024-09-08T13:44:31.229+0300 [DEBUG] using github.com/zclconf/go-cty v1.14.3'
+>&2 echo 'stderr1'
+>&2 echo '2024-09-08T13:44:31.230+0300 [INFO]  Go runtime version: go1.22.1'
@@ -2,6 +2,7 @@ package util
 
 import (
 	"bytes"
+	"compress/gzip"
 	"crypto/sha256"
 	"encoding/gob"
 	goErrors "errors"
@@ -15,6 +16,7 @@ import (
 
 	"fmt"
 
+	"github.com/bmatcuk/doublestar"
 	"github.com/gruntwork-io/go-commons/errors"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
 	"github.com/mattn/go-zglob"
@@ -132,6 +134,31 @@ func GlobCanonicalPath(basePath string, globPaths ...string) ([]string, error) {
 	return paths, nil
 }
 
+// IsGlobPattern returns true if the given path contains any of the `*`, `?` or `[` glob special characters.
+func IsGlobPattern(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+// MatchesAnyPath returns true if the given canonical path is equal to one of the given canonical paths or matches one
+// of them that is a glob pattern, where `**` matches any number of nested directories, e.g. `/repo/**/experimental/**`.
+func MatchesAnyPath(paths []string, path string) bool {
+	for _, pattern := range paths {
+		if pattern == path {
+			return true
+		}
+
+		if !IsGlobPattern(pattern) {
+			continue
+		}
+
+		if matched, err := doublestar.Match(pattern, path); err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
+
 // CanonicalPaths returns the canonical version of the given paths, relative to the given base path. That is, if a given path is a
 // relative path, assume it is relative to the given base path. A canonical path is an absolute path with all relative
 // components (e.g. "../") fully resolved, which makes it safe to compare paths as strings.
@@ -786,3 +813,57 @@ func FileSHA256(filePath string) ([]byte, error) {
 
 	return hash.Sum(nil), nil
 }
+
+// GzipFile compresses the file at the given source path and writes the result to the destination path.
+func GzipFile(source, destination string) error {
+	srcFile, err := os.Open(source)
+	if err != nil {
+		return errors.WithStackTrace(err)
+	}
+	defer srcFile.Close() //nolint:errcheck
+
+	dstFile, err := os.Create(destination)
+	if err != nil {
+		return errors.WithStackTrace(err)
+	}
+	defer dstFile.Close() //nolint:errcheck
+
+	gzipWriter := gzip.NewWriter(dstFile)
+
+	if _, err := io.Copy(gzipWriter, srcFile); err != nil {
+		return errors.WithStackTrace(err)
+	}
+
+	if err := gzipWriter.Close(); err != nil {
+		return errors.WithStackTrace(err)
+	}
+
+	return errors.WithStackTrace(dstFile.Close())
+}
+
+// GunzipFile decompresses the gzip file at the given source path and writes the result to the destination path.
+func GunzipFile(source, destination string) error {
+	srcFile, err := os.Open(source)
+	if err != nil {
+		return errors.WithStackTrace(err)
+	}
+	defer srcFile.Close() //nolint:errcheck
+
+	gzipReader, err := gzip.NewReader(srcFile)
+	if err != nil {
+		return errors.WithStackTrace(err)
+	}
+	defer gzipReader.Close() //nolint:errcheck
+
+	dstFile, err := os.Create(destination)
+	if err != nil {
+		return errors.WithStackTrace(err)
+	}
+	defer dstFile.Close() //nolint:errcheck
+
+	if _, err := io.Copy(dstFile, gzipReader); err != nil {
+		return errors.WithStackTrace(err)
+	}
+
+	return errors.WithStackTrace(dstFile.Close())
+}
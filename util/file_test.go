@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strconv"
+	"strings"
 	"testing"
 
 	"fmt"
@@ -128,6 +129,36 @@ func TestGlobCanonicalPath(t *testing.T) {
 	}
 }
 
+func TestMatchesAnyPath(t *testing.T) {
+	t.Parallel()
+
+	tc := []struct {
+		paths    []string
+		path     string
+		expected bool
+	}{
+		{[]string{"/repo/live/app"}, "/repo/live/app", true},
+		{[]string{"/repo/live/app"}, "/repo/live/app/child", false},
+		{[]string{"/repo/**/experimental/**"}, "/repo/live/us-east-1/experimental/app", true},
+		{[]string{"/repo/**/experimental/**"}, "/repo/experimental/app", true},
+		{[]string{"/repo/**/experimental/**"}, "/repo/live/stable/app", false},
+		{[]string{"/repo/*/stage-*/app"}, "/repo/live/stage-eu/app", true},
+		{[]string{"/repo/*/stage-*/app"}, "/repo/live/prod-eu/app", false},
+		{[]string{"/repo/live/app-?"}, "/repo/live/app-1", true},
+		{nil, "/repo/live/app", false},
+	}
+
+	for i, tt := range tc {
+		tt := tt
+
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, util.MatchesAnyPath(tt.paths, tt.path))
+		})
+	}
+}
+
 func TestPathContainsHiddenFileOrFolder(t *testing.T) {
 	t.Parallel()
 
@@ -400,3 +431,29 @@ func TestEmptyDir(t *testing.T) {
 		})
 	}
 }
+
+func TestGzipFile(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	planFile := filepath.Join(tmpDir, "tfplan.tfplan")
+	compressedFile := planFile + ".gz"
+	decompressedFile := filepath.Join(tmpDir, "decompressed.tfplan")
+
+	contents := []byte(strings.Repeat("plan contents ", 1024))
+	require.NoError(t, os.WriteFile(planFile, contents, 0644))
+
+	require.NoError(t, util.GzipFile(planFile, compressedFile))
+
+	compressed, err := os.ReadFile(compressedFile)
+	require.NoError(t, err)
+	assert.Less(t, len(compressed), len(contents))
+
+	require.NoError(t, util.GunzipFile(compressedFile, decompressedFile))
+
+	decompressed, err := os.ReadFile(decompressedFile)
+	require.NoError(t, err)
+	assert.Equal(t, contents, decompressed)
+
+	require.Error(t, util.GunzipFile(planFile, decompressedFile))
+}
@@ -4,7 +4,10 @@ import (
 	goErrors "errors"
 	"fmt"
 	"os/exec"
+	"strings"
 	"syscall"
+	"time"
+	"unicode"
 
 	"github.com/gruntwork-io/go-commons/errors"
 	"github.com/hashicorp/go-multierror"
@@ -32,6 +35,70 @@ func IsCommandExecutable(command string, args ...string) bool {
 type CmdOutput struct {
 	Stdout string
 	Stderr string
+	// Duration is the wall-clock time the command took to run.
+	Duration time.Duration
+}
+
+// Lines returns the trimmed non-empty lines of the stdout.
+func (output CmdOutput) Lines() []string {
+	return nonEmptyLines(output.Stdout)
+}
+
+// ErrLines returns the trimmed non-empty lines of the stderr.
+func (output CmdOutput) ErrLines() []string {
+	return nonEmptyLines(output.Stderr)
+}
+
+// Combine returns the stdout and the stderr, with the trailing whitespace trimmed, joined by a single newline. An empty
+// stream is skipped, so that searching both streams doesn't depend on which of them is empty.
+func (output CmdOutput) Combine() string {
+	var parts []string
+
+	for _, str := range []string{output.Stdout, output.Stderr} {
+		if str = strings.TrimRightFunc(str, unicode.IsSpace); str != "" {
+			parts = append(parts, str)
+		}
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+// FilterStdout returns a copy of the output without the stdout lines for which `fn` returns true. The lines are passed
+// to `fn` without their line ending.
+func (output CmdOutput) FilterStdout(fn func(line string) bool) CmdOutput {
+	output.Stdout = removeLines(output.Stdout, fn)
+	return output
+}
+
+// FilterStderr returns a copy of the output without the stderr lines for which `fn` returns true. The lines are passed
+// to `fn` without their line ending.
+func (output CmdOutput) FilterStderr(fn func(line string) bool) CmdOutput {
+	output.Stderr = removeLines(output.Stderr, fn)
+	return output
+}
+
+func removeLines(str string, fn func(line string) bool) string {
+	var sb strings.Builder
+
+	for _, line := range strings.SplitAfter(str, "\n") {
+		if line != "" && !fn(strings.TrimRight(line, "\r\n")) {
+			sb.WriteString(line)
+		}
+	}
+
+	return sb.String()
+}
+
+func nonEmptyLines(str string) []string {
+	var lines []string
+
+	for _, line := range strings.Split(str, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
 }
 
 // GetExitCode returns the exit code of a command. If the error does not
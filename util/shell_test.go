@@ -1,6 +1,7 @@
 package util_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/gruntwork-io/terragrunt/util"
@@ -18,3 +19,65 @@ func TestNotExistingCommand(t *testing.T) {
 
 	assert.False(t, util.IsCommandExecutable("not-existing-command", "--version"))
 }
+
+func TestCmdOutputLines(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		str      string
+		expected []string
+	}{
+		{"", nil},
+		{"\n\n", nil},
+		{"one", []string{"one"}},
+		{"one\ntwo\n", []string{"one", "two"}},
+		{"  one \r\n\n\ttwo\t\n", []string{"one", "two"}},
+	}
+
+	for _, testCase := range testCases {
+		output := util.CmdOutput{Stdout: testCase.str, Stderr: testCase.str}
+
+		assert.Equal(t, testCase.expected, output.Lines(), testCase.str)
+		assert.Equal(t, testCase.expected, output.ErrLines(), testCase.str)
+	}
+}
+
+func TestCmdOutputFilter(t *testing.T) {
+	t.Parallel()
+
+	isProgress := func(line string) bool {
+		return strings.HasSuffix(line, "...")
+	}
+
+	output := util.CmdOutput{
+		Stdout: "Refreshing state...\r\n{\n  \"id\": 1\n}\nReading...",
+		Stderr: "Reading...\nError: Invalid provider configuration\n",
+	}
+
+	filtered := output.FilterStdout(isProgress)
+	assert.Equal(t, "{\n  \"id\": 1\n}\n", filtered.Stdout)
+	assert.Equal(t, output.Stderr, filtered.Stderr)
+
+	filtered = output.FilterStderr(isProgress)
+	assert.Equal(t, output.Stdout, filtered.Stdout)
+	assert.Equal(t, "Error: Invalid provider configuration\n", filtered.Stderr)
+}
+
+func TestCmdOutputCombine(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		output   util.CmdOutput
+		expected string
+	}{
+		{util.CmdOutput{}, ""},
+		{util.CmdOutput{Stdout: "out\n"}, "out"},
+		{util.CmdOutput{Stderr: "err\n"}, "err"},
+		{util.CmdOutput{Stdout: "\n", Stderr: "err\n"}, "err"},
+		{util.CmdOutput{Stdout: "one\ntwo \n", Stderr: "  err\t\n"}, "one\ntwo\n  err"},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, testCase.output.Combine())
+	}
+}
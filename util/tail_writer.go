@@ -0,0 +1,64 @@
+package util
+
+import (
+	"io"
+	"sync"
+)
+
+// TailWriter forwards every write to the given writer, keeping the last `size` bytes written so that they can be read
+// back with `String`. It is safe for concurrent use, and unlike `io.MultiWriter`, the forwarded writer can be
+// retrieved with `UnwrapWriter`, e.g. to check whether the output goes to a terminal.
+type TailWriter struct {
+	writer io.Writer
+	tail   []byte
+	size   int
+	mu     sync.Mutex
+}
+
+// NewTailWriter returns a new TailWriter that forwards its writes to `writer` and keeps their last `size` bytes.
+func NewTailWriter(writer io.Writer, size int) *TailWriter {
+	return &TailWriter{
+		writer: writer,
+		size:   size,
+	}
+}
+
+// Write keeps the tail of `p` and then writes it to the forwarded writer.
+func (tail *TailWriter) Write(p []byte) (int, error) {
+	tail.mu.Lock()
+	defer tail.mu.Unlock()
+
+	tail.tail = append(tail.tail, p...)
+
+	if extra := len(tail.tail) - tail.size; extra > 0 {
+		tail.tail = append(tail.tail[:0], tail.tail[extra:]...)
+	}
+
+	return tail.writer.Write(p)
+}
+
+// String returns the last bytes written.
+func (tail *TailWriter) String() string {
+	tail.mu.Lock()
+	defer tail.mu.Unlock()
+
+	return string(tail.tail)
+}
+
+// Unwrap returns the forwarded writer.
+func (tail *TailWriter) Unwrap() io.Writer {
+	return tail.writer
+}
+
+// UnwrapWriter returns the innermost writer of the given writer, unwrapping the writers that have an
+// `Unwrap() io.Writer` method, such as `TailWriter`.
+func UnwrapWriter(writer io.Writer) io.Writer {
+	for {
+		wrapper, ok := writer.(interface{ Unwrap() io.Writer })
+		if !ok {
+			return writer
+		}
+
+		writer = wrapper.Unwrap()
+	}
+}
@@ -0,0 +1,64 @@
+package util_test
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTailWriter(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	writer := util.NewTailWriter(&buf, 8)
+
+	for _, data := range []string{"first ", "second ", "third"} {
+		n, err := writer.Write([]byte(data))
+		require.NoError(t, err)
+		assert.Len(t, data, n)
+	}
+
+	assert.Equal(t, "first second third", buf.String())
+	assert.Equal(t, "nd third", writer.String())
+}
+
+func TestTailWriterConcurrentWrites(t *testing.T) {
+	t.Parallel()
+
+	writer := util.NewTailWriter(util.NewTailWriter(&bytes.Buffer{}, 0), 100)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				_, err := writer.Write([]byte("0123456789"))
+				assert.NoError(t, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, strings.Repeat("0123456789", 10), writer.String())
+}
+
+func TestUnwrapWriter(t *testing.T) {
+	t.Parallel()
+
+	writer := util.NewTailWriter(util.NewTailWriter(os.Stdout, 10), 10)
+
+	assert.Equal(t, os.Stdout, util.UnwrapWriter(writer))
+	assert.Equal(t, os.Stdout, util.UnwrapWriter(os.Stdout))
+}
@@ -0,0 +1,95 @@
+package util
+
+import (
+	"io"
+	"sync"
+
+	"github.com/gruntwork-io/go-commons/errors"
+)
+
+// TeeBuffer is an in-memory buffer that forwards every write to the given writers, similar to `io.MultiWriter`,
+// while keeping the written data so that it can be read back and rewound with `Seek`.
+type TeeBuffer struct {
+	writers []io.Writer
+	data    []byte
+	offset  int64
+	mu      sync.Mutex
+}
+
+// NewTeeBuffer returns a new TeeBuffer that duplicates its writes to all the given writers.
+func NewTeeBuffer(writers ...io.Writer) *TeeBuffer {
+	return &TeeBuffer{
+		writers: writers,
+	}
+}
+
+// Write appends `p` to the buffer and then writes it to each of the writers in turn.
+func (buf *TeeBuffer) Write(p []byte) (int, error) {
+	buf.mu.Lock()
+	defer buf.mu.Unlock()
+
+	buf.data = append(buf.data, p...)
+
+	for _, writer := range buf.writers {
+		n, err := writer.Write(p)
+		if err != nil {
+			return n, err
+		}
+
+		if n != len(p) {
+			return n, io.ErrShortWrite
+		}
+	}
+
+	return len(p), nil
+}
+
+// Read reads the buffered data starting from the current offset.
+func (buf *TeeBuffer) Read(p []byte) (int, error) {
+	buf.mu.Lock()
+	defer buf.mu.Unlock()
+
+	if buf.offset >= int64(len(buf.data)) {
+		return 0, io.EOF
+	}
+
+	n := copy(p, buf.data[buf.offset:])
+	buf.offset += int64(n)
+
+	return n, nil
+}
+
+// Seek sets the offset for the next Read, interpreted according to `whence` as described in `io.Seeker`.
+func (buf *TeeBuffer) Seek(offset int64, whence int) (int64, error) {
+	buf.mu.Lock()
+	defer buf.mu.Unlock()
+
+	var abs int64
+
+	switch whence {
+	case io.SeekStart:
+		abs = offset
+	case io.SeekCurrent:
+		abs = buf.offset + offset
+	case io.SeekEnd:
+		abs = int64(len(buf.data)) + offset
+	default:
+		return 0, errors.Errorf("invalid whence %d", whence)
+	}
+
+	if abs < 0 {
+		return 0, errors.Errorf("negative position %d", abs)
+	}
+
+	buf.offset = abs
+
+	return abs, nil
+}
+
+// String returns the whole buffered content regardless of the current read offset.
+func (buf *TeeBuffer) String() string {
+	buf.mu.Lock()
+	defer buf.mu.Unlock()
+
+	return string(buf.data)
+}
@@ -0,0 +1,51 @@
+package util_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeeBuffer(t *testing.T) {
+	t.Parallel()
+
+	var first, second bytes.Buffer
+
+	buf := util.NewTeeBuffer(&first, &second)
+
+	_, err := buf.Write([]byte("hello "))
+	require.NoError(t, err)
+
+	_, err = buf.Write([]byte("world"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "hello world", first.String())
+	assert.Equal(t, "hello world", second.String())
+
+	content, err := io.ReadAll(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(content))
+
+	pos, err := buf.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), pos)
+
+	content, err = io.ReadAll(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(content))
+
+	_, err = buf.Seek(-5, io.SeekEnd)
+	require.NoError(t, err)
+
+	content, err = io.ReadAll(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "world", string(content))
+	assert.Equal(t, "hello world", buf.String())
+
+	_, err = buf.Seek(-1, io.SeekStart)
+	require.Error(t, err)
+}
@@ -0,0 +1,34 @@
+package util_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriterNotifier(t *testing.T) {
+	t.Parallel()
+
+	var (
+		buf   bytes.Buffer
+		calls int
+		first []byte
+	)
+
+	writer := util.WriterNotifier(&buf, func(p []byte) {
+		calls++
+		first = append([]byte{}, p...)
+	})
+
+	for _, data := range []string{"", "first", "second", "third"} {
+		_, err := writer.Write([]byte(data))
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, "first", string(first))
+	assert.Equal(t, "firstsecondthird", buf.String())
+}